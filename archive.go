@@ -0,0 +1,299 @@
+package bitbucket
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Archive formats supported by DownloadArchive.
+const (
+	ArchiveZip   = "zip"
+	ArchiveTarGz = "tar.gz"
+)
+
+// DownloadArchive downloads a snapshot of the repository at a commit, branch
+// or tag without cloning it. The caller must close the returned archive, which
+// can be read with WalkArchive or ExtractArchive.
+//
+// Archives are served by the Bitbucket website rather than the API, which
+// redirects to a short-lived download URL. The redirect is followed, and the
+// credentials are only sent to the Bitbucket host.
+func (r *Repository) DownloadArchive(rao *RepositoryArchiveOptions) (io.ReadCloser, error) {
+	if rao.Ref == "" {
+		return nil, fmt.Errorf("a ref is required")
+	}
+	format := rao.Format
+	if format == "" {
+		format = ArchiveZip
+	}
+	if format != ArchiveZip && format != ArchiveTarGz {
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+
+	urlStr := fmt.Sprintf("%s/%s/%s/get/%s.%s", r.c.GetWebBaseURL(),
+		url.PathEscape(rao.Owner), url.PathEscape(rao.RepoSlug), url.PathEscape(rao.Ref), format)
+	return r.c.executeRawWithContext("GET", urlStr, "", rao.ctx)
+}
+
+// ArchiveFile is a file read from a repository archive.
+type ArchiveFile struct {
+	// Path is the slash-separated path of the file in the repository.
+	Path string
+	Mode fs.FileMode
+	// Linkname is the target of a symbolic link.
+	Linkname string
+	// Body reads the content of a regular file. It is only valid until the
+	// walk function returns.
+	Body io.Reader
+}
+
+// WalkArchive calls fn for each regular file and symbolic link of an archive
+// returned by DownloadArchive, in archive order, without writing to disk.
+// Paths are relative to the repository root: the top-level directory
+// Bitbucket wraps archives in is removed. Entries whose path is absolute or
+// escapes the repository root are rejected with an error. An error returned
+// by fn stops the walk and is returned.
+func WalkArchive(r io.Reader, format string, fn func(f *ArchiveFile) error) error {
+	switch format {
+	case ArchiveZip, "":
+		return walkZip(r, fn)
+	case ArchiveTarGz:
+		return walkTarGz(r, fn)
+	}
+	return fmt.Errorf("unsupported archive format %q", format)
+}
+
+func walkZip(r io.Reader, fn func(f *ArchiveFile) error) error {
+	// The zip directory is at the end of the archive, so it is read in memory.
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range zr.File {
+		mode := entry.Mode()
+		if mode.IsDir() {
+			continue
+		}
+		name, ok, err := archivePath(entry.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		f := &ArchiveFile{Path: name, Mode: mode}
+		if mode&fs.ModeSymlink != 0 {
+			target, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+			f.Linkname = string(target)
+		} else {
+			f.Body = rc
+		}
+		err = fn(f)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTarGz(r io.Reader, fn func(f *ArchiveFile) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeSymlink {
+			continue
+		}
+		name, ok, err := archivePath(header.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		f := &ArchiveFile{Path: name, Mode: header.FileInfo().Mode(), Linkname: header.Linkname}
+		if header.Typeflag == tar.TypeReg {
+			f.Body = tr
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+}
+
+// archivePath strips the top-level directory from an archive entry name. It
+// reports false for entries outside of it, like the pax global header.
+func archivePath(name string) (string, bool, error) {
+	_, rest, found := strings.Cut(strings.TrimPrefix(name, "./"), "/")
+	if !found || rest == "" {
+		return "", false, nil
+	}
+	if !filepath.IsLocal(filepath.FromSlash(rest)) || strings.Contains(rest, `\`) {
+		return "", false, fmt.Errorf("archive entry %q escapes the archive root", name)
+	}
+	return rest, true, nil
+}
+
+// ExtractArchive writes the files of an archive returned by DownloadArchive
+// to dir, which is created if needed. Paths are relative to the repository
+// root, see WalkArchive.
+//
+// Files are written through an os.Root, so nothing is written outside of dir.
+// Symbolic links are created once all the files are written, and are rejected
+// when they point outside of dir or when their path or target goes through
+// another symbolic link.
+func ExtractArchive(r io.Reader, format string, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return err
+	}
+	defer root.Close()
+
+	type archiveLink struct{ path, target string }
+	var links []archiveLink
+	err = WalkArchive(r, format, func(f *ArchiveFile) error {
+		if f.Mode&fs.ModeSymlink != 0 {
+			links = append(links, archiveLink{f.Path, f.Linkname})
+			return nil
+		}
+		name := filepath.FromSlash(f.Path)
+		if err := mkdirAllRoot(root, filepath.Dir(name)); err != nil {
+			return err
+		}
+
+		perm := fs.FileMode(0o644)
+		if f.Mode&0o111 != 0 {
+			perm = 0o755
+		}
+		out, err := root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, f.Body); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+	if err != nil {
+		return err
+	}
+
+	isLink := make(map[string]bool, len(links))
+	for _, link := range links {
+		isLink[link.path] = true
+	}
+	for _, link := range links {
+		if err := checkArchiveLink(dir, link.path, link.target, isLink); err != nil {
+			return err
+		}
+		if err := mkdirAllRoot(root, filepath.FromSlash(path.Dir(link.path))); err != nil {
+			return err
+		}
+		if err := os.Symlink(link.target, filepath.Join(dir, filepath.FromSlash(link.path))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkArchiveLink rejects a symbolic link of an archive whose path or target
+// goes through a symbolic link, either from the archive or already in dir, or
+// whose target is outside of dir. Without links along the way, resolving the
+// target lexically is exact.
+func checkArchiveLink(dir, name, target string, isLink map[string]bool) error {
+	throughLink := func(p string) bool {
+		if isLink[p] {
+			return true
+		}
+		info, err := os.Lstat(filepath.Join(dir, filepath.FromSlash(p)))
+		return err == nil && info.Mode()&fs.ModeSymlink != 0
+	}
+
+	for p := path.Dir(name); p != "."; p = path.Dir(p) {
+		if throughLink(p) {
+			return fmt.Errorf("archive entry %q is inside of a symbolic link", name)
+		}
+	}
+	if path.IsAbs(target) || strings.Contains(target, `\`) {
+		return fmt.Errorf("archive entry %q links outside of the archive root", name)
+	}
+	var resolved []string
+	if dirName := path.Dir(name); dirName != "." {
+		resolved = strings.Split(dirName, "/")
+	}
+	components := strings.Split(target, "/")
+	for i, component := range components {
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			if len(resolved) == 0 {
+				return fmt.Errorf("archive entry %q links outside of the archive root", name)
+			}
+			resolved = resolved[:len(resolved)-1]
+			continue
+		}
+		resolved = append(resolved, component)
+		if i < len(components)-1 && throughLink(strings.Join(resolved, "/")) {
+			return fmt.Errorf("archive entry %q links through a symbolic link", name)
+		}
+	}
+	return nil
+}
+
+// mkdirAllRoot creates the directory name of root along with its parents.
+func mkdirAllRoot(root *os.Root, name string) error {
+	if name == "." {
+		return nil
+	}
+	if err := mkdirAllRoot(root, filepath.Dir(name)); err != nil {
+		return err
+	}
+	err := root.Mkdir(name, 0o755)
+	if errors.Is(err, fs.ErrExist) {
+		return nil
+	}
+	return err
+}
@@ -1,38 +1,171 @@
 package bitbucket
 
-import "context"
+import (
+	"context"
+	"io"
+	"iter"
+	"time"
+)
+
+// The interfaces below describe each service of a Client, so code using the
+// client can depend on them and substitute mocks, e.g. from the mockgen package.
 
-type users interface {
+// UsersService looks up other Bitbucket users.
+type UsersService interface {
 	Get(username string) (*User, error)
+	GetWithOptions(uo *UserOptions) (*User, error)
 	Followers(username string) (interface{}, error)
-	Following(username string) (interface{}, error)
-	Repositories(username string) (interface{}, error)
+	Following(t string) (interface{}, error)
+	Repositories(t string) (interface{}, error)
 }
 
-type user interface {
+// UserService reads the profile of the authenticated user.
+type UserService interface {
 	Profile() (*User, error)
+	ProfileWithOptions(uo *UserOptions) (*User, error)
 	Emails() (interface{}, error)
 }
 
-type pullrequests interface {
-	Create(opt PullRequestsOptions) (interface{}, error)
-	Update(opt PullRequestsOptions) (interface{}, error)
-	List(opt PullRequestsOptions) (interface{}, error)
-	Get(opt PullRequestsOptions) (interface{}, error)
-	Activities(opt PullRequestsOptions) (interface{}, error)
-	Activity(opt PullRequestsOptions) (interface{}, error)
-	Commits(opt PullRequestsOptions) (interface{}, error)
-	Patch(opt PullRequestsOptions) (interface{}, error)
-	Diff(opt PullRequestsOptions) (interface{}, error)
-	Merge(opt PullRequestsOptions) (interface{}, error)
-	Decline(opt PullRequestsOptions) (interface{}, error)
-}
-type workspace interface {
-	GetProject(opt ProjectOptions) (*Project, error)
-	CreateProject(opt ProjectOptions) (*Project, error)
+// TeamsService reads teams. Teams are deprecated in favour of workspaces.
+type TeamsService interface {
+	List(role string) (interface{}, error)
+	Profile(teamname string) (interface{}, error)
+	Members(teamname string) (interface{}, error)
+	Followers(teamname string) (interface{}, error)
+	Following(teamname string) (interface{}, error)
+	Repositories(teamname string) (interface{}, error)
+	Projects(teamname string) (interface{}, error)
 }
 
-type issues interface {
+// WorkspaceService manages workspaces and their projects.
+type WorkspaceService interface {
+	GetProject(opt *ProjectOptions) (*Project, error)
+	CreateProject(opt *ProjectOptions) (*Project, error)
+	DeleteProject(opt *ProjectOptions) (interface{}, error)
+	UpdateProject(opt *ProjectOptions) (*Project, error)
+	List() (*WorkspaceList, error)
+	ListWithOptions(wo *WorkspaceOptions) (*WorkspaceList, error)
+	Get(workspace string) (*Workspace, error)
+	GetWithOptions(wo *WorkspaceOptions) (*Workspace, error)
+	Members(teamname string) (*WorkspaceMembers, error)
+	MembersWithOptions(wo *WorkspaceOptions) (*WorkspaceMembers, error)
+	Projects(teamname string) (*ProjectsRes, error)
+	ProjectsWithOptions(wo *WorkspaceOptions) (*ProjectsRes, error)
+	SearchCode(ctx context.Context, workspace string, query string) iter.Seq2[CodeSearchResult, error]
+}
+
+// PermissionService reads the permissions of workspace members.
+type PermissionService interface {
+	GetUserPermissions(organization, member string) (*Permission, error)
+	GetUserPermissionsByUuid(organization, member string) (*Permission, error)
+}
+
+// RepositoriesService lists repositories.
+type RepositoriesService interface {
+	ListForAccount(ro *RepositoriesOptions) (*RepositoriesRes, error)
+	ListForTeam(ro *RepositoriesOptions) (*RepositoriesRes, error)
+	ListProject(ro *RepositoriesOptions) (*RepositoriesRes, error)
+	ListPublic() (*RepositoriesRes, error)
+}
+
+// RepositoryService manages a repository and its branches, files, pipelines configuration, environments and permissions.
+type RepositoryService interface {
+	Create(ro *RepositoryOptions) (*Repository, error)
+	Fork(fo *RepositoryForkOptions) (*Repository, error)
+	Get(ro *RepositoryOptions) (*Repository, error)
+	GetFileContent(ro *RepositoryFilesOptions) ([]byte, error)
+	ListFiles(ro *RepositoryFilesOptions) ([]RepositoryFile, error)
+	WalkTree(ctx context.Context, wo *WalkTreeOptions, fn WalkTreeFunc) error
+	FS(fo *RepositoryFSOptions) (*RepositoryFS, error)
+	FileHistory(fho *FileHistoryOptions) iter.Seq2[RepositoryFile, error]
+	LastCommits(ro *RepositoryFilesOptions) ([]LastCommit, error)
+	MergeBase(co *CompareOptions) (*Commit, error)
+	Compare(co *CompareOptions) (*Comparison, error)
+	ReleaseNotes(rno *ReleaseNotesOptions) (*ReleaseNotes, error)
+	CleanupBranches(bco *BranchCleanupOptions) (*BranchCleanupReport, error)
+	GetFileBlob(ro *RepositoryBlobOptions) (*RepositoryBlob, error)
+	WriteFileBlob(ro *RepositoryBlobWriteOptions) error
+	ListRefs(rbo *RepositoryRefOptions) (*RepositoryRefs, error)
+	ListBranches(rbo *RepositoryBranchOptions) (*RepositoryBranches, error)
+	GetBranch(rbo *RepositoryBranchOptions) (*RepositoryBranch, error)
+	DeleteBranch(rbo *RepositoryBranchDeleteOptions) error
+	CreateBranch(rbo *RepositoryBranchCreationOptions) (*RepositoryBranch, error)
+	ListTags(rbo *RepositoryTagOptions) (*RepositoryTags, error)
+	CreateTag(rbo *RepositoryTagCreationOptions) (*RepositoryTag, error)
+	GetTag(rbo *RepositoryTagOptions) (*RepositoryTag, error)
+	DeleteTag(rbo *RepositoryTagOptions) error
+	FindTagsForCommit(rbo *RepositoryTagOptions, commit string) ([]RepositoryTag, error)
+	Update(ro *RepositoryOptions) (*Repository, error)
+	Delete(ro *RepositoryOptions) (interface{}, error)
+	ListWatchers(ro *RepositoryOptions) (interface{}, error)
+	ListForks(ro *RepositoryOptions) (interface{}, error)
+	ListDefaultReviewers(ro *RepositoryOptions) (*DefaultReviewers, error)
+	GetDefaultReviewer(rdro *RepositoryDefaultReviewerOptions) (*DefaultReviewer, error)
+	AddDefaultReviewer(rdro *RepositoryDefaultReviewerOptions) (*DefaultReviewer, error)
+	DeleteDefaultReviewer(rdro *RepositoryDefaultReviewerOptions) (interface{}, error)
+	ListEffectiveDefaultReviewers(ro *RepositoryOptions) (*EffectiveDefaultReviewers, error)
+	GetPipelineConfig(rpo *RepositoryPipelineOptions) (*Pipeline, error)
+	UpdatePipelineConfig(rpo *RepositoryPipelineOptions) (*Pipeline, error)
+	ListPipelineVariables(opt *RepositoryPipelineVariablesOptions) (*PipelineVariables, error)
+	AddPipelineVariable(rpvo *RepositoryPipelineVariableOptions) (*PipelineVariable, error)
+	DeletePipelineVariable(opt *RepositoryPipelineVariableDeleteOptions) (interface{}, error)
+	GetPipelineVariable(opt *RepositoryPipelineVariableOptions) (*PipelineVariable, error)
+	UpdatePipelineVariable(opt *RepositoryPipelineVariableOptions) (*PipelineVariable, error)
+	GetPipelineKeyPair(rpkpo *RepositoryPipelineKeyPairOptions) (*PipelineKeyPair, error)
+	AddPipelineKeyPair(rpkpo *RepositoryPipelineKeyPairOptions) (*PipelineKeyPair, error)
+	DeletePipelineKeyPair(rpkpo *RepositoryPipelineKeyPairOptions) (interface{}, error)
+	UpdatePipelineBuildNumber(rpbno *RepositoryPipelineBuildNumberOptions) (*PipelineBuildNumber, error)
+	BranchingModel(rbmo *RepositoryBranchingModelOptions) (*BranchingModel, error)
+	ListEnvironments(opt *RepositoryEnvironmentsOptions) (*Environments, error)
+	AddEnvironment(opt *RepositoryEnvironmentOptions) (*Environment, error)
+	DeleteEnvironment(opt *RepositoryEnvironmentDeleteOptions) (interface{}, error)
+	GetEnvironment(opt *RepositoryEnvironmentOptions) (*Environment, error)
+	ListDeploymentVariables(opt *RepositoryDeploymentVariablesOptions) (*DeploymentVariables, error)
+	AddDeploymentVariable(opt *RepositoryDeploymentVariableOptions) (*DeploymentVariable, error)
+	DeleteDeploymentVariable(opt *RepositoryDeploymentVariableDeleteOptions) (interface{}, error)
+	UpdateDeploymentVariable(opt *RepositoryDeploymentVariableOptions) (*DeploymentVariable, error)
+	ListGroupPermissions(ro *RepositoryOptions) (*GroupPermissions, error)
+	SetGroupPermissions(rgo *RepositoryGroupPermissionsOptions) (*GroupPermission, error)
+	DeleteGroupPermissions(rgo *RepositoryGroupPermissionsOptions) (interface{}, error)
+	GetGroupPermissions(rgo *RepositoryGroupPermissionsOptions) (*GroupPermission, error)
+	ListUserPermissions(ro *RepositoryOptions) (*UserPermissions, error)
+	SetUserPermissions(rgo *RepositoryUserPermissionsOptions) (*UserPermission, error)
+	DeleteUserPermissions(rgo *RepositoryUserPermissionsOptions) (interface{}, error)
+	GetUserPermissions(rgo *RepositoryUserPermissionsOptions) (*UserPermission, error)
+	DownloadArchive(rao *RepositoryArchiveOptions) (io.ReadCloser, error)
+	NewCommit(owner, repoSlug string) *CommitBuilder
+}
+
+// PullRequestsService manages pull requests and their comments.
+type PullRequestsService interface {
+	Create(po *PullRequestsOptions) (interface{}, error)
+	Update(po *PullRequestsOptions) (interface{}, error)
+	GetByCommit(po *PullRequestsOptions) (interface{}, error)
+	GetCommits(po *PullRequestsOptions) (interface{}, error)
+	List(po *PullRequestsOptions) (interface{}, error)
+	Gets(po *PullRequestsOptions) (interface{}, error)
+	Get(po *PullRequestsOptions) (interface{}, error)
+	Activities(po *PullRequestsOptions) (interface{}, error)
+	Activity(po *PullRequestsOptions) (interface{}, error)
+	Commits(po *PullRequestsOptions) (interface{}, error)
+	Patch(po *PullRequestsOptions) (interface{}, error)
+	Diff(po *PullRequestsOptions) (interface{}, error)
+	Merge(po *PullRequestsOptions) (interface{}, error)
+	Decline(po *PullRequestsOptions) (interface{}, error)
+	Approve(po *PullRequestsOptions) (interface{}, error)
+	UnApprove(po *PullRequestsOptions) (interface{}, error)
+	RequestChanges(po *PullRequestsOptions) (interface{}, error)
+	UnRequestChanges(po *PullRequestsOptions) (interface{}, error)
+	AddComment(co *PullRequestCommentOptions) (interface{}, error)
+	UpdateComment(co *PullRequestCommentOptions) (interface{}, error)
+	DeleteComment(co *PullRequestCommentOptions) (interface{}, error)
+	GetComments(po *PullRequestsOptions) (interface{}, error)
+	GetComment(po *PullRequestsOptions) (interface{}, error)
+	Statuses(po *PullRequestsOptions) (interface{}, error)
+}
+
+// IssuesService manages issues of the repository issue tracker.
+type IssuesService interface {
 	Gets(io *IssuesOptions) (interface{}, error)
 	Get(io *IssuesOptions) (interface{}, error)
 	Delete(io *IssuesOptions) (interface{}, error)
@@ -54,88 +187,8 @@ type issues interface {
 	GetChange(ico *IssueChangesOptions) (interface{}, error)
 }
 
-type repository interface {
-	Get(opt RepositoryOptions) (*Repository, error)
-	Create(opt RepositoryOptions) (*Repository, error)
-	Delete(opt RepositoryOptions) (interface{}, error)
-	ListWatchers(opt RepositoryOptions) (interface{}, error)
-	ListForks(opt RepositoryOptions) (interface{}, error)
-	ListDefaultReviewers(opt RepositoryOptions) (*DefaultReviewers, error)
-	GetDefaultReviewer(opt RepositoryDefaultReviewerOptions) (*DefaultReviewer, error)
-	AddDefaultReviewer(opt RepositoryDefaultReviewerOptions) (*DefaultReviewer, error)
-	DeleteDefaultReviewer(opt RepositoryDefaultReviewerOptions) (interface{}, error)
-	UpdatePipelineConfig(opt RepositoryPipelineOptions) (*Pipeline, error)
-	ListPipelineVariables(opt RepositoryPipelineVariablesOptions) (*PipelineVariables, error)
-	GetPipelineVariable(opt RepositoryPipelineVariableOptions) (*PipelineVariable, error)
-	AddPipelineVariable(opt RepositoryPipelineVariableOptions) (*PipelineVariable, error)
-	DeletePipelineVariable(opt RepositoryPipelineVariableDeleteOptions) (interface{}, error)
-	UpdatePipelineVariable(opt RepositoryPipelineVariableOptions) (*PipelineVariable, error)
-	AddPipelineKeyPair(opt RepositoryPipelineKeyPairOptions) (*PipelineKeyPair, error)
-	UpdatePipelineBuildNumber(opt RepositoryPipelineBuildNumberOptions) (*PipelineBuildNumber, error)
-	ListFiles(opt RepositoryFilesOptions) (*[]RepositoryFile, error)
-	GetFileBlob(opt RepositoryBlobOptions) (*RepositoryBlob, error)
-	ListBranches(opt RepositoryBranchOptions) (*RepositoryBranches, error)
-	BranchingModel(opt RepositoryBranchingModelOptions) (*BranchingModel, error)
-	ListEnvironments(opt RepositoryEnvironmentsOptions) (*Environments, error)
-	AddEnvironment(opt RepositoryEnvironmentOptions) (*Environment, error)
-	DeleteEnvironment(opt RepositoryEnvironmentDeleteOptions) (interface{}, error)
-	GetEnvironment(opt RepositoryEnvironmentOptions) (*Environment, error)
-	ListDeploymentVariables(opt RepositoryDeploymentVariablesOptions) (*DeploymentVariables, error)
-	AddDeploymentVariable(opt RepositoryDeploymentVariableOptions) (*DeploymentVariable, error)
-	DeleteDeploymentVariable(opt RepositoryDeploymentVariableDeleteOptions) (interface{}, error)
-	UpdateDeploymentVariable(opt RepositoryDeploymentVariableOptions) (*DeploymentVariable, error)
-}
-
-type repositories interface {
-	ListForAccount(opt RepositoriesOptions) (interface{}, error)
-	ListForTeam(opt RepositoriesOptions) (interface{}, error)
-	ListProject(opt RepositoriesOptions) (interface{}, error)
-	ListPublic() (interface{}, error)
-}
-
-type commits interface {
-	GetCommits(opt CommitsOptions) (interface{}, error)
-	GetCommit(opt CommitsOptions) (interface{}, error)
-	GetCommitComments(opt CommitsOptions) (interface{}, error)
-	GetCommitComment(opt CommitsOptions) (interface{}, error)
-	GetCommitStatus(opt CommitsOptions) (interface{}, error)
-	GiveApprove(opt CommitsOptions) (interface{}, error)
-	RemoveApprove(opt CommitsOptions) (interface{}, error)
-	CreateCommitStatus(cmo CommitsOptions, cso CommitStatusOptions) (interface{}, error)
-}
-
-type branchrestrictions interface {
-	Gets(opt BranchRestrictionsOptions) (interface{}, error)
-	Get(opt BranchRestrictionsOptions) (interface{}, error)
-	Create(opt BranchRestrictionsOptions) (interface{}, error)
-	Update(opt BranchRestrictionsOptions) (interface{}, error)
-	Delete(opt BranchRestrictionsOptions) (interface{}, error)
-}
-
-type diff interface {
-	GetDiff(opt DiffOptions) (interface{}, error)
-	GetPatch(opt DiffOptions) (interface{}, error)
-}
-
-type webhooks interface {
-	Gets(opt WebhooksOptions) (interface{}, error)
-	Get(opt WebhooksOptions) (interface{}, error)
-	Create(opt WebhooksOptions) (interface{}, error)
-	Update(opt WebhooksOptions) (interface{}, error)
-	Delete(opt WebhooksOptions) (interface{}, error)
-}
-
-type teams interface {
-	List(role string) (interface{}, error) // [WIP?] role=[admin|contributor|member]
-	Profile(teamname string) (interface{}, error)
-	Members(teamname string) (interface{}, error)
-	Followers(teamname string) (interface{}, error)
-	Following(teamname string) (interface{}, error)
-	Repositories(teamname string) (interface{}, error)
-	Projects(teamname string) (interface{}, error)
-}
-
-type pipelines interface {
+// PipelinesService reads pipeline runs, steps and logs.
+type PipelinesService interface {
 	List(po *PipelinesOptions) (interface{}, error)
 	Get(po *PipelinesOptions) (interface{}, error)
 	ListSteps(po *PipelinesOptions) (interface{}, error)
@@ -143,12 +196,116 @@ type pipelines interface {
 	GetLog(po *PipelinesOptions) (string, error)
 }
 
+// CommitsService reads commits and manages their approvals and build statuses.
+type CommitsService interface {
+	GetCommits(cmo *CommitsOptions) (interface{}, error)
+	GetCommit(cmo *CommitsOptions) (interface{}, error)
+	GetCommitComments(cmo *CommitsOptions) (interface{}, error)
+	GetCommitComment(cmo *CommitsOptions) (interface{}, error)
+	GetCommitStatuses(cmo *CommitsOptions) (interface{}, error)
+	GetCommitStatus(cmo *CommitsOptions, commitStatusKey string) (interface{}, error)
+	GiveApprove(cmo *CommitsOptions) (interface{}, error)
+	RemoveApprove(cmo *CommitsOptions) (interface{}, error)
+	CreateCommitStatus(cmo *CommitsOptions, cso *CommitStatusOptions) (interface{}, error)
+}
+
+// BranchRestrictionsService manages branch restrictions.
+type BranchRestrictionsService interface {
+	Gets(bo *BranchRestrictionsOptions) (interface{}, error)
+	Create(bo *BranchRestrictionsOptions) (*BranchRestrictions, error)
+	Get(bo *BranchRestrictionsOptions) (*BranchRestrictions, error)
+	Update(bo *BranchRestrictionsOptions) (interface{}, error)
+	Delete(bo *BranchRestrictionsOptions) (interface{}, error)
+}
+
+// DiffService reads diffs and diffstats.
+type DiffService interface {
+	GetDiff(do *DiffOptions) (interface{}, error)
+	GetPatch(do *DiffOptions) (interface{}, error)
+	GetDiffStat(dso *DiffStatOptions) (*DiffStatRes, error)
+}
+
+// WebhooksService manages repository webhooks.
+type WebhooksService interface {
+	List(ro *WebhooksOptions) ([]Webhook, error)
+	Gets(ro *WebhooksOptions) (interface{}, error)
+	Create(ro *WebhooksOptions) (*Webhook, error)
+	Get(ro *WebhooksOptions) (*Webhook, error)
+	Update(ro *WebhooksOptions) (*Webhook, error)
+	Delete(ro *WebhooksOptions) (interface{}, error)
+}
+
+// DownloadsService manages repository downloads.
+type DownloadsService interface {
+	Create(do *DownloadsOptions) (interface{}, error)
+	List(do *DownloadsOptions) (interface{}, error)
+}
+
+// DeployKeysService manages repository deploy keys.
+type DeployKeysService interface {
+	Create(opt *DeployKeyOptions) (*DeployKey, error)
+	Get(opt *DeployKeyOptions) (*DeployKey, error)
+	Delete(opt *DeployKeyOptions) (interface{}, error)
+	List(opt *DeployKeyOptions) (*DeployKeysRes, error)
+}
+
+// SSHKeysService manages the SSH keys of a user.
+type SSHKeysService interface {
+	Create(ro *SSHKeyOptions) (*SSHKey, error)
+	Get(ro *SSHKeyOptions) (*SSHKey, error)
+	Delete(ro *SSHKeyOptions) (interface{}, error)
+}
+
+// SnippetsService manages snippets, their revisions and comments.
+type SnippetsService interface {
+	List(so *SnippetOptions) (*SnippetsRes, error)
+	Create(so *SnippetOptions) (*Snippet, error)
+	Get(so *SnippetOptions) (*Snippet, error)
+	Update(so *SnippetOptions) (*Snippet, error)
+	Delete(so *SnippetOptions) error
+	GetFile(so *SnippetOptions) ([]byte, error)
+	ListRevisions(so *SnippetOptions) ([]SnippetCommit, error)
+	GetRevision(so *SnippetOptions) (*SnippetCommit, error)
+	Diff(so *SnippetOptions) (string, error)
+	Patch(so *SnippetOptions) (string, error)
+	ListComments(sco *SnippetCommentOptions) ([]SnippetComment, error)
+	GetComment(sco *SnippetCommentOptions) (*SnippetComment, error)
+	AddComment(sco *SnippetCommentOptions) (*SnippetComment, error)
+	UpdateComment(sco *SnippetCommentOptions) (*SnippetComment, error)
+	DeleteComment(sco *SnippetCommentOptions) error
+	IsWatching(so *SnippetOptions) (bool, error)
+	Watch(so *SnippetOptions) error
+	Unwatch(so *SnippetOptions) error
+}
+
+var (
+	_ UsersService              = (*Users)(nil)
+	_ UserService               = (*User)(nil)
+	_ TeamsService              = (*Teams)(nil)
+	_ WorkspaceService          = (*Workspace)(nil)
+	_ PermissionService         = (*Permission)(nil)
+	_ RepositoriesService       = (*Repositories)(nil)
+	_ RepositoryService         = (*Repository)(nil)
+	_ PullRequestsService       = (*PullRequests)(nil)
+	_ IssuesService             = (*Issues)(nil)
+	_ PipelinesService          = (*Pipelines)(nil)
+	_ CommitsService            = (*Commits)(nil)
+	_ BranchRestrictionsService = (*BranchRestrictions)(nil)
+	_ DiffService               = (*Diff)(nil)
+	_ WebhooksService           = (*Webhooks)(nil)
+	_ DownloadsService          = (*Downloads)(nil)
+	_ DeployKeysService         = (*DeployKeys)(nil)
+	_ SSHKeysService            = (*SSHKeys)(nil)
+	_ SnippetsService           = (*Snippets)(nil)
+)
+
 type RepositoriesOptions struct {
 	Owner   string  `json:"owner"`
 	Project string  `json:"project"`
 	Role    string  `json:"role"` // role=[owner|admin|contributor|member]
 	Page    *int    `json:"page"`
 	Keyword *string `json:"keyword"`
+	Fields  []string
 }
 
 type RepositoryOptions struct {
@@ -164,6 +321,7 @@ type RepositoryOptions struct {
 	HasIssues   string `json:"has_issues"`
 	HasWiki     string `json:"has_wiki"`
 	Project     string `json:"project"`
+	Fields      []string
 	ctx         context.Context
 }
 
@@ -200,6 +358,63 @@ type RepositoryFilesOptions struct {
 	Ref      string `json:"ref"`
 	Path     string `json:"path"`
 	MaxDepth int    `json:"max_depth"`
+	Fields   []string
+	ctx      context.Context
+}
+
+func (fo *RepositoryFilesOptions) WithContext(ctx context.Context) *RepositoryFilesOptions {
+	fo.ctx = ctx
+	return fo
+}
+
+// RepositoryFSOptions configures Repository.FS.
+type RepositoryFSOptions struct {
+	Owner    string `json:"owner"`
+	RepoSlug string `json:"repo_slug"`
+	Ref      string `json:"ref"`
+	// Cache stores file contents, a MemoryCache of DEFAULT_FS_CACHE_ENTRIES
+	// files by default.
+	Cache ResponseCache
+	ctx   context.Context
+}
+
+func (fo *RepositoryFSOptions) WithContext(ctx context.Context) *RepositoryFSOptions {
+	fo.ctx = ctx
+	return fo
+}
+
+type FileHistoryOptions struct {
+	Owner    string `json:"owner"`
+	RepoSlug string `json:"repo_slug"`
+	// Commit is the commit, branch or tag the history starts from.
+	Commit string `json:"commit"`
+	Path   string `json:"path"`
+	// NoRenames stops the history at the commit that renamed the path.
+	NoRenames bool `json:"-"`
+	ctx       context.Context
+}
+
+func (fo *FileHistoryOptions) WithContext(ctx context.Context) *FileHistoryOptions {
+	fo.ctx = ctx
+	return fo
+}
+
+// WalkTreeOptions configures Repository.WalkTree.
+type WalkTreeOptions struct {
+	Owner    string `json:"owner"`
+	RepoSlug string `json:"repo_slug"`
+	Ref      string `json:"ref"`
+	// Root is the directory or file to walk, the repository root by default.
+	Root string `json:"root"`
+	// Include, when set, only visits files matching one of the glob patterns.
+	Include []string
+	// Exclude skips files and directories matching one of the glob patterns.
+	Exclude []string
+	// FetchContents sets TreeEntry.Content for files.
+	FetchContents bool
+	// Concurrency is the number of contents fetched at once,
+	// DEFAULT_WALK_TREE_CONCURRENCY by default.
+	Concurrency int
 }
 
 type RepositoryBlobOptions struct {
@@ -212,6 +427,8 @@ type RepositoryBlobOptions struct {
 type File struct {
 	Path string
 	Name string
+	// Content is uploaded instead of the file at Path when it is not nil.
+	Content []byte
 }
 
 // Based on https://developer.atlassian.com/bitbucket/api/2/reference/resource/repositories/%7Bworkspace%7D/%7Brepo_slug%7D/src#post
@@ -246,6 +463,7 @@ type RepositoryRefOptions struct {
 	MaxDepth  int    `json:"max_depth"`
 	Name      string `json:"name"`
 	BranchFlg bool
+	Fields    []string
 }
 
 type RepositoryBranchOptions struct {
@@ -257,6 +475,7 @@ type RepositoryBranchOptions struct {
 	Pagelen    int    `json:"pagelen"`
 	MaxDepth   int    `json:"max_depth"`
 	BranchName string `json:"branch_name"`
+	Fields     []string
 }
 
 type RepositoryBranchCreationOptions struct {
@@ -271,7 +490,37 @@ type RepositoryBranchDeleteOptions struct {
 	RepoSlug string `json:"repo_slug"`
 	RepoUUID string `json:"uuid"`
 	RefName  string `json:"name"`
-	RefUUID  string `json:"uuid"`
+	RefUUID  string `json:"ref_uuid"`
+	ctx      context.Context
+}
+
+func (rbo *RepositoryBranchDeleteOptions) WithContext(ctx context.Context) *RepositoryBranchDeleteOptions {
+	rbo.ctx = ctx
+	return rbo
+}
+
+type BranchCleanupOptions struct {
+	Owner    string `json:"owner"`
+	RepoSlug string `json:"repo_slug"`
+	// MainBranch is the branch stale branches must be merged into, the
+	// repository's main branch by default.
+	MainBranch string `json:"main_branch"`
+	// OlderThan is the minimum age of the last commit of a stale branch.
+	OlderThan time.Duration `json:"older_than"`
+	// Exclude lists more branch patterns to keep, like branch restrictions
+	// patterns.
+	Exclude []string `json:"exclude"`
+	// DryRun reports the stale branches without deleting them.
+	DryRun bool `json:"dry_run"`
+	// Concurrency is the number of branches checked or deleted at once,
+	// DEFAULT_BRANCH_CLEANUP_CONCURRENCY by default.
+	Concurrency int `json:"concurrency"`
+	ctx         context.Context
+}
+
+func (bco *BranchCleanupOptions) WithContext(ctx context.Context) *BranchCleanupOptions {
+	bco.ctx = ctx
+	return bco
 }
 
 type RepositoryBranchTarget struct {
@@ -281,11 +530,41 @@ type RepositoryBranchTarget struct {
 type RepositoryTagOptions struct {
 	Owner    string `json:"owner"`
 	RepoSlug string `json:"repo_slug"`
+	// Name is the tag read by GetTag or deleted by DeleteTag.
+	Name     string `json:"name"`
 	Query    string `json:"q"`
 	Sort     string `json:"sort"`
 	PageNum  int    `json:"page"`
 	Pagelen  int    `json:"pagelen"`
 	MaxDepth int    `json:"max_depth"`
+	Fields   []string
+	ctx      context.Context
+}
+
+func (rto *RepositoryTagOptions) WithContext(ctx context.Context) *RepositoryTagOptions {
+	rto.ctx = ctx
+	return rto
+}
+
+// ReleaseNotesOptions configures Repository.ReleaseNotes.
+type ReleaseNotesOptions struct {
+	Owner    string `json:"owner"`
+	RepoSlug string `json:"repo_slug"`
+	// From is the tag of the previous release, the most recent tag before To
+	// by default.
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Types are the sections of the notes, DefaultReleaseNoteTypes by default.
+	Types []ReleaseNoteType
+	// Classify, when set, returns the type of a change, for example from a
+	// keyword of the pull request title.
+	Classify func(note *ReleaseNote) string
+	ctx      context.Context
+}
+
+func (rno *ReleaseNotesOptions) WithContext(ctx context.Context) *ReleaseNotesOptions {
+	rno.ctx = ctx
+	return rno
 }
 
 type RepositoryTagCreationOptions struct {
@@ -293,6 +572,8 @@ type RepositoryTagCreationOptions struct {
 	RepoSlug string              `json:"repo_slug"`
 	Name     string              `json:"name"`
 	Target   RepositoryTagTarget `json:"target"`
+	// Message, when set, creates an annotated tag.
+	Message string `json:"message"`
 }
 
 type RepositoryTagTarget struct {
@@ -318,6 +599,7 @@ type PullRequestsOptions struct {
 	Sort              string   `json:"sort"`
 	Draft             bool     `json:"draft"`
 	Commit            string   `json:"commit"`
+	Fields            []string
 	ctx               context.Context
 }
 
@@ -333,7 +615,17 @@ type PullRequestCommentOptions struct {
 	Content       string `json:"content"`
 	CommentId     string `json:"-"`
 	Parent        *int   `json:"parent"`
-	ctx           context.Context
+	// Inline places the comment on a line of the diff, see FileDiff.Inline.
+	Inline *CommentInline `json:"inline"`
+	ctx    context.Context
+}
+
+// CommentInline places a comment on a line of a file: on the line From of the
+// old file, or on the line To of the new file.
+type CommentInline struct {
+	Path string `json:"path"`
+	From *int   `json:"from,omitempty"`
+	To   *int   `json:"to,omitempty"`
 }
 
 func (pco *PullRequestCommentOptions) WithContext(ctx context.Context) *PullRequestCommentOptions {
@@ -357,6 +649,7 @@ type IssuesOptions struct {
 	Priority  string   `json:"priority"`
 	Version   string   `json:"version"`
 	Assignee  string   `json:"assignee"`
+	Fields    []string
 	ctx       context.Context
 }
 
@@ -394,6 +687,7 @@ type CommitsOptions struct {
 	Exclude     string `json:"exclude"`
 	CommentID   string `json:"comment_id"`
 	Page        *int   `json:"page"`
+	Fields      []string
 	ctx         context.Context
 }
 
@@ -402,6 +696,21 @@ func (cm *CommitsOptions) WithContext(ctx context.Context) *CommitsOptions {
 	return cm
 }
 
+// CompareOptions selects the revisions compared by Repository.Compare and
+// Repository.MergeBase. Base and Head are commits, branches or tags.
+type CompareOptions struct {
+	Owner    string `json:"owner"`
+	RepoSlug string `json:"repo_slug"`
+	Base     string `json:"base"`
+	Head     string `json:"head"`
+	ctx      context.Context
+}
+
+func (co *CompareOptions) WithContext(ctx context.Context) *CompareOptions {
+	co.ctx = ctx
+	return co
+}
+
 type CommitStatusOptions struct {
 	Key         string `json:"key"`
 	Url         string `json:"url"`
@@ -421,6 +730,7 @@ type BranchRestrictionsOptions struct {
 	FullSlug string            `json:"full_slug"`
 	Name     string            `json:"name"`
 	Value    interface{}       `json:"value"`
+	Fields   []string
 	ctx      context.Context
 }
 
@@ -468,6 +778,7 @@ type WebhooksOptions struct {
 	Url         string   `json:"url"`
 	Active      bool     `json:"active"`
 	Events      []string `json:"events"` // EX: {'repo:push','issue:created',..} REF: https://bit.ly/3FjRHHu
+	Fields      []string
 	ctx         context.Context
 }
 
@@ -480,12 +791,14 @@ type RepositoryPipelineOptions struct {
 	Owner    string `json:"owner"`
 	RepoSlug string `json:"repo_slug"`
 	Enabled  bool   `json:"has_pipelines"`
+	Fields   []string
 }
 
 type RepositoryDefaultReviewerOptions struct {
 	Owner    string `json:"owner"`
 	RepoSlug string `json:"repo_slug"`
 	Username string `json:"username"`
+	Fields   []string
 }
 
 type RepositoryPipelineVariablesOptions struct {
@@ -496,6 +809,7 @@ type RepositoryPipelineVariablesOptions struct {
 	PageNum  int    `json:"page"`
 	Pagelen  int    `json:"pagelen"`
 	MaxDepth int    `json:"max_depth"`
+	Fields   []string
 }
 
 type RepositoryPipelineVariableOptions struct {
@@ -505,6 +819,7 @@ type RepositoryPipelineVariableOptions struct {
 	Key      string `json:"key"`
 	Value    string `json:"value"`
 	Secured  bool   `json:"secured"`
+	Fields   []string
 	ctx      context.Context
 }
 
@@ -524,6 +839,7 @@ type RepositoryPipelineKeyPairOptions struct {
 	RepoSlug   string `json:"repo_slug"`
 	PrivateKey string `json:"private_key"`
 	PublicKey  string `json:"public_key"`
+	Fields     []string
 }
 
 type RepositoryPipelineBuildNumberOptions struct {
@@ -535,6 +851,13 @@ type RepositoryPipelineBuildNumberOptions struct {
 type RepositoryBranchingModelOptions struct {
 	Owner    string `json:"owner"`
 	RepoSlug string `json:"repo_slug"`
+	Fields   []string
+	ctx      context.Context
+}
+
+func (rbmo *RepositoryBranchingModelOptions) WithContext(ctx context.Context) *RepositoryBranchingModelOptions {
+	rbmo.ctx = ctx
+	return rbmo
 }
 
 type DownloadsOptions struct {
@@ -543,6 +866,7 @@ type DownloadsOptions struct {
 	FilePath string `json:"filepath"`
 	FileName string `json:"filename"`
 	Files    []File `json:"files"`
+	Fields   []string
 	ctx      context.Context
 }
 
@@ -566,11 +890,13 @@ type PipelinesOptions struct {
 	Sort     string `json:"sort"`
 	IDOrUuid string `json:"ID"`
 	StepUuid string `json:"StepUUID"`
+	Fields   []string
 }
 
 type RepositoryEnvironmentsOptions struct {
 	Owner    string `json:"owner"`
 	RepoSlug string `json:"repo_slug"`
+	Fields   []string
 }
 
 type RepositoryGroupPermissionsOptions struct {
@@ -578,6 +904,7 @@ type RepositoryGroupPermissionsOptions struct {
 	RepoSlug   string `json:"repo_slug"`
 	Group      string `json:"group"`
 	Permission string `json:"permission"`
+	Fields     []string
 }
 
 type RepositoryUserPermissionsOptions struct {
@@ -585,6 +912,7 @@ type RepositoryUserPermissionsOptions struct {
 	RepoSlug   string `json:"repo_slug"`
 	User       string `json:"user"`
 	Permission string `json:"permission"`
+	Fields     []string
 }
 
 type RepositoryEnvironmentTypeOption int
@@ -606,6 +934,7 @@ type RepositoryEnvironmentOptions struct {
 	Name            string                          `json:"name"`
 	EnvironmentType RepositoryEnvironmentTypeOption `json:"environment_type"`
 	Rank            int                             `json:"rank"`
+	Fields          []string
 	ctx             context.Context
 }
 
@@ -629,6 +958,7 @@ type RepositoryDeploymentVariablesOptions struct {
 	PageNum     int          `json:"page"`
 	Pagelen     int          `json:"pagelen"`
 	MaxDepth    int          `json:"max_depth"`
+	Fields      []string
 }
 
 type RepositoryDeploymentVariableOptions struct {
@@ -660,6 +990,7 @@ type DeployKeyOptions struct {
 	Id       int    `json:"id"`
 	Label    string `json:"label"`
 	Key      string `json:"key"`
+	Fields   []string
 	ctx      context.Context
 }
 
@@ -668,9 +999,80 @@ func (dk *DeployKeyOptions) WithContext(ctx context.Context) *DeployKeyOptions {
 	return dk
 }
 
+// UserOptions configures User.ProfileWithOptions and Users.GetWithOptions.
+type UserOptions struct {
+	// Username is the user read by Users.GetWithOptions, ignored by Profile.
+	Username string `json:"username"`
+	Fields   []string
+}
+
+// WorkspaceOptions configures the Workspace methods taking options.
+type WorkspaceOptions struct {
+	// Workspace is the workspace to read, ignored by ListWithOptions.
+	Workspace string `json:"workspace"`
+	Fields    []string
+}
+
 type SSHKeyOptions struct {
-	Owner string `json:"owner"`
-	Uuid  string `json:"uuid"`
-	Label string `json:"label"`
-	Key   string `json:"key"`
+	Owner  string `json:"owner"`
+	Uuid   string `json:"uuid"`
+	Label  string `json:"label"`
+	Key    string `json:"key"`
+	Fields []string
+}
+
+type SnippetOptions struct {
+	// Workspace owns the snippet. When listing or creating, an empty
+	// workspace uses the snippets of the authenticated user.
+	Workspace string `json:"workspace"`
+	Id        string `json:"id"`
+	Title     string `json:"title"`
+	IsPrivate string `json:"is_private"`
+	// Files are added to or replaced in the snippet by Create and Update.
+	Files []File `json:"files"`
+	// FilesToDelete are removed from the snippet by Update.
+	FilesToDelete []string `json:"files_to_delete"`
+	// Role filters listed snippets, role=[owner|contributor|member].
+	Role string `json:"role"`
+	// Revision selects a revision of the snippet, or a range such as
+	// "a1b2c3..d4e5f6" for Diff and Patch.
+	Revision string `json:"revision"`
+	// Path selects a file of the snippet.
+	Path   string `json:"path"`
+	Fields []string
+	ctx    context.Context
+}
+
+func (so *SnippetOptions) WithContext(ctx context.Context) *SnippetOptions {
+	so.ctx = ctx
+	return so
+}
+
+type SnippetCommentOptions struct {
+	Workspace string `json:"workspace"`
+	SnippetId string `json:"snippet_id"`
+	CommentId string `json:"comment_id"`
+	Content   string `json:"content"`
+	Parent    *int   `json:"parent"`
+	ctx       context.Context
+}
+
+func (sco *SnippetCommentOptions) WithContext(ctx context.Context) *SnippetCommentOptions {
+	sco.ctx = ctx
+	return sco
+}
+
+type RepositoryArchiveOptions struct {
+	Owner    string `json:"owner"`
+	RepoSlug string `json:"repo_slug"`
+	// Ref is the commit, branch or tag to download.
+	Ref string `json:"ref"`
+	// Format is ArchiveZip or ArchiveTarGz; it defaults to ArchiveZip.
+	Format string `json:"format"`
+	ctx    context.Context
+}
+
+func (rao *RepositoryArchiveOptions) WithContext(ctx context.Context) *RepositoryArchiveOptions {
+	rao.ctx = ctx
+	return rao
 }
@@ -0,0 +1,656 @@
+package bitbuckettest
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var fakeUser = object{
+	"type":         "user",
+	"uuid":         "{00000000-0000-4000-8000-000000000000}",
+	"username":     "bitbuckettest",
+	"nickname":     "bitbuckettest",
+	"display_name": "Bitbucket Test",
+	"account_id":   "000000:bitbuckettest",
+}
+
+func (s *Server) route(w http.ResponseWriter, req *http.Request, seg []string, body object) {
+	switch {
+	case len(seg) == 1 && seg[0] == "user" && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, fakeUser)
+	case seg[0] == "workspaces":
+		s.serveWorkspaces(w, req, seg[1:])
+	case seg[0] == "repositories" && len(seg) == 2 && req.Method == http.MethodGet:
+		s.listRepositories(w, req, seg[1])
+	case seg[0] == "repositories" && len(seg) >= 3:
+		s.serveRepository(w, req, seg[1], seg[2], seg[3:], body)
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *Server) serveWorkspaces(w http.ResponseWriter, req *http.Request, seg []string) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	switch len(seg) {
+	case 0:
+		slugs := make([]string, 0, len(s.workspaces))
+		for slug := range s.workspaces {
+			slugs = append(slugs, slug)
+		}
+		sort.Strings(slugs)
+		values := make([]object, 0, len(slugs))
+		for _, slug := range slugs {
+			values = append(values, s.workspaces[slug].data)
+		}
+		writePage(w, req, values)
+	case 1:
+		ws, ok := s.workspaces[seg[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "No workspace with identifier '"+seg[0]+"'.")
+			return
+		}
+		writeJSON(w, http.StatusOK, ws.data)
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+// repositoryQuery matches the repository filters the fake understands; like
+// the API, "~" is a case-insensitive substring match.
+var repositoryQuery = regexp.MustCompile(`^(name|full_name) ~ "(.*)"$`)
+
+func (s *Server) listRepositories(w http.ResponseWriter, req *http.Request, workspace string) {
+	ws, ok := s.workspaces[workspace]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No workspace with identifier '"+workspace+"'.")
+		return
+	}
+	query := req.URL.Query()
+	switch query.Get("role") {
+	case "", "member", "contributor", "admin", "owner":
+		// The caller owns every repository of the fake, so each role matches all of them.
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid role '"+query.Get("role")+"'.")
+		return
+	}
+	field, filter := "", ""
+	if q := query.Get("q"); q != "" {
+		m := repositoryQuery.FindStringSubmatch(q)
+		if m == nil {
+			writeError(w, http.StatusBadRequest, "Unsupported query '"+q+"'.")
+			return
+		}
+		field, filter = m[1], strings.ToLower(m[2])
+	}
+	slugs := make([]string, 0, len(ws.repos))
+	for slug, r := range ws.repos {
+		if field != "" {
+			value, _ := r.data[field].(string)
+			if !strings.Contains(strings.ToLower(value), filter) {
+				continue
+			}
+		}
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	values := make([]object, 0, len(slugs))
+	for _, slug := range slugs {
+		values = append(values, ws.repos[slug].data)
+	}
+	writePage(w, req, values)
+}
+
+func (s *Server) serveRepository(w http.ResponseWriter, req *http.Request, workspace, slug string, seg []string, body object) {
+	if len(seg) == 0 && req.Method == http.MethodPost {
+		if _, exists := s.repo(workspace, slug); exists {
+			writeError(w, http.StatusBadRequest, "Repository with this Slug and Owner already exists.")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.createRepository(workspace, slug, body).data)
+		return
+	}
+
+	r, ok := s.repo(workspace, slug)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Repository "+workspace+"/"+slug+" not found")
+		return
+	}
+	if len(seg) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, r.data)
+		case http.MethodPut:
+			for _, key := range []string{"description", "is_private", "fork_policy", "language", "has_issues", "has_wiki", "name"} {
+				if v, ok := body[key]; ok {
+					r.data[key] = v
+				}
+			}
+			r.data["updated_on"] = timestamp()
+			writeJSON(w, http.StatusOK, r.data)
+		case http.MethodDelete:
+			delete(s.workspaces[workspace].repos, slug)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	switch {
+	case seg[0] == "refs" && len(seg) >= 2 && seg[1] == "branches":
+		s.serveBranches(w, req, r, strings.Join(seg[2:], "/"), body)
+	case seg[0] == "pullrequests":
+		s.servePullRequests(w, req, r, seg[1:], body)
+	case seg[0] == "pipelines":
+		s.servePipelines(w, req, r, seg[1:])
+	case seg[0] == "hooks":
+		s.serveHooks(w, req, r, seg[1:], body)
+	case seg[0] == "pipelines_config" && len(seg) >= 2 && seg[1] == "variables":
+		s.serveVariables(w, req, r, seg[2:], body)
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+var nameContains = regexp.MustCompile(`^name ~ "(.*)"$`)
+
+func (s *Server) serveBranches(w http.ResponseWriter, req *http.Request, r *repo, name string, body object) {
+	switch {
+	case name == "" && req.Method == http.MethodGet:
+		var filter string
+		if m := nameContains.FindStringSubmatch(req.URL.Query().Get("q")); m != nil {
+			filter = m[1]
+		}
+		names := make([]string, 0, len(r.branches))
+		for branch := range r.branches {
+			if strings.Contains(branch, filter) {
+				names = append(names, branch)
+			}
+		}
+		sort.Strings(names)
+		values := make([]object, 0, len(names))
+		for _, branch := range names {
+			values = append(values, r.branches[branch])
+		}
+		writePage(w, req, values)
+	case name == "" && req.Method == http.MethodPost:
+		branch, _ := body["name"].(string)
+		target, _ := body["target"].(object)
+		hash, _ := target["hash"].(string)
+		if branch == "" || hash == "" {
+			writeError(w, http.StatusBadRequest, "Branch name and target hash are required")
+			return
+		}
+		if _, exists := r.branches[branch]; exists {
+			writeError(w, http.StatusBadRequest, "BRANCH_ALREADY_EXISTS")
+			return
+		}
+		// The real API resolves branch names as targets too.
+		if existing, ok := r.branches[hash]; ok {
+			hash = existing["target"].(object)["hash"].(string)
+		}
+		r.branches[branch] = s.newBranch(r, branch, hash)
+		writeJSON(w, http.StatusCreated, r.branches[branch])
+	case req.Method == http.MethodGet:
+		branch, ok := r.branches[name]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Branch \""+name+"\" does not exist")
+			return
+		}
+		writeJSON(w, http.StatusOK, branch)
+	case req.Method == http.MethodDelete:
+		if _, ok := r.branches[name]; !ok {
+			writeError(w, http.StatusNotFound, "Branch \""+name+"\" does not exist")
+			return
+		}
+		if name == r.data["mainbranch"].(object)["name"] {
+			writeError(w, http.StatusBadRequest, "You can't delete the main branch")
+			return
+		}
+		delete(r.branches, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) servePullRequests(w http.ResponseWriter, req *http.Request, r *repo, seg []string, body object) {
+	if len(seg) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			states := req.URL.Query()["state"]
+			if len(states) == 0 {
+				states = []string{"OPEN"}
+			}
+			var values []object
+			for _, pr := range r.pullRequests {
+				for _, state := range states {
+					if strings.EqualFold(pr["state"].(string), state) {
+						values = append(values, pr)
+						break
+					}
+				}
+			}
+			writePage(w, req, values)
+		case http.MethodPost:
+			s.createPullRequest(w, r, body)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	id, err := strconv.Atoi(seg[0])
+	if err != nil || id < 1 || id > len(r.pullRequests) {
+		writeError(w, http.StatusNotFound, "Pull request "+seg[0]+" not found")
+		return
+	}
+	pr := r.pullRequests[id-1]
+
+	switch {
+	case len(seg) == 1 && req.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, pr)
+	case len(seg) == 1 && req.Method == http.MethodPut:
+		for _, key := range []string{"title", "description", "close_source_branch", "reviewers", "draft"} {
+			if v, ok := body[key]; ok {
+				pr[key] = v
+			}
+		}
+		if dest, ok := body["destination"].(object); ok && len(dest) > 0 {
+			pr["destination"] = dest
+		}
+		pr["updated_on"] = timestamp()
+		writeJSON(w, http.StatusOK, pr)
+	case len(seg) == 2 && seg[1] == "merge" && req.Method == http.MethodPost:
+		s.mergePullRequest(w, r, pr, body)
+	case len(seg) == 2 && seg[1] == "decline" && req.Method == http.MethodPost:
+		if pr["state"] != "OPEN" {
+			writeError(w, http.StatusBadRequest, "You can't decline a pull request that is not open")
+			return
+		}
+		pr["state"] = "DECLINED"
+		pr["updated_on"] = timestamp()
+		writeJSON(w, http.StatusOK, pr)
+	case len(seg) == 2 && (seg[1] == "approve" || seg[1] == "request-changes"):
+		s.setParticipantState(w, req, pr, seg[1])
+	case len(seg) >= 2 && seg[1] == "comments":
+		s.serveComments(w, req, r, id, seg[2:], body)
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *Server) createPullRequest(w http.ResponseWriter, r *repo, body object) {
+	title, _ := body["title"].(string)
+	source, _ := body["source"].(object)
+	sourceBranch, _ := source["branch"].(object)
+	sourceName, _ := sourceBranch["name"].(string)
+	if title == "" || sourceName == "" {
+		writeError(w, http.StatusBadRequest, "title and source branch are required")
+		return
+	}
+	if _, ok := r.branches[sourceName]; !ok {
+		writeError(w, http.StatusBadRequest, "Branch \""+sourceName+"\" does not exist")
+		return
+	}
+	destName := r.data["mainbranch"].(object)["name"].(string)
+	if dest, ok := body["destination"].(object); ok {
+		if branch, ok := dest["branch"].(object); ok {
+			if name, _ := branch["name"].(string); name != "" {
+				destName = name
+			}
+		}
+	}
+	for _, pr := range r.pullRequests {
+		if pr["state"] == "OPEN" &&
+			pr["source"].(object)["branch"].(object)["name"] == sourceName &&
+			pr["destination"].(object)["branch"].(object)["name"] == destName {
+			// Like the real API, creating a duplicate returns the open pull request.
+			writeJSON(w, http.StatusOK, pr)
+			return
+		}
+	}
+
+	id := len(r.pullRequests) + 1
+	now := timestamp()
+	description, _ := body["description"].(string)
+	closeSource, _ := body["close_source_branch"].(bool)
+	draft, _ := body["draft"].(bool)
+	reviewers, _ := body["reviewers"].([]interface{})
+	if reviewers == nil {
+		reviewers = []interface{}{}
+	}
+	pr := object{
+		"type":                "pullrequest",
+		"id":                  float64(id),
+		"title":               title,
+		"description":         description,
+		"state":               "OPEN",
+		"draft":               draft,
+		"close_source_branch": closeSource,
+		"author":              fakeUser,
+		"reviewers":           reviewers,
+		"participants":        []interface{}{},
+		"comment_count":       float64(0),
+		"created_on":          now,
+		"updated_on":          now,
+		"source": object{
+			"branch":     object{"name": sourceName},
+			"commit":     object{"hash": r.branches[sourceName]["target"].(object)["hash"]},
+			"repository": object{"full_name": r.data["full_name"], "type": "repository"},
+		},
+		"destination": object{
+			"branch":     object{"name": destName},
+			"repository": object{"full_name": r.data["full_name"], "type": "repository"},
+		},
+		"links": object{"html": object{"href": "https://bitbucket.org/" + r.data["full_name"].(string) + "/pull-requests/" + strconv.Itoa(id)}},
+	}
+	r.pullRequests = append(r.pullRequests, pr)
+	writeJSON(w, http.StatusCreated, pr)
+}
+
+func (s *Server) mergePullRequest(w http.ResponseWriter, r *repo, pr object, body object) {
+	if pr["state"] != "OPEN" {
+		writeError(w, http.StatusBadRequest, "You can't merge a pull request that is not open")
+		return
+	}
+	destName := pr["destination"].(object)["branch"].(object)["name"].(string)
+	if _, ok := r.branches[destName]; !ok {
+		writeError(w, http.StatusBadRequest, "Branch \""+destName+"\" does not exist")
+		return
+	}
+	hash := s.newHash()
+	r.branches[destName] = s.newBranch(r, destName, hash)
+
+	pr["state"] = "MERGED"
+	pr["merge_commit"] = object{"type": "commit", "hash": hash}
+	pr["updated_on"] = timestamp()
+	closeSource, _ := pr["close_source_branch"].(bool)
+	if v, ok := body["close_source_branch"].(bool); ok {
+		closeSource = v
+	}
+	if closeSource {
+		delete(r.branches, pr["source"].(object)["branch"].(object)["name"].(string))
+	}
+	writeJSON(w, http.StatusOK, pr)
+}
+
+func (s *Server) setParticipantState(w http.ResponseWriter, req *http.Request, pr object, action string) {
+	participants, _ := pr["participants"].([]interface{})
+	var participant object
+	for _, p := range participants {
+		if p.(object)["user"].(object)["uuid"] == fakeUser["uuid"] {
+			participant = p.(object)
+		}
+	}
+	if participant == nil {
+		participant = object{"type": "participant", "user": fakeUser, "role": "PARTICIPANT", "approved": false, "state": nil}
+		pr["participants"] = append(participants, participant)
+	}
+
+	switch req.Method {
+	case http.MethodPost:
+		if action == "approve" {
+			participant["approved"] = true
+			participant["state"] = "approved"
+		} else {
+			participant["approved"] = false
+			participant["state"] = "changes_requested"
+		}
+		writeJSON(w, http.StatusOK, participant)
+	case http.MethodDelete:
+		participant["approved"] = false
+		participant["state"] = nil
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) serveComments(w http.ResponseWriter, req *http.Request, r *repo, prID int, seg []string, body object) {
+	comments := r.comments[prID]
+	pr := r.pullRequests[prID-1]
+
+	if len(seg) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			var values []object
+			for _, c := range comments {
+				if c["deleted"] != true {
+					values = append(values, c)
+				}
+			}
+			writePage(w, req, values)
+		case http.MethodPost:
+			content, _ := body["content"].(object)
+			raw, _ := content["raw"].(string)
+			if raw == "" {
+				writeError(w, http.StatusBadRequest, "content.raw is required")
+				return
+			}
+			s.serial++
+			now := timestamp()
+			comment := object{
+				"type":       "pullrequest_comment",
+				"id":         float64(s.serial),
+				"content":    object{"raw": raw, "markup": "markdown"},
+				"user":       fakeUser,
+				"deleted":    false,
+				"created_on": now,
+				"updated_on": now,
+				"pullrequest": object{
+					"type": "pullrequest",
+					"id":   pr["id"],
+				},
+			}
+			if parent, ok := body["parent"].(object); ok {
+				comment["parent"] = parent
+			}
+			if inline, ok := body["inline"].(object); ok {
+				comment["inline"] = inline
+			}
+			r.comments[prID] = append(comments, comment)
+			pr["comment_count"] = pr["comment_count"].(float64) + 1
+			writeJSON(w, http.StatusCreated, comment)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	var comment object
+	for _, c := range comments {
+		if strconv.Itoa(int(c["id"].(float64))) == seg[0] && c["deleted"] != true {
+			comment = c
+		}
+	}
+	if comment == nil || len(seg) > 1 {
+		writeError(w, http.StatusNotFound, "Comment "+seg[0]+" not found")
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, comment)
+	case http.MethodPut:
+		if content, ok := body["content"].(object); ok {
+			if raw, _ := content["raw"].(string); raw != "" {
+				comment["content"] = object{"raw": raw, "markup": "markdown"}
+			}
+		}
+		comment["updated_on"] = timestamp()
+		writeJSON(w, http.StatusOK, comment)
+	case http.MethodDelete:
+		comment["deleted"] = true
+		pr["comment_count"] = pr["comment_count"].(float64) - 1
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (s *Server) servePipelines(w http.ResponseWriter, req *http.Request, r *repo, seg []string) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	switch len(seg) {
+	case 0:
+		values := append([]object(nil), r.pipelines...)
+		if strings.TrimPrefix(req.URL.Query().Get("sort"), "+") == "-created_on" {
+			for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+				values[i], values[j] = values[j], values[i]
+			}
+		}
+		writePage(w, req, values)
+	case 1:
+		for _, p := range r.pipelines {
+			if p["uuid"] == seg[0] || strconv.Itoa(int(p["build_number"].(float64))) == seg[0] {
+				writeJSON(w, http.StatusOK, p)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "Pipeline "+seg[0]+" not found")
+	default:
+		writeError(w, http.StatusNotFound, "Resource not found")
+	}
+}
+
+func (s *Server) serveHooks(w http.ResponseWriter, req *http.Request, r *repo, seg []string, body object) {
+	if len(seg) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			writePage(w, req, r.hooks)
+		case http.MethodPost:
+			if u, _ := body["url"].(string); u == "" {
+				writeError(w, http.StatusBadRequest, "url is required")
+				return
+			}
+			hook := object{"type": "webhook_subscription", "uuid": s.newUUID(), "created_at": timestamp()}
+			updateHook(hook, body)
+			r.hooks = append(r.hooks, hook)
+			writeJSON(w, http.StatusCreated, hook)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	for i, hook := range r.hooks {
+		if hook["uuid"] != seg[0] {
+			continue
+		}
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, hook)
+		case http.MethodPut:
+			updateHook(hook, body)
+			writeJSON(w, http.StatusOK, hook)
+		case http.MethodDelete:
+			r.hooks = append(r.hooks[:i], r.hooks[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+	writeError(w, http.StatusNotFound, "Webhook "+seg[0]+" not found")
+}
+
+func updateHook(hook, body object) {
+	for _, key := range []string{"url", "description", "active", "events"} {
+		if v, ok := body[key]; ok {
+			hook[key] = v
+		}
+	}
+	// Secrets are write-only; the API only reports whether one is set.
+	if secret, ok := body["secret"].(string); ok {
+		hook["secret_set"] = secret != ""
+	}
+}
+
+func (s *Server) serveVariables(w http.ResponseWriter, req *http.Request, r *repo, seg []string, body object) {
+	if len(seg) == 0 {
+		switch req.Method {
+		case http.MethodGet:
+			values := make([]object, 0, len(r.variables))
+			for _, v := range r.variables {
+				values = append(values, publicVariable(v))
+			}
+			writePage(w, req, values)
+		case http.MethodPost:
+			key, _ := body["key"].(string)
+			if key == "" {
+				writeError(w, http.StatusBadRequest, "key is required")
+				return
+			}
+			for _, v := range r.variables {
+				if v["key"] == key {
+					writeError(w, http.StatusConflict, "A variable with the key provided already exists.")
+					return
+				}
+			}
+			variable := object{"type": "pipeline_variable", "uuid": s.newUUID(), "key": key}
+			updateVariable(variable, body)
+			r.variables = append(r.variables, variable)
+			writeJSON(w, http.StatusCreated, publicVariable(variable))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+
+	for i, variable := range r.variables {
+		if variable["uuid"] != seg[0] {
+			continue
+		}
+		switch req.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, publicVariable(variable))
+		case http.MethodPut:
+			if key, _ := body["key"].(string); key != "" {
+				variable["key"] = key
+			}
+			updateVariable(variable, body)
+			writeJSON(w, http.StatusOK, publicVariable(variable))
+		case http.MethodDelete:
+			r.variables = append(r.variables[:i], r.variables[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+		return
+	}
+	writeError(w, http.StatusNotFound, "Variable "+seg[0]+" not found")
+}
+
+func updateVariable(variable, body object) {
+	if value, ok := body["value"]; ok {
+		variable["value"] = value
+	}
+	if secured, ok := body["secured"].(bool); ok {
+		variable["secured"] = secured
+	} else if _, ok := variable["secured"]; !ok {
+		variable["secured"] = false
+	}
+}
+
+// publicVariable hides the value of secured variables, as the API does.
+func publicVariable(v object) object {
+	if v["secured"] != true {
+		return v
+	}
+	public := object{}
+	for key, value := range v {
+		if key != "value" {
+			public[key] = value
+		}
+	}
+	return public
+}
@@ -0,0 +1,442 @@
+// Package bitbuckettest provides an in-memory fake of the Bitbucket Cloud API
+// for testing code built on github.com/ktrysmt/go-bitbucket.
+//
+// A Server models workspaces, repositories, branches, pull requests and their
+// comments, pipelines, webhooks and pipeline variables, and serves the
+// endpoints the bitbucket package calls for them, including pagination and
+// Bitbucket-style error responses. Faults can be injected to exercise error
+// handling:
+//
+//	srv := bitbuckettest.NewServer()
+//	defer srv.Close()
+//	srv.AddRepository("acme", "api")
+//	srv.InjectFault(bitbuckettest.Fault{Method: "POST", Path: "/repositories/acme/api/pullrequests", Status: 503})
+//
+//	c, err := srv.NewClient()
+package bitbuckettest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+// DefaultPagelen is the page length used when a request does not set one.
+const DefaultPagelen = 10
+
+// maxPagelen is the largest page length the fake serves, as the real API does.
+const maxPagelen = 100
+
+// object is a JSON object as served by the API.
+type object = map[string]interface{}
+
+// Fault makes the server fail matching requests.
+type Fault struct {
+	// Method matches the request method; empty matches any method.
+	Method string
+	// Path is a path.Match pattern for the request path below /2.0, e.g.
+	// "/repositories/*/*/pullrequests". A trailing slash is ignored.
+	Path string
+	// Status is the status code of the response.
+	Status int
+	// Message is the error message in the response body.
+	Message string
+	// Header is added to the response, e.g. Retry-After.
+	Header http.Header
+	// Times is how many requests fail; zero means every matching request.
+	Times int
+}
+
+// Server is a fake Bitbucket API. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	workspaces map[string]*workspace
+	faults     []*Fault
+	requests   []string
+	serial     int
+}
+
+type workspace struct {
+	data  object
+	repos map[string]*repo
+}
+
+type repo struct {
+	data         object
+	branches     map[string]object
+	pullRequests []object
+	comments     map[int][]object
+	pipelines    []object
+	hooks        []object
+	variables    []object
+}
+
+// NewServer starts a fake Bitbucket API server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{workspaces: make(map[string]*workspace)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the API base URL of the server, to be used with bitbucket.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/2.0"
+}
+
+// NewClient returns a client talking to the server, configured by opts.
+func (s *Server) NewClient(opts ...bitbucket.Option) (*bitbucket.Client, error) {
+	return bitbucket.New(bitbucket.BasicCredentials("bitbuckettest", "password"),
+		append([]bitbucket.Option{bitbucket.WithBaseURL(s.BaseURL())}, opts...)...)
+}
+
+// InjectFault makes matching requests fail until the fault is used up.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.Status == 0 {
+		f.Status = http.StatusInternalServerError
+	}
+	if f.Message == "" {
+		f.Message = http.StatusText(f.Status)
+	}
+	f.Path = strings.TrimSuffix(f.Path, "/")
+	s.faults = append(s.faults, &f)
+}
+
+// Requests returns the requests served so far as "METHOD /path?query".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// AddWorkspace adds a workspace if it does not exist yet.
+func (s *Server) AddWorkspace(slug string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workspace(slug)
+}
+
+// AddRepository adds a repository, and its workspace if needed, with a main
+// branch called "main".
+func (s *Server) AddRepository(workspace, slug string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.createRepository(workspace, slug, object{})
+}
+
+// AddBranch adds or moves a branch of an existing repository to the commit hash.
+func (s *Server) AddBranch(workspace, repoSlug, name, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.repo(workspace, repoSlug)
+	if !ok {
+		return fmt.Errorf("bitbuckettest: no repository %s/%s", workspace, repoSlug)
+	}
+	r.branches[name] = s.newBranch(r, name, hash)
+	return nil
+}
+
+// AddPipeline adds a pipeline run on branch in the given state, e.g.
+// "COMPLETED", and returns its UUID.
+func (s *Server) AddPipeline(workspace, repoSlug, branch, state string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.repo(workspace, repoSlug)
+	if !ok {
+		return "", fmt.Errorf("bitbuckettest: no repository %s/%s", workspace, repoSlug)
+	}
+	uuid := s.newUUID()
+	r.pipelines = append(r.pipelines, object{
+		"type":         "pipeline",
+		"uuid":         uuid,
+		"build_number": float64(len(r.pipelines) + 1),
+		"state":        object{"name": state, "type": "pipeline_state"},
+		"target":       object{"type": "pipeline_ref_target", "ref_type": "branch", "ref_name": branch},
+		"created_on":   timestamp(),
+		"repository":   object{"full_name": r.data["full_name"], "type": "repository"},
+	})
+	return uuid, nil
+}
+
+// Branches returns the branch names of a repository in sorted order.
+func (s *Server) Branches(workspace, repoSlug string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.repo(workspace, repoSlug)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(r.branches))
+	for name := range r.branches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PullRequest returns a copy of a pull request as served by the API.
+func (s *Server) PullRequest(workspace, repoSlug string, id int) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.repo(workspace, repoSlug)
+	if !ok || id < 1 || id > len(r.pullRequests) {
+		return nil, false
+	}
+	return deepCopy(r.pullRequests[id-1]), true
+}
+
+// Comments returns copies of the comments on a pull request.
+func (s *Server) Comments(workspace, repoSlug string, id int) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.repo(workspace, repoSlug)
+	if !ok {
+		return nil
+	}
+	return copyAll(r.comments[id])
+}
+
+// Webhooks returns copies of the webhooks of a repository.
+func (s *Server) Webhooks(workspace, repoSlug string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.repo(workspace, repoSlug)
+	if !ok {
+		return nil
+	}
+	return copyAll(r.hooks)
+}
+
+// Variables returns copies of the pipeline variables of a repository,
+// including the values of secured ones.
+func (s *Server) Variables(workspace, repoSlug string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.repo(workspace, repoSlug)
+	if !ok {
+		return nil
+	}
+	return copyAll(r.variables)
+}
+
+func (s *Server) workspace(slug string) *workspace {
+	ws, ok := s.workspaces[slug]
+	if !ok {
+		ws = &workspace{
+			data: object{
+				"type":       "workspace",
+				"uuid":       s.newUUID(),
+				"slug":       slug,
+				"name":       slug,
+				"is_private": true,
+				"links":      object{"html": object{"href": "https://bitbucket.org/" + slug}},
+			},
+			repos: make(map[string]*repo),
+		}
+		s.workspaces[slug] = ws
+	}
+	return ws
+}
+
+func (s *Server) repo(workspace, slug string) (*repo, bool) {
+	ws, ok := s.workspaces[workspace]
+	if !ok {
+		return nil, false
+	}
+	r, ok := ws.repos[slug]
+	return r, ok
+}
+
+func (s *Server) createRepository(workspace, slug string, body object) *repo {
+	ws := s.workspace(workspace)
+	now := timestamp()
+	data := object{
+		"type":        "repository",
+		"uuid":        s.newUUID(),
+		"name":        slug,
+		"slug":        slug,
+		"full_name":   workspace + "/" + slug,
+		"scm":         "git",
+		"is_private":  true,
+		"description": "",
+		"fork_policy": "allow_forks",
+		"mainbranch":  object{"type": "branch", "name": "main"},
+		"owner":       object{"type": "team", "username": workspace, "uuid": ws.data["uuid"]},
+		"workspace":   object{"type": "workspace", "slug": workspace, "uuid": ws.data["uuid"]},
+		"created_on":  now,
+		"updated_on":  now,
+		"links":       object{"html": object{"href": "https://bitbucket.org/" + workspace + "/" + slug}},
+	}
+	for _, key := range []string{"description", "is_private", "fork_policy", "language", "has_issues", "has_wiki", "project"} {
+		if v, ok := body[key]; ok {
+			data[key] = v
+		}
+	}
+	r := &repo{data: data, branches: make(map[string]object), comments: make(map[int][]object)}
+	r.branches["main"] = s.newBranch(r, "main", s.newHash())
+	ws.repos[slug] = r
+	return r
+}
+
+func (s *Server) newBranch(r *repo, name, hash string) object {
+	return object{
+		"type": "branch",
+		"name": name,
+		"target": object{
+			"type":       "commit",
+			"hash":       hash,
+			"date":       timestamp(),
+			"repository": object{"full_name": r.data["full_name"], "type": "repository"},
+		},
+		"merge_strategies":       []interface{}{"merge_commit", "squash", "fast_forward"},
+		"default_merge_strategy": "merge_commit",
+		"links":                  object{"html": object{"href": fmt.Sprintf("https://bitbucket.org/%s/branch/%s", r.data["full_name"], name)}},
+	}
+}
+
+func (s *Server) newUUID() string {
+	s.serial++
+	return fmt.Sprintf("{00000000-0000-4000-8000-%012x}", s.serial)
+}
+
+func (s *Server) newHash() string {
+	s.serial++
+	return fmt.Sprintf("%040x", s.serial)
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000000+00:00")
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req.Method+" "+req.URL.RequestURI())
+
+	p := strings.TrimSuffix(req.URL.Path, "/")
+	if !strings.HasPrefix(p, "/2.0/") {
+		writeError(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+	p = strings.TrimPrefix(p, "/2.0")
+
+	if s.fault(w, req.Method, p) {
+		return
+	}
+
+	var body object
+	if req.Body != nil && (req.Method == http.MethodPost || req.Method == http.MethodPut) {
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil && err.Error() != "EOF" {
+			writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+			return
+		}
+	}
+	if body == nil {
+		body = object{}
+	}
+
+	s.route(w, req, strings.Split(strings.TrimPrefix(p, "/"), "/"), body)
+}
+
+func (s *Server) fault(w http.ResponseWriter, method, p string) bool {
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, method) {
+			continue
+		}
+		if ok, _ := path.Match(f.Path, p); !ok {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		for name, values := range f.Header {
+			for _, value := range values {
+				w.Header().Add(name, value)
+			}
+		}
+		writeError(w, f.Status, f.Message)
+		return true
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, object{"type": "error", "error": object{"message": message}})
+}
+
+// writePage serves values as a paginated response honouring the page and
+// pagelen query parameters, with a next link for the following page.
+func writePage(w http.ResponseWriter, req *http.Request, values []object) {
+	q := req.URL.Query()
+	pagelen := DefaultPagelen
+	if n, err := strconv.Atoi(q.Get("pagelen")); err == nil && n > 0 {
+		pagelen = n
+	}
+	if pagelen > maxPagelen {
+		pagelen = maxPagelen
+	}
+	page := 1
+	if n, err := strconv.Atoi(q.Get("page")); err == nil && n > 0 {
+		page = n
+	}
+
+	start := (page - 1) * pagelen
+	if start > len(values) {
+		start = len(values)
+	}
+	end := start + pagelen
+	if end > len(values) {
+		end = len(values)
+	}
+
+	items := make([]interface{}, 0, end-start)
+	for _, v := range values[start:end] {
+		items = append(items, v)
+	}
+	resp := object{"size": float64(len(values)), "page": float64(page), "pagelen": float64(pagelen), "values": items}
+	if end < len(values) {
+		next := url.URL{Scheme: "http", Host: req.Host, Path: req.URL.Path}
+		q.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = q.Encode()
+		resp["next"] = next.String()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func deepCopy(o object) object {
+	data, _ := json.Marshal(o)
+	var c object
+	json.Unmarshal(data, &c)
+	return c
+}
+
+func copyAll(objects []object) []map[string]interface{} {
+	copies := make([]map[string]interface{}, 0, len(objects))
+	for _, o := range objects {
+		copies = append(copies, deepCopy(o))
+	}
+	return copies
+}
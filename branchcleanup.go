@@ -0,0 +1,355 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+const DEFAULT_BRANCH_CLEANUP_CONCURRENCY = 4
+
+// BranchKeptReason tells why CleanupBranches kept a branch.
+type BranchKeptReason string
+
+const (
+	BranchKeptMain            BranchKeptReason = "main branch"
+	BranchKeptDevelopment     BranchKeptReason = "development branch"
+	BranchKeptProtected       BranchKeptReason = "protected"
+	BranchKeptExcluded        BranchKeptReason = "excluded"
+	BranchKeptRecent          BranchKeptReason = "recent"
+	BranchKeptOpenPullRequest BranchKeptReason = "open pull request"
+	BranchKeptUnmerged        BranchKeptReason = "not merged"
+)
+
+// BranchCleanupEntry is a branch considered by CleanupBranches.
+type BranchCleanupEntry struct {
+	Name string
+	Hash string
+	// Date is the date of the last commit of the branch.
+	Date time.Time
+	// Reason is why the branch was kept, empty for stale branches.
+	Reason BranchKeptReason
+	// Pattern is the branch restriction or excluded pattern matching a
+	// protected or excluded branch.
+	Pattern string
+	// Err is why a stale branch could not be checked or deleted.
+	Err error
+}
+
+// BranchCleanupReport is the outcome of CleanupBranches.
+type BranchCleanupReport struct {
+	MainBranch string
+	// DevelopmentBranch is the development branch of the branching model,
+	// empty when it is the main branch.
+	DevelopmentBranch string
+	DryRun            bool
+	// Deleted are the stale branches that were deleted, or would be on a dry
+	// run.
+	Deleted []BranchCleanupEntry
+	Kept    []BranchCleanupEntry
+	Failed  []BranchCleanupEntry
+}
+
+// CleanupBranches deletes the stale branches of a repository: the branches
+// whose last commit is older than BranchCleanupOptions.OlderThan, that are not
+// the source of an open pull request and that are already merged into the
+// main branch. The main branch and the development branch of the branching
+// model are always kept, as are the branches matching the pattern of a branch
+// restriction or of BranchCleanupOptions.Exclude. Branch restrictions on a
+// branch type of the branching model protect the branches of that type.
+//
+// Branch restrictions and open pull requests are read in full, whatever the
+// LimitPages of the client, since missing one could delete a branch that
+// must be kept.
+//
+// The report lists every branch. Branches that could not be checked or
+// deleted are reported as failed, and their errors are joined in the returned
+// error.
+func (r *Repository) CleanupBranches(bco *BranchCleanupOptions) (*BranchCleanupReport, error) {
+	mainBranch := bco.MainBranch
+	if mainBranch == "" {
+		repo, err := r.Get((&RepositoryOptions{Owner: bco.Owner, RepoSlug: bco.RepoSlug}).WithContext(bco.ctx))
+		if err != nil {
+			return nil, err
+		}
+		mainBranch = repo.Mainbranch.Name
+		if mainBranch == "" {
+			return nil, errors.New("Error: Main Branch is empty")
+		}
+	}
+
+	model, err := r.BranchingModel((&RepositoryBranchingModelOptions{
+		Owner: bco.Owner, RepoSlug: bco.RepoSlug,
+	}).WithContext(bco.ctx))
+	if err != nil {
+		return nil, err
+	}
+	developmentBranch := modelBranchName(&model.Development, mainBranch)
+	if developmentBranch == mainBranch {
+		developmentBranch = ""
+	}
+	patterns, err := r.protectedBranchPatterns(bco, model, mainBranch)
+	if err != nil {
+		return nil, err
+	}
+	openPullRequests, err := r.openPullRequestBranches(bco)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BranchCleanupReport{MainBranch: mainBranch, DevelopmentBranch: developmentBranch, DryRun: bco.DryRun}
+	var stale []BranchCleanupEntry
+	urlStr := r.c.requestUrl("/repositories/%s/%s/refs/branches", bco.Owner, bco.RepoSlug)
+	for branch, err := range iteratePages(r.c, bco.ctx, urlStr, decodeCleanupBranch) {
+		if err != nil {
+			return nil, err
+		}
+		entry := BranchCleanupEntry{Name: branch.Name}
+		if branch.Target != nil {
+			entry.Hash = branch.Target.Hash
+			entry.Date = branch.Target.Date
+		}
+		switch {
+		case branch.Name == mainBranch:
+			entry.Reason = BranchKeptMain
+		case branch.Name == developmentBranch:
+			entry.Reason = BranchKeptDevelopment
+		case matchBranchPatterns(patterns, branch.Name, &entry.Pattern):
+			entry.Reason = BranchKeptProtected
+		case matchBranchPatterns(bco.Exclude, branch.Name, &entry.Pattern):
+			entry.Reason = BranchKeptExcluded
+		case time.Since(entry.Date) < bco.OlderThan:
+			entry.Reason = BranchKeptRecent
+		case openPullRequests[branch.Name]:
+			entry.Reason = BranchKeptOpenPullRequest
+		}
+		if entry.Reason != "" {
+			report.Kept = append(report.Kept, entry)
+		} else {
+			stale = append(stale, entry)
+		}
+	}
+
+	concurrency := bco.Concurrency
+	if concurrency <= 0 {
+		concurrency = DEFAULT_BRANCH_CLEANUP_CONCURRENCY
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := range stale {
+		wg.Add(1)
+		go func(entry *BranchCleanupEntry) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			r.cleanupBranch(bco, mainBranch, entry)
+		}(&stale[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, entry := range stale {
+		switch {
+		case entry.Err != nil:
+			report.Failed = append(report.Failed, entry)
+			errs = append(errs, fmt.Errorf("branch %q: %w", entry.Name, entry.Err))
+		case entry.Reason != "":
+			report.Kept = append(report.Kept, entry)
+		default:
+			report.Deleted = append(report.Deleted, entry)
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// cleanupBranch deletes a stale branch once it is known to be merged into the
+// main branch, that is when its last commit is their merge base.
+func (r *Repository) cleanupBranch(bco *BranchCleanupOptions, mainBranch string, entry *BranchCleanupEntry) {
+	if bco.ctx != nil {
+		if entry.Err = bco.ctx.Err(); entry.Err != nil {
+			return
+		}
+	}
+	mergeBase, err := r.MergeBase((&CompareOptions{
+		Owner: bco.Owner, RepoSlug: bco.RepoSlug, Base: mainBranch, Head: entry.Hash,
+	}).WithContext(bco.ctx))
+	if err != nil {
+		entry.Err = err
+		return
+	}
+	if mergeBase.Hash != entry.Hash {
+		entry.Reason = BranchKeptUnmerged
+		return
+	}
+	if bco.DryRun {
+		return
+	}
+	entry.Err = r.DeleteBranch((&RepositoryBranchDeleteOptions{
+		Owner: bco.Owner, RepoSlug: bco.RepoSlug, RefName: entry.Name,
+	}).WithContext(bco.ctx))
+}
+
+func decodeCleanupBranch(value interface{}) (*RepositoryBranch, error) {
+	var branch RepositoryBranch
+	if err := decodeWithTimeHook(value, &branch); err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// branchRestrictionPattern is the part of a branch restriction telling which
+// branches it applies to.
+type branchRestrictionPattern struct {
+	Pattern         string
+	BranchMatchKind string `mapstructure:"branch_match_kind"`
+	BranchType      string `mapstructure:"branch_type"`
+}
+
+func decodeBranchRestrictionPattern(value interface{}) (*branchRestrictionPattern, error) {
+	restriction := new(branchRestrictionPattern)
+	if err := mapstructure.Decode(value, restriction); err != nil {
+		return nil, err
+	}
+	return restriction, nil
+}
+
+// protectedBranchPatterns returns the patterns of the branch restrictions of
+// a repository. Restrictions on a branch type of the branching model are
+// resolved to the prefix of that type, or to the development or production
+// branch.
+func (r *Repository) protectedBranchPatterns(bco *BranchCleanupOptions, model *BranchingModel, mainBranch string) ([]string, error) {
+	var patterns []string
+	urlStr := r.c.requestUrl("/repositories/%s/%s/branch-restrictions", bco.Owner, bco.RepoSlug)
+	for restriction, err := range iterateAllPages(r.c, bco.ctx, urlStr, decodeBranchRestrictionPattern) {
+		if err != nil {
+			return nil, err
+		}
+		if restriction.BranchMatchKind != "branching_model" {
+			if restriction.Pattern != "" {
+				patterns = append(patterns, restriction.Pattern)
+			}
+			continue
+		}
+		pattern, err := branchTypePattern(model, restriction.BranchType, mainBranch)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// branchTypePattern returns the pattern matching the branches of a branch
+// type of a branching model. A type the model cannot resolve is an error, so
+// that its branches are never deleted unprotected.
+func branchTypePattern(model *BranchingModel, branchType string, mainBranch string) (string, error) {
+	var branch *BranchModel
+	switch branchType {
+	case "development":
+		branch = &model.Development
+	case "production":
+		branch = &model.Production
+	default:
+		for _, t := range model.Branch_Types {
+			if t.Kind == branchType && t.Prefix != "" {
+				return t.Prefix + "*", nil
+			}
+		}
+		return "", fmt.Errorf("branch type %q is not in the branching model", branchType)
+	}
+	name := modelBranchName(branch, mainBranch)
+	if name == "" {
+		return "", fmt.Errorf("branch type %q has no branch in the branching model", branchType)
+	}
+	return name, nil
+}
+
+// modelBranchName returns the name of the development or production branch
+// of a branching model, or an empty string when it has none.
+func modelBranchName(branch *BranchModel, mainBranch string) string {
+	switch {
+	case branch.Use_Mainbranch:
+		return mainBranch
+	case branch.Name != "":
+		return branch.Name
+	}
+	return branch.Branch.Name
+}
+
+// openPullRequest is the part of a pull request telling which branch it
+// merges.
+type openPullRequest struct {
+	Source struct {
+		Branch struct {
+			Name string
+		}
+		Repository struct {
+			FullName string `mapstructure:"full_name"`
+		}
+	}
+	Destination struct {
+		Repository struct {
+			FullName string `mapstructure:"full_name"`
+		}
+	}
+}
+
+func decodeOpenPullRequest(value interface{}) (*openPullRequest, error) {
+	pr := new(openPullRequest)
+	if err := mapstructure.Decode(value, pr); err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// openPullRequestBranches returns the branches of a repository that are the
+// source of an open pull request. Pull requests from forks are ignored.
+func (r *Repository) openPullRequestBranches(bco *BranchCleanupOptions) (map[string]bool, error) {
+	branches := make(map[string]bool)
+	urlStr := r.c.requestUrl("/repositories/%s/%s/pullrequests/?state=OPEN", bco.Owner, bco.RepoSlug)
+	for pr, err := range iterateAllPages(r.c, bco.ctx, urlStr, decodeOpenPullRequest) {
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(pr.Source.Repository.FullName, pr.Destination.Repository.FullName) {
+			continue
+		}
+		branches[pr.Source.Branch.Name] = true
+	}
+	return branches, nil
+}
+
+// matchBranchPatterns reports whether name matches one of patterns, and sets
+// matched to that pattern.
+func matchBranchPatterns(patterns []string, name string, matched *string) bool {
+	for _, pattern := range patterns {
+		if matchBranchPattern(pattern, name) {
+			*matched = pattern
+			return true
+		}
+	}
+	return false
+}
+
+// matchBranchPattern reports whether name matches a branch restriction
+// pattern, in which "*" matches any sequence of characters, slashes included.
+func matchBranchPattern(pattern, name string) bool {
+	star := strings.IndexByte(pattern, '*')
+	if star < 0 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, pattern[:star]) {
+		return false
+	}
+	name, pattern = name[star:], pattern[star+1:]
+	for i := 0; i <= len(name); i++ {
+		if matchBranchPattern(pattern, name[i:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,7 +17,11 @@ type BranchRestrictions struct {
 
 func (b *BranchRestrictions) Gets(bo *BranchRestrictionsOptions) (interface{}, error) {
 	urlStr := b.c.requestUrl("/repositories/%s/%s/branch-restrictions", bo.Owner, bo.RepoSlug)
-	return b.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := b.c.withFieldsParam(urlStr, bo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return b.c.executePaginatedWithContext("GET", urlStr, "", nil, bo.ctx)
 }
 
 func (b *BranchRestrictions) Create(bo *BranchRestrictionsOptions) (*BranchRestrictions, error) {
@@ -36,7 +40,11 @@ func (b *BranchRestrictions) Create(bo *BranchRestrictionsOptions) (*BranchRestr
 
 func (b *BranchRestrictions) Get(bo *BranchRestrictionsOptions) (*BranchRestrictions, error) {
 	urlStr := b.c.requestUrl("/repositories/%s/%s/branch-restrictions/%s", bo.Owner, bo.RepoSlug, bo.ID)
-	response, err := b.c.execute("GET", urlStr, "")
+	urlStr, err := b.c.withFieldsParam(urlStr, bo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := b.c.executeWithContext("GET", urlStr, "", bo.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,331 @@
+package bitbucket
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ResponseCache stores serialized HTTP responses for CacheTransport.
+// Implementations must be safe for concurrent use.
+type ResponseCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, response []byte)
+	Delete(key string)
+}
+
+type bypassCacheKey struct{}
+
+// BypassCache returns a context that makes CacheTransport skip the cached copy
+// of a response and fetch it again. The fresh response replaces the cached one.
+//
+// Pass it to any options struct that supports WithContext, e.g.
+//
+//	ro := (&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}).WithContext(bitbucket.BypassCache(ctx))
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+func cacheBypassed(req *http.Request) bool {
+	if bypass, _ := req.Context().Value(bypassCacheKey{}).(bool); bypass {
+		return true
+	}
+	cacheControl := req.Header.Get("Cache-Control")
+	return strings.Contains(cacheControl, "no-cache") || strings.Contains(cacheControl, "no-store")
+}
+
+// CacheTransport is an http.RoundTripper that caches GET responses carrying an
+// ETag or Last-Modified validator and revalidates them with If-None-Match and
+// If-Modified-Since. A 304 Not Modified answer is turned into the cached 200
+// response, so callers never see it.
+//
+// Entries are keyed by URL and by a hash of the credentials of the Client
+// sending the request, so clients with different credentials never share
+// cached data. Requests not sent by a Client are keyed by their Authorization
+// header instead. The responses of a Client whose credentials rotate, like an
+// OAuth token source, are only cached when it has a cache namespace, see
+// WithCacheNamespace.
+type CacheTransport struct {
+	Transport http.RoundTripper
+	Cache     ResponseCache
+}
+
+// NewCacheTransport returns a CacheTransport storing responses in cache and
+// sending requests through base, or http.DefaultTransport when base is nil.
+func NewCacheTransport(cache ResponseCache, base http.RoundTripper) *CacheTransport {
+	return &CacheTransport{Transport: base, Cache: cache}
+}
+
+// EnableCache wraps the client's transport with a CacheTransport backed by cache.
+func (c *Client) EnableCache(cache ResponseCache) {
+	if c.HttpClient == nil {
+		c.HttpClient = new(http.Client)
+	}
+	c.HttpClient.Transport = NewCacheTransport(cache, c.HttpClient.Transport)
+}
+
+func (t *CacheTransport) transport() http.RoundTripper {
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return http.DefaultTransport
+}
+
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || t.Cache == nil {
+		return t.transport().RoundTrip(req)
+	}
+
+	key, ok := cacheKey(req)
+	if !ok {
+		return t.transport().RoundTrip(req)
+	}
+	var cached *http.Response
+	if !cacheBypassed(req) {
+		if data, ok := t.Cache.Get(key); ok {
+			resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+			if err == nil {
+				cached = resp
+			} else {
+				t.Cache.Delete(key)
+			}
+		}
+	}
+
+	outReq := req
+	if cached != nil {
+		outReq = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" && outReq.Header.Get("If-None-Match") == "" {
+			outReq.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" && outReq.Header.Get("If-Modified-Since") == "" {
+			outReq.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.transport().RoundTrip(outReq)
+	if err != nil {
+		if cached != nil {
+			cached.Body.Close()
+		}
+		return nil, err
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		for _, name := range []string{"Date", "Expires", "Cache-Control", "ETag", "Last-Modified"} {
+			if value := resp.Header.Get(name); value != "" {
+				cached.Header.Set(name, value)
+			}
+		}
+		cached.Request = req
+		cached.Header.Set("X-From-Cache", "1")
+		return cached, nil
+	}
+	if cached != nil {
+		cached.Body.Close()
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK && cacheable(resp):
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if data, err := httputil.DumpResponse(resp, true); err == nil {
+			t.Cache.Set(key, data)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		t.Cache.Delete(key)
+	}
+
+	return resp, nil
+}
+
+func cacheable(resp *http.Response) bool {
+	if strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return false
+	}
+	return resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
+
+type cacheIdentityKey struct{}
+
+// withCacheIdentity attaches the identity of the credentials authenticating
+// req to its context, for cacheKey.
+func withCacheIdentity(req *http.Request, identity string) {
+	*req = *req.WithContext(context.WithValue(req.Context(), cacheIdentityKey{}, identity))
+}
+
+// cacheIdentity identifies the credentials of a within namespace. Unlike the
+// Authorization header, it does not change when a Connect JWT is signed. The
+// tokens of an OAuth token source rotate and tell nothing about the account
+// they belong to, so they are only identified by namespace, and not at all
+// without one: an empty identity disables the cache.
+func (a *Auth) cacheIdentity(namespace string) string {
+	var identity string
+	switch {
+	case a.connectSecret != "":
+		identity = "connect " + a.connectAppKey + " " + a.connectClientKey + " " + a.connectSecret
+	case a.user != "" && a.password != "":
+		identity = "basic " + a.user + ":" + a.password
+	case a.tokenSource != nil:
+		if namespace == "" {
+			return ""
+		}
+		identity = "oauth"
+	case a.token.AccessToken != "":
+		identity = "oauth " + a.token.AccessToken
+	case a.bearerToken != "":
+		identity = "bearer " + a.bearerToken
+	}
+	return namespace + "\x00" + identity
+}
+
+// cacheKey returns the key of the cached response to req, and false when req
+// must not be cached.
+func cacheKey(req *http.Request) (string, bool) {
+	identity, ok := req.Context().Value(cacheIdentityKey{}).(string)
+	if !ok {
+		identity = req.Header.Get("Authorization")
+	} else if identity == "" {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(identity))
+	return req.URL.String() + " " + hex.EncodeToString(sum[:8]), true
+}
+
+// MemoryCache is an in-memory ResponseCache evicting the least recently used
+// entries once MaxEntries is exceeded.
+type MemoryCache struct {
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemoryCache returns a MemoryCache holding at most maxEntries responses.
+// A maxEntries of zero means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		MaxEntries: maxEntries,
+		entries:    list.New(),
+		index:      make(map[string]*list.Element),
+	}
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.index[key]; ok {
+		m.entries.MoveToFront(el)
+		return el.Value.(*memoryCacheEntry).value, true
+	}
+	return nil, false
+}
+
+func (m *MemoryCache) Set(key string, value []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.index[key]; ok {
+		el.Value.(*memoryCacheEntry).value = value
+		m.entries.MoveToFront(el)
+		return
+	}
+	m.index[key] = m.entries.PushFront(&memoryCacheEntry{key: key, value: value})
+
+	for m.MaxEntries > 0 && m.entries.Len() > m.MaxEntries {
+		oldest := m.entries.Back()
+		m.entries.Remove(oldest)
+		delete(m.index, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+func (m *MemoryCache) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.index[key]; ok {
+		m.entries.Remove(el)
+		delete(m.index, key)
+	}
+}
+
+// Len returns the number of cached responses.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.entries.Len()
+}
+
+// DiskCache is a ResponseCache storing one file per response in Dir, so cached
+// data survives process restarts.
+type DiskCache struct {
+	Dir string
+}
+
+// NewDiskCache returns a DiskCache writing to dir, creating it if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{Dir: dir}, nil
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:]))
+}
+
+func (d *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func (d *DiskCache) Set(key string, value []byte) {
+	// Write to a temporary file first so concurrent readers never see a partial entry.
+	tmp, err := os.CreateTemp(d.Dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+func (d *DiskCache) Delete(key string) {
+	os.Remove(d.path(key))
+}
@@ -2,8 +2,6 @@ package bitbucket
 
 import (
 	"bytes"
-	"crypto/tls"
-	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,6 +12,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
@@ -35,37 +34,14 @@ func apiBaseUrl() (*url.URL, error) {
 	return url.Parse(ev)
 }
 
-func appendCaCerts(caCerts []byte) (*http.Client, error) {
-	// 1. If the system standard cert pool exists, create a copy that can be modified.
-	caCertPool, err := x509.SystemCertPool()
-	// The system cert pool does not exist, so we are going to create a new one.
-	if err != nil {
-		// The system standard cert pool does not exist so create a new empty one.
-		caCertPool = x509.NewCertPool()
-	}
-	// 2. Append the custom CA certs to the pool.
-	if success := caCertPool.AppendCertsFromPEM(caCerts); !success {
-		return nil, fmt.Errorf("unable to append CA Certs to cert pool: %w", err)
-	}
-	// 3. Create a new http.Transport
-	newTransport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			RootCAs: caCertPool,
-		},
-	}
-	// 4. Assign the new transport the http.DefaultTransport
-	http.DefaultTransport = newTransport
-	// 5. Create a new http client with the modified default transport
-	return &http.Client{Transport: http.DefaultTransport}, nil
-}
-
 type Client struct {
-	Auth         *auth
+	Auth         *Auth
 	Users        *Users
-	User         user
-	Teams        teams
+	User         UserService
+	Teams        TeamsService
 	Repositories *Repositories
 	Workspaces   *Workspace
+	Snippets     *Snippets
 	Pagelen      int
 	MaxDepth     int
 	// LimitPages limits the number of pages for a request
@@ -75,16 +51,27 @@ type Client struct {
 	// all the pages for a paginated response.
 	DisableAutoPaging bool
 	apiBaseURL        *url.URL
+	cacheNamespace    string
 
 	HttpClient *http.Client
 }
 
-type auth struct {
+// Auth holds the credentials a Client authenticates with. Use BasicCredentials,
+// BearerTokenCredentials or OAuthTokenCredentials to create one for New.
+type Auth struct {
 	appID, secret  string
 	user, password string
 	token          oauth2.Token
 	bearerToken    string
 	caCerts        []byte
+
+	connectAppKey    string
+	connectClientKey string
+	connectSecret    string
+
+	mu             sync.Mutex
+	tokenSource    oauth2.TokenSource
+	onTokenRefresh TokenRefreshFunc
 }
 
 type Response struct {
@@ -99,7 +86,7 @@ type Response struct {
 
 // Uses the Client Credentials Grant oauth2 flow to authenticate to Bitbucket
 func NewOAuthClientCredentials(i, s string) (*Client, error) {
-	a := &auth{appID: i, secret: s}
+	a := &Auth{appID: i, secret: s}
 	ctx := context.Background()
 	conf := &clientcredentials.Config{
 		ClientID:     i,
@@ -112,8 +99,8 @@ func NewOAuthClientCredentials(i, s string) (*Client, error) {
 		return nil, fmt.Errorf("failed to obtain token: %w", err)
 	}
 	a.token = *tok
-	return injectClient(a)
-
+	a.tokenSource = oauth2.ReuseTokenSource(tok, conf.TokenSource(ctx))
+	return New(a)
 }
 
 // NewOAuth performs an interactive OAuth flow using stdin/stdout.
@@ -121,9 +108,10 @@ func NewOAuthClientCredentials(i, s string) (*Client, error) {
 // Deprecated: This function uses stdin/stdout directly, making it unsuitable for
 // non-interactive environments (e.g., web servers, background jobs). Instead, use
 // NewOAuthWithCode after obtaining the authorization code through your own UI/CLI.
-// You can generate the authorization URL using oauth2.Config.AuthCodeURL() directly.
+// You can generate the authorization URL using oauth2.Config.AuthCodeURL() directly,
+// or use NewOAuthWithLoopback to run the whole flow from a command line tool.
 func NewOAuth(i, s string) (*Client, error) {
-	a := &auth{appID: i, secret: s}
+	a := &Auth{appID: i, secret: s}
 	ctx := context.Background()
 	conf := &oauth2.Config{
 		ClientID:     i,
@@ -150,13 +138,14 @@ func NewOAuth(i, s string) (*Client, error) {
 		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
 	}
 	a.token = *tok
-	return injectClient(a)
+	a.tokenSource = conf.TokenSource(ctx, tok)
+	return New(a)
 }
 
 // NewOAuthWithCode finishes the OAuth handshake with a given code
 // and returns a *Client
 func NewOAuthWithCode(i, s, c string) (*Client, string, error) {
-	a := &auth{appID: i, secret: s}
+	a := &Auth{appID: i, secret: s}
 	ctx := context.Background()
 	conf := &oauth2.Config{
 		ClientID:     i,
@@ -169,7 +158,8 @@ func NewOAuthWithCode(i, s, c string) (*Client, string, error) {
 		return nil, "", fmt.Errorf("failed to exchange authorization code: %w", err)
 	}
 	a.token = *tok
-	client, err := injectClient(a)
+	a.tokenSource = conf.TokenSource(ctx, tok)
+	client, err := New(a)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create client: %w", err)
 	}
@@ -179,7 +169,7 @@ func NewOAuthWithCode(i, s, c string) (*Client, string, error) {
 // NewOAuthWithRefreshToken obtains a new access token with a given refresh token
 // and returns a *Client
 func NewOAuthWithRefreshToken(i, s, rt string) (*Client, string, error) {
-	a := &auth{appID: i, secret: s}
+	a := &Auth{appID: i, secret: s}
 	ctx := context.Background()
 	conf := &oauth2.Config{
 		ClientID:     i,
@@ -195,7 +185,8 @@ func NewOAuthWithRefreshToken(i, s, rt string) (*Client, string, error) {
 		return nil, "", fmt.Errorf("failed to refresh token: %w", err)
 	}
 	a.token = *tok
-	client, err := injectClient(a)
+	a.tokenSource = conf.TokenSource(ctx, tok)
+	client, err := New(a)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to create client: %w", err)
 	}
@@ -203,64 +194,29 @@ func NewOAuthWithRefreshToken(i, s, rt string) (*Client, string, error) {
 }
 
 func NewOAuthbearerToken(t string) (*Client, error) {
-	a := &auth{bearerToken: t}
-	return injectClient(a)
+	a := &Auth{bearerToken: t}
+	return New(a)
 }
 
 func NewOAuthbearerTokenWithCaCert(t string, c []byte) (*Client, error) {
-	a := &auth{bearerToken: t, caCerts: c}
-	return injectClient(a)
+	a := &Auth{bearerToken: t, caCerts: c}
+	return New(a)
 }
 
 func NewBasicAuth(u, p string) (*Client, error) {
-	a := &auth{user: u, password: p}
-	return injectClient(a)
+	a := &Auth{user: u, password: p}
+	return New(a)
 }
 
 func NewBasicAuthWithCaCert(u, p string, c []byte) (*Client, error) {
-	a := &auth{user: u, password: p, caCerts: c}
-	return injectClient(a)
-}
-
-func injectClient(a *auth) (*Client, error) {
-	bitbucketUrl, err := apiBaseUrl()
-	if err != nil {
-		return nil, fmt.Errorf("invalid bitbucket url: %w", err)
-	}
-	c := &Client{Auth: a, Pagelen: DEFAULT_PAGE_LENGTH, MaxDepth: DEFAULT_MAX_DEPTH,
-		apiBaseURL: bitbucketUrl, LimitPages: DEFAULT_LIMIT_PAGES}
-	c.Repositories = &Repositories{
-		c:                  c,
-		PullRequests:       &PullRequests{c: c},
-		Pipelines:          &Pipelines{c: c},
-		Repository:         &Repository{c: c},
-		Issues:             &Issues{c: c},
-		Commits:            &Commits{c: c},
-		Diff:               &Diff{c: c},
-		BranchRestrictions: &BranchRestrictions{c: c},
-		Webhooks:           &Webhooks{c: c},
-		Downloads:          &Downloads{c: c},
-		DeployKeys:         &DeployKeys{c: c},
-	}
-	c.Users = &Users{
-		c:       c,
-		SSHKeys: &SSHKeys{c: c},
-	}
-	c.User = &User{c: c}
-	c.Teams = &Teams{c: c}
-	c.Workspaces = &Workspace{c: c, Repositories: c.Repositories, Permissions: &Permission{c: c}}
-	if a.caCerts != nil {
-		c.HttpClient, err = appendCaCerts(a.caCerts)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		c.HttpClient = new(http.Client)
-	}
-	return c, nil
+	a := &Auth{user: u, password: p, caCerts: c}
+	return New(a)
 }
 
+// GetOAuthToken returns the OAuth token most recently used by the client.
 func (c *Client) GetOAuthToken() oauth2.Token {
+	c.Auth.mu.Lock()
+	defer c.Auth.mu.Unlock()
 	return c.Auth.token
 }
 
@@ -272,11 +228,21 @@ func (c *Client) GetApiHostnameURL() string {
 	return fmt.Sprintf("%s://%s", c.apiBaseURL.Scheme, c.apiBaseURL.Host)
 }
 
+// GetWebBaseURL returns the URL of the Bitbucket website matching the API
+// base URL, e.g. https://bitbucket.org for https://api.bitbucket.org/2.0.
+func (c *Client) GetWebBaseURL() string {
+	return fmt.Sprintf("%s://%s", c.apiBaseURL.Scheme, strings.TrimPrefix(c.apiBaseURL.Host, "api."))
+}
+
 func (c *Client) SetApiBaseURL(urlStr url.URL) {
 	c.apiBaseURL = &urlStr
 }
 
 func (c *Client) executeRaw(method string, urlStr string, text string) (io.ReadCloser, error) {
+	return c.executeRawWithContext(method, urlStr, text, context.Background())
+}
+
+func (c *Client) executeRawWithContext(method string, urlStr string, text string, ctx context.Context) (io.ReadCloser, error) {
 	body := strings.NewReader(text)
 
 	req, err := http.NewRequest(method, urlStr, body)
@@ -286,8 +252,13 @@ func (c *Client) executeRaw(method string, urlStr string, text string) (io.ReadC
 	if text != "" {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
 
-	c.authenticateRequest(req)
+	if err := c.authenticateRequest(req); err != nil {
+		return nil, err
+	}
 	return c.doRawRequest(req, false)
 }
 
@@ -305,9 +276,11 @@ func (c *Client) executeWithContext(method string, urlStr string, text string, c
 		req.Header.Set("Content-Type", "application/json")
 	}
 	if ctx != nil {
-		req.WithContext(ctx)
+		req = req.WithContext(ctx)
+	}
+	if err := c.authenticateRequest(req); err != nil {
+		return nil, err
 	}
-	c.authenticateRequest(req)
 	result, err := c.doRequest(req, false)
 	if err != nil {
 		return nil, err
@@ -317,6 +290,10 @@ func (c *Client) executeWithContext(method string, urlStr string, text string, c
 }
 
 func (c *Client) executePaginated(method string, urlStr string, text string, page *int) (interface{}, error) {
+	return c.executePaginatedWithContext(method, urlStr, text, page, context.Background())
+}
+
+func (c *Client) executePaginatedWithContext(method string, urlStr string, text string, page *int, ctx context.Context) (interface{}, error) {
 	if c.Pagelen != DEFAULT_PAGE_LENGTH {
 		urlObj, err := url.Parse(urlStr)
 		if err != nil {
@@ -336,8 +313,10 @@ func (c *Client) executePaginated(method string, urlStr string, text string, pag
 	if text != "" {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
 
-	c.authenticateRequest(req)
 	result, err := c.doPaginatedRequest(req, page, false)
 	if err != nil {
 		return nil, err
@@ -347,36 +326,63 @@ func (c *Client) executePaginated(method string, urlStr string, text string, pag
 }
 
 func (c *Client) executeFileUpload(method string, urlStr string, files []File, filesToDelete []string, params map[string]string, ctx context.Context) (interface{}, error) {
+	form := uploadForm{files: files, deleteField: "files", filesToDelete: filesToDelete, params: params}
+	return c.executeUpload(method, urlStr, form, true, ctx)
+}
+
+// uploadForm describes a multipart/form-data request body.
+type uploadForm struct {
+	files []File
+	// fileField is the form field of each file; empty uses the file name.
+	fileField     string
+	deleteField   string
+	filesToDelete []string
+	params        map[string]string
+}
+
+func (c *Client) executeUpload(method string, urlStr string, form uploadForm, emptyResponse bool, ctx context.Context) (interface{}, error) {
 	// Prepare a form that you will submit to that URL.
 	var b bytes.Buffer
 	w := multipart.NewWriter(&b)
 
 	var fw io.Writer
-	for _, file := range files {
-		fileReader, err := os.Open(file.Path)
-		if err != nil {
+	for _, file := range form.files {
+		field := form.fileField
+		if field == "" {
+			field = file.Name
+		}
+		var err error
+		if fw, err = w.CreateFormFile(field, file.Name); err != nil {
 			return nil, err
 		}
-		defer fileReader.Close()
 
-		if fw, err = w.CreateFormFile(file.Name, file.Name); err != nil {
+		if file.Content != nil {
+			if _, err = fw.Write(file.Content); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		fileReader, err := os.Open(file.Path)
+		if err != nil {
 			return nil, err
 		}
+		defer fileReader.Close()
 
 		if _, err = io.Copy(fw, fileReader); err != nil {
 			return nil, err
 		}
 	}
 
-	for key, value := range params {
+	for key, value := range form.params {
 		err := w.WriteField(key, value)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	for _, filename := range filesToDelete {
-		err := w.WriteField("files", filename)
+	for _, filename := range form.filesToDelete {
+		err := w.WriteField(form.deleteField, filename)
 		if err != nil {
 			return nil, err
 		}
@@ -394,23 +400,40 @@ func (c *Client) executeFileUpload(method string, urlStr string, files []File, f
 	// Don't forget to set the content type, this will contain the boundary.
 	req.Header.Set("Content-Type", w.FormDataContentType())
 	if ctx != nil {
-		req.WithContext(ctx)
+		req = req.WithContext(ctx)
+	}
+	if err := c.authenticateRequest(req); err != nil {
+		return nil, err
 	}
-	c.authenticateRequest(req)
-	return c.doRequest(req, true)
+	return c.doRequest(req, emptyResponse)
 
 }
 
-func (c *Client) authenticateRequest(req *http.Request) {
+func (c *Client) authenticateRequest(req *http.Request) error {
+	withCacheIdentity(req, c.Auth.cacheIdentity(c.cacheNamespace))
+
+	if c.Auth.connectSecret != "" {
+		return c.Auth.signConnectRequest(req)
+	}
+
+	// Basic credentials and OAuth tokens override a bearer token.
 	if c.Auth.bearerToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.Auth.bearerToken)
 	}
 
 	if c.Auth.user != "" && c.Auth.password != "" {
 		req.SetBasicAuth(c.Auth.user, c.Auth.password)
-	} else if c.Auth.token.Valid() {
-		c.Auth.token.SetAuthHeader(req)
+		return nil
 	}
+
+	tok, err := c.Auth.oauthToken()
+	if err != nil {
+		return err
+	}
+	if tok != nil {
+		tok.SetAuthHeader(req)
+	}
+	return nil
 }
 
 func (c *Client) doRequest(req *http.Request, emptyResponse bool) (interface{}, error) {
@@ -449,6 +472,10 @@ func (c *Client) doPaginatedRequest(req *http.Request, page *int, emptyResponse
 	// q.Encode() does not encode "~".
 	req.URL.RawQuery = strings.ReplaceAll(req.URL.RawQuery, "~", "%7E")
 
+	// Authenticate only once the URL is final, request signatures may cover the query.
+	if err := c.authenticateRequest(req); err != nil {
+		return nil, err
+	}
 	resBody, err := c.doRawRequest(req, emptyResponse)
 	if err != nil {
 		return nil, err
@@ -474,11 +501,13 @@ func (c *Client) doPaginatedRequest(req *http.Request, page *int, emptyResponse
 				break
 			}
 			curPage++
-			newReq, err := http.NewRequest(req.Method, responsePaginated.Next, nil)
+			newReq, err := http.NewRequestWithContext(req.Context(), req.Method, responsePaginated.Next, nil)
 			if err != nil {
 				return resBody, err
 			}
-			c.authenticateRequest(newReq)
+			if err := c.authenticateRequest(newReq); err != nil {
+				return resBody, err
+			}
 			resp, err := c.doRawRequest(newReq, false)
 			if err != nil {
 				return resBody, err
@@ -503,6 +532,26 @@ func (c *Client) doPaginatedRequest(req *http.Request, page *int, emptyResponse
 }
 
 func (c *Client) doRawRequest(req *http.Request, emptyResponse bool) (io.ReadCloser, error) {
+	resp, err := c.doRawResponse(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if emptyResponse || resp.StatusCode == http.StatusNoContent {
+		resp.Body.Close()
+		return nil, nil
+	}
+
+	if resp.Body == nil {
+		return nil, fmt.Errorf("response body is nil")
+	}
+
+	return resp.Body, nil
+}
+
+// doRawResponse sends req and returns the response, or an
+// UnexpectedResponseStatusError if its status does not indicate success.
+func (c *Client) doRawResponse(req *http.Request) (*http.Response, error) {
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -523,16 +572,7 @@ func (c *Client) doRawRequest(req *http.Request, emptyResponse bool) (io.ReadClo
 		return nil, out
 	}
 
-	if emptyResponse || resp.StatusCode == http.StatusNoContent {
-		resp.Body.Close()
-		return nil, nil
-	}
-
-	if resp.Body == nil {
-		return nil, fmt.Errorf("response body is nil")
-	}
-
-	return resp.Body, nil
+	return resp, nil
 }
 
 func unexpectedHttpStatusCode(statusCode int) bool {
@@ -555,6 +595,59 @@ func (c *Client) requestUrl(template string, args ...interface{}) string {
 	return c.GetApiBaseURL() + fmt.Sprintf(template, args...)
 }
 
+// addFieldsParam sets the "fields" query parameter so that Bitbucket returns a
+// partial response. A field prefixed with "-" is excluded from the default set
+// and one prefixed with "+" is added to it, e.g. "-values.links,+values.owner".
+// When paginated is set, the request is auto paged and the "next" link is kept
+// in the response, see keepPaginationFields.
+func (c *Client) addFieldsParam(params *url.Values, fields []string, paginated bool) {
+	if len(fields) > 0 {
+		params.Set("fields", cleanFields(fields))
+		if paginated {
+			keepPaginationFields(params)
+		}
+	}
+}
+
+// withFieldsParam returns urlStr with the "fields" query parameter applied.
+func (c *Client) withFieldsParam(urlStr string, fields []string, paginated bool) (string, error) {
+	if len(fields) == 0 {
+		return urlStr, nil
+	}
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		return "", err
+	}
+	query := parsed.Query()
+	c.addFieldsParam(&query, fields, paginated)
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+// keepPaginationFields makes sure a paginated request that narrows the returned
+// fields still receives the "next" link, otherwise auto paging would silently
+// stop after the first page.
+func keepPaginationFields(params *url.Values) {
+	fields := params.Get("fields")
+	if fields == "" {
+		return
+	}
+
+	replacesDefaults := false
+	for _, field := range strings.Split(fields, ",") {
+		switch {
+		case field == "next" || field == "*" || field == "+next":
+			return
+		case field != "" && !strings.HasPrefix(field, "+") && !strings.HasPrefix(field, "-"):
+			replacesDefaults = true
+		}
+	}
+
+	if replacesDefaults {
+		params.Set("fields", fields+",next")
+	}
+}
+
 func (c *Client) addMaxDepthParam(params *url.Values, customMaxDepth *int) {
 	maxDepth := c.MaxDepth
 	if customMaxDepth != nil && *customMaxDepth > 0 {
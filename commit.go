@@ -0,0 +1,297 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// CommitFileMode is the mode of a file written by a CommitBuilder.
+type CommitFileMode int
+
+const (
+	CommitFileRegular CommitFileMode = iota
+	CommitFileExecutable
+	// CommitFileSymlink files contain the target of the link.
+	CommitFileSymlink
+)
+
+type commitFile struct {
+	path    string
+	content io.Reader
+	mode    CommitFileMode
+}
+
+type commitRename struct {
+	from, to string
+}
+
+// CommitBuilder creates a single commit adding, changing, renaming and
+// deleting files from in-memory content. Either all changes are committed or
+// none. Create one with Repository.NewCommit.
+//
+// The commit is sent as a streamed multipart body, so contents are read only
+// once while the request is written and large files are not buffered.
+//
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-source/#api-repositories-workspace-repo-slug-src-post
+type CommitBuilder struct {
+	c        *Client
+	owner    string
+	repoSlug string
+
+	message string
+	author  string
+	branch  string
+	parents []string
+	files   []commitFile
+	deletes []string
+	renames []commitRename
+	err     error
+}
+
+// NewCommit returns a builder for a commit to a repository.
+func (r *Repository) NewCommit(owner, repoSlug string) *CommitBuilder {
+	return &CommitBuilder{c: r.c, owner: owner, repoSlug: repoSlug}
+}
+
+// Message sets the commit message.
+func (b *CommitBuilder) Message(message string) *CommitBuilder {
+	b.message = message
+	return b
+}
+
+// Author sets the commit author, e.g. "Jane Doe <jane@example.com>". It
+// defaults to the authenticated user.
+func (b *CommitBuilder) Author(author string) *CommitBuilder {
+	b.author = author
+	return b
+}
+
+// Branch sets the branch to commit to. It is created if it does not exist, and
+// defaults to the main branch.
+func (b *CommitBuilder) Branch(branch string) *CommitBuilder {
+	b.branch = branch
+	return b
+}
+
+// Parents sets the parent commits. When a branch is set too, the commit fails
+// unless the tip of the branch is one of the parents, which guards against
+// overwriting concurrent changes.
+func (b *CommitBuilder) Parents(hashes ...string) *CommitBuilder {
+	b.parents = append(b.parents, hashes...)
+	return b
+}
+
+// Put adds or replaces the file at path with content read from r.
+func (b *CommitBuilder) Put(path string, r io.Reader) *CommitBuilder {
+	return b.PutMode(path, r, CommitFileRegular)
+}
+
+// PutBytes adds or replaces the file at path with content.
+func (b *CommitBuilder) PutBytes(path string, content []byte) *CommitBuilder {
+	return b.PutMode(path, bytes.NewReader(content), CommitFileRegular)
+}
+
+// PutMode adds or replaces the file at path with content read from r and the
+// given mode.
+func (b *CommitBuilder) PutMode(path string, r io.Reader, mode CommitFileMode) *CommitBuilder {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" || r == nil {
+		b.setErr(fmt.Errorf("invalid file %q", path))
+		return b
+	}
+	b.files = append(b.files, commitFile{path: path, content: r, mode: mode})
+	return b
+}
+
+// Symlink adds or replaces a symbolic link at path pointing to target.
+func (b *CommitBuilder) Symlink(path, target string) *CommitBuilder {
+	return b.PutMode(path, strings.NewReader(target), CommitFileSymlink)
+}
+
+// Delete deletes the file at path.
+func (b *CommitBuilder) Delete(path string) *CommitBuilder {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		b.setErr(errors.New("invalid file to delete"))
+		return b
+	}
+	b.deletes = append(b.deletes, path)
+	return b
+}
+
+// Rename moves the file at from to to, keeping its content and mode. The file
+// is read from the first parent, or else from the branch, so one of them must
+// be set.
+func (b *CommitBuilder) Rename(from, to string) *CommitBuilder {
+	from, to = strings.TrimPrefix(from, "/"), strings.TrimPrefix(to, "/")
+	if from == "" || to == "" || from == to {
+		b.setErr(fmt.Errorf("invalid rename of %q to %q", from, to))
+		return b
+	}
+	b.renames = append(b.renames, commitRename{from: from, to: to})
+	return b
+}
+
+func (b *CommitBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// Commit creates the commit and returns its hash.
+func (b *CommitBuilder) Commit(ctx context.Context) (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	if len(b.files) == 0 && len(b.deletes) == 0 && len(b.renames) == 0 {
+		return "", errors.New("the commit has no changes")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	files := b.files
+	deletes := b.deletes
+	for _, rename := range b.renames {
+		file, err := b.openRenamed(ctx, rename)
+		if err != nil {
+			return "", err
+		}
+		defer file.content.(io.Closer).Close()
+		files = append(files, file)
+		deletes = append(deletes, rename.from)
+	}
+
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(b.writeForm(w, files, deletes))
+	}()
+	defer pr.Close()
+
+	urlStr := b.c.requestUrl("/repositories/%s/%s/src", b.owner, b.repoSlug)
+	req, err := http.NewRequestWithContext(ctx, "POST", urlStr, pr)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	if err := b.c.authenticateRequest(req); err != nil {
+		return "", err
+	}
+	resp, err := b.c.doRawResponse(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	return commitHashFromLocation(resp.Header.Get("Location"))
+}
+
+func (b *CommitBuilder) writeForm(w *multipart.Writer, files []commitFile, deletes []string) error {
+	fields := [][2]string{{"message", b.message}, {"author", b.author}, {"branch", b.branch}}
+	if len(b.parents) > 0 {
+		fields = append(fields, [2]string{"parents", strings.Join(b.parents, ",")})
+	}
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+		if err := w.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range files {
+		header := make(textproto.MIMEHeader)
+		disposition := fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(file.path), escapeQuotes(path.Base(file.path)))
+		// The x-attributes syntax is the one documented by Bitbucket, even
+		// though it is not a valid MIME parameter.
+		switch file.mode {
+		case CommitFileExecutable:
+			disposition += `; x-attributes:"executable"`
+		case CommitFileSymlink:
+			disposition += `; x-attributes:"link"`
+		}
+		header.Set("Content-Disposition", disposition)
+		header.Set("Content-Type", "application/octet-stream")
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file.content); err != nil {
+			return err
+		}
+	}
+
+	// A path listed in the files field without content is deleted.
+	for _, path := range deletes {
+		if err := w.WriteField("files", path); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// openRenamed opens the content of a renamed file at the source revision and
+// looks up its mode.
+func (b *CommitBuilder) openRenamed(ctx context.Context, rename commitRename) (commitFile, error) {
+	ref := b.branch
+	if len(b.parents) > 0 {
+		ref = b.parents[0]
+	}
+	if ref == "" {
+		return commitFile{}, fmt.Errorf("renaming %q requires a parent or a branch", rename.from)
+	}
+	urlStr := b.c.requestUrl("/repositories/%s/%s/src/%s/%s", b.owner, b.repoSlug, url.PathEscape(ref), escapeTreePath(rename.from))
+
+	meta, err := b.c.executeWithContext("GET", urlStr+"?format=meta", "", ctx)
+	if err != nil {
+		return commitFile{}, err
+	}
+	mode := CommitFileRegular
+	metaMap, _ := meta.(map[string]interface{})
+	attributes, _ := metaMap["attributes"].([]interface{})
+	for _, attribute := range attributes {
+		switch attribute {
+		case "executable":
+			mode = CommitFileExecutable
+		case "link":
+			mode = CommitFileSymlink
+		}
+	}
+
+	content, err := b.c.executeRawWithContext("GET", urlStr, "", ctx)
+	if err != nil {
+		return commitFile{}, err
+	}
+	return commitFile{path: rename.to, content: content, mode: mode}, nil
+}
+
+// commitHashFromLocation returns the hash of the commit the Location of a
+// created commit points to, ".../repositories/{workspace}/{repo}/commit/{hash}".
+func commitHashFromLocation(location string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil || location == "" {
+		return "", fmt.Errorf("unexpected commit location %q", location)
+	}
+	segments := strings.Split(strings.TrimSuffix(u.Path, "/"), "/")
+	if len(segments) < 2 || segments[len(segments)-2] != "commit" {
+		return "", fmt.Errorf("unexpected commit location %q", location)
+	}
+	return segments[len(segments)-1], nil
+}
@@ -2,42 +2,86 @@ package bitbucket
 
 import (
 	"encoding/json"
+	"errors"
 	"net/url"
+	"time"
 )
 
 type Commits struct {
 	c *Client
 }
 
+// Commit is a commit of a repository.
+type Commit struct {
+	Type    string
+	Hash    string
+	Date    time.Time
+	Message string
+	Author  CommitAuthor
+	Parents []Commit
+	Links   map[string]interface{}
+}
+
+// CommitAuthor is the author of a commit. User is only set when the author
+// matches a Bitbucket account.
+type CommitAuthor struct {
+	Raw  string
+	User *User
+}
+
 func (cm *Commits) GetCommits(cmo *CommitsOptions) (interface{}, error) {
 	urlStr := cm.c.requestUrl("/repositories/%s/%s/commits/%s", cmo.Owner, cmo.RepoSlug, cmo.Branchortag)
 	urlStr += cm.buildCommitsQuery(cmo.Include, cmo.Exclude)
-	return cm.c.executePaginated("GET", urlStr, "", cmo.Page)
+	urlStr, err := cm.c.withFieldsParam(urlStr, cmo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return cm.c.executePaginatedWithContext("GET", urlStr, "", cmo.Page, cmo.ctx)
 }
 
 func (cm *Commits) GetCommit(cmo *CommitsOptions) (interface{}, error) {
 	urlStr := cm.c.requestUrl("/repositories/%s/%s/commit/%s", cmo.Owner, cmo.RepoSlug, cmo.Revision)
-	return cm.c.execute("GET", urlStr, "")
+	urlStr, err := cm.c.withFieldsParam(urlStr, cmo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return cm.c.executeWithContext("GET", urlStr, "", cmo.ctx)
 }
 
 func (cm *Commits) GetCommitComments(cmo *CommitsOptions) (interface{}, error) {
 	urlStr := cm.c.requestUrl("/repositories/%s/%s/commit/%s/comments", cmo.Owner, cmo.RepoSlug, cmo.Revision)
-	return cm.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := cm.c.withFieldsParam(urlStr, cmo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return cm.c.executePaginatedWithContext("GET", urlStr, "", nil, cmo.ctx)
 }
 
 func (cm *Commits) GetCommitComment(cmo *CommitsOptions) (interface{}, error) {
 	urlStr := cm.c.requestUrl("/repositories/%s/%s/commit/%s/comments/%s", cmo.Owner, cmo.RepoSlug, cmo.Revision, cmo.CommentID)
-	return cm.c.execute("GET", urlStr, "")
+	urlStr, err := cm.c.withFieldsParam(urlStr, cmo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return cm.c.executeWithContext("GET", urlStr, "", cmo.ctx)
 }
 
 func (cm *Commits) GetCommitStatuses(cmo *CommitsOptions) (interface{}, error) {
 	urlStr := cm.c.requestUrl("/repositories/%s/%s/commit/%s/statuses", cmo.Owner, cmo.RepoSlug, cmo.Revision)
-	return cm.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := cm.c.withFieldsParam(urlStr, cmo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return cm.c.executePaginatedWithContext("GET", urlStr, "", nil, cmo.ctx)
 }
 
 func (cm *Commits) GetCommitStatus(cmo *CommitsOptions, commitStatusKey string) (interface{}, error) {
 	urlStr := cm.c.requestUrl("/repositories/%s/%s/commit/%s/statuses/build/%s", cmo.Owner, cmo.RepoSlug, cmo.Revision, commitStatusKey)
-	return cm.c.execute("GET", urlStr, "")
+	urlStr, err := cm.c.withFieldsParam(urlStr, cmo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return cm.c.executeWithContext("GET", urlStr, "", cmo.ctx)
 }
 
 func (cm *Commits) GiveApprove(cmo *CommitsOptions) (interface{}, error) {
@@ -75,3 +119,19 @@ func (cm *Commits) buildCommitsQuery(include, exclude string) string {
 	}
 	return ""
 }
+
+func decodeCommit(response interface{}) (*Commit, error) {
+	commitMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+	if commitMap["type"] == "error" {
+		return nil, DecodeError(commitMap)
+	}
+
+	var commit = new(Commit)
+	if err := decodeWithTimeHook(commitMap, commit); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
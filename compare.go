@@ -0,0 +1,118 @@
+package bitbucket
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// compareCountPagelen is the page length used to count commits, for which
+// only the hashes are requested.
+const compareCountPagelen = 100
+
+// Comparison compares two revisions, like "git log base..head" and
+// "git diff base...head" do.
+type Comparison struct {
+	Base      string
+	Head      string
+	MergeBase *Commit
+	// Ahead is the number of commits of head that are not in base, and
+	// Behind the number of commits of base that are not in head.
+	Ahead  int
+	Behind int
+	// Commits are the commits of head that are not in base, newest first.
+	Commits []Commit
+	// DiffStats are the changes of head since the merge base.
+	DiffStats    []*DiffStat
+	FilesChanged int
+	LinesAdded   int
+	LinesRemoved int
+}
+
+// MergeBase returns the best common ancestor of CompareOptions.Base and
+// CompareOptions.Head.
+//
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-merge-base-revspec-get
+func (r *Repository) MergeBase(co *CompareOptions) (*Commit, error) {
+	urlStr := r.c.requestUrl("/repositories/%s/%s/merge-base/%s", co.Owner, co.RepoSlug,
+		url.PathEscape(co.Base+".."+co.Head))
+	response, err := r.c.executeWithContext("GET", urlStr, "", co.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCommit(response)
+}
+
+// Compare compares CompareOptions.Head to CompareOptions.Base: it returns
+// their merge base, the commits of head missing from base, the number of
+// commits head is ahead and behind, and the diff stat of head since the merge
+// base. The commits missing from head are counted without being decoded.
+func (r *Repository) Compare(co *CompareOptions) (*Comparison, error) {
+	mergeBase, err := r.MergeBase(co)
+	if err != nil {
+		return nil, err
+	}
+	comparison := &Comparison{Base: co.Base, Head: co.Head, MergeBase: mergeBase}
+
+	for commit, err := range iteratePages(r.c, co.ctx, r.c.commitRangeUrl(co.Owner, co.RepoSlug, co.Head, co.Base, nil), decodeCommit) {
+		if err != nil {
+			return nil, err
+		}
+		comparison.Commits = append(comparison.Commits, *commit)
+	}
+	comparison.Ahead = len(comparison.Commits)
+
+	count := url.Values{}
+	count.Set("fields", "values.hash,next")
+	count.Set("pagelen", strconv.Itoa(compareCountPagelen))
+	for _, err := range iteratePages(r.c, co.ctx, r.c.commitRangeUrl(co.Owner, co.RepoSlug, co.Base, co.Head, count), decodeCommit) {
+		if err != nil {
+			return nil, err
+		}
+		comparison.Behind++
+	}
+
+	// The "three-dot" diff stat of head against base starts at their merge
+	// base.
+	stats := url.Values{}
+	stats.Set("topic", "true")
+	urlStr := r.c.requestUrl("/repositories/%s/%s/diffstat/%s?%s", co.Owner, co.RepoSlug,
+		url.PathEscape(co.Head+".."+co.Base), stats.Encode())
+	for stat, err := range iteratePages(r.c, co.ctx, urlStr, decodeDiffStatValue) {
+		if err != nil {
+			return nil, err
+		}
+		comparison.DiffStats = append(comparison.DiffStats, stat)
+		comparison.FilesChanged++
+		comparison.LinesAdded += stat.LinedAdded
+		comparison.LinesRemoved += stat.LinesRemoved
+	}
+	return comparison, nil
+}
+
+// commitRangeUrl returns the URL of the commits reachable from include and
+// not from exclude, if set.
+func (c *Client) commitRangeUrl(owner, repoSlug, include, exclude string, params url.Values) string {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("include", include)
+	if exclude != "" {
+		params.Set("exclude", exclude)
+	}
+	return c.requestUrl("/repositories/%s/%s/commits?%s", owner, repoSlug, params.Encode())
+}
+
+func decodeDiffStatValue(value interface{}) (*DiffStat, error) {
+	var stat = new(DiffStat)
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:  stat,
+		TagName: "json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = decoder.Decode(value)
+	return stat, err
+}
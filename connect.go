@@ -0,0 +1,242 @@
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// connectTokenLifetime is how long a JWT signed for an outgoing request is valid.
+const connectTokenLifetime = 3 * time.Minute
+
+// connectClockSkew is the clock difference tolerated when verifying incoming JWTs.
+const connectClockSkew = time.Minute
+
+// ConnectClaims are the claims of an Atlassian Connect JWT.
+type ConnectClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	// QSH is the query string hash binding the token to a single request.
+	QSH     string                 `json:"qsh,omitempty"`
+	Context map[string]interface{} `json:"context,omitempty"`
+}
+
+// ConnectSecretLookup returns the shared secret for the installation that
+// issued a token, usually found by claims.Issuer, the installation's clientKey.
+// The claims passed to it have not been verified yet.
+type ConnectSecretLookup func(claims *ConnectClaims) (sharedSecret string, err error)
+
+// ConnectCredentials returns an Auth signing every request with an Atlassian
+// Connect JWT, for apps calling the API on behalf of an installation. appKey is
+// the key from the app descriptor, clientKey and sharedSecret are received in
+// the installed lifecycle callback.
+func ConnectCredentials(appKey, clientKey, sharedSecret string) *Auth {
+	return &Auth{connectAppKey: appKey, connectClientKey: clientKey, connectSecret: sharedSecret}
+}
+
+// NewConnectJWT returns a Client authenticating with an Atlassian Connect JWT,
+// see ConnectCredentials.
+func NewConnectJWT(appKey, clientKey, sharedSecret string, opts ...Option) (*Client, error) {
+	return New(ConnectCredentials(appKey, clientKey, sharedSecret), opts...)
+}
+
+func (a *Auth) signConnectRequest(req *http.Request) error {
+	now := time.Now()
+	token, err := SignConnectJWT(&ConnectClaims{
+		Issuer:    a.connectAppKey,
+		Subject:   a.connectClientKey,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(connectTokenLifetime).Unix(),
+		QSH:       ConnectQSH(req.Method, req.URL, ""),
+	}, a.connectSecret)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "JWT "+token)
+	return nil
+}
+
+// SignConnectJWT returns claims as a JWT signed with HS256 and sharedSecret.
+func SignConnectJWT(claims *ConnectClaims, sharedSecret string) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(connectSignature(signingInput, sharedSecret)), nil
+}
+
+func connectSignature(signingInput, sharedSecret string) []byte {
+	mac := hmac.New(sha256.New, []byte(sharedSecret))
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+// VerifyConnectRequest verifies the Atlassian Connect JWT sent with an incoming
+// lifecycle or webhook request, either in the Authorization header or in the
+// "jwt" query parameter, and returns its claims.
+//
+// The signature is checked with the secret returned by lookup, the token must
+// not be expired, and when it carries a query string hash, the hash must match
+// r. basePath is the path of the app's base URL, which is not part of the hash.
+func VerifyConnectRequest(r *http.Request, basePath string, lookup ConnectSecretLookup) (*ConnectClaims, error) {
+	token := r.URL.Query().Get("jwt")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "JWT ") {
+		token = strings.TrimPrefix(auth, "JWT ")
+	}
+	if token == "" {
+		return nil, errors.New("connect jwt: no token in request")
+	}
+
+	claims, err := verifyConnectJWT(token, lookup, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	// Tokens with the "context-qsh" placeholder are not bound to a request.
+	if claims.QSH != "" && claims.QSH != "context-qsh" {
+		expected := ConnectQSH(r.Method, r.URL, basePath)
+		if subtle.ConstantTimeCompare([]byte(claims.QSH), []byte(expected)) != 1 {
+			return nil, errors.New("connect jwt: query string hash does not match the request")
+		}
+	}
+	return claims, nil
+}
+
+func verifyConnectJWT(token string, lookup ConnectSecretLookup, now time.Time) (*ConnectClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("connect jwt: malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("connect jwt: unsupported algorithm %q", header.Alg)
+	}
+
+	claims := &ConnectClaims{}
+	if err := decodeJWTSegment(parts[1], claims); err != nil {
+		return nil, err
+	}
+	secret, err := lookup(claims)
+	if err != nil {
+		return nil, fmt.Errorf("connect jwt: %w", err)
+	}
+	if secret == "" {
+		return nil, errors.New("connect jwt: unknown issuer")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("connect jwt: malformed signature: %w", err)
+	}
+	if !hmac.Equal(signature, connectSignature(parts[0]+"."+parts[1], secret)) {
+		return nil, errors.New("connect jwt: invalid signature")
+	}
+
+	if claims.ExpiresAt == 0 || now.Add(-connectClockSkew).Unix() > claims.ExpiresAt {
+		return nil, errors.New("connect jwt: token has expired")
+	}
+	if now.Add(connectClockSkew).Unix() < claims.IssuedAt {
+		return nil, errors.New("connect jwt: token issued in the future")
+	}
+	return claims, nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("connect jwt: malformed token: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("connect jwt: malformed token: %w", err)
+	}
+	return nil
+}
+
+// ConnectQSH returns the Atlassian Connect query string hash of a request to u,
+// the hex encoded SHA-256 of ConnectCanonicalRequest.
+func ConnectQSH(method string, u *url.URL, basePath string) string {
+	sum := sha256.Sum256([]byte(ConnectCanonicalRequest(method, u, basePath)))
+	return hex.EncodeToString(sum[:])
+}
+
+// ConnectCanonicalRequest returns the canonical form of a request used for the
+// query string hash: the upper case method, the path relative to basePath and
+// the sorted, percent-encoded query without the "jwt" parameter, joined by "&".
+func ConnectCanonicalRequest(method string, u *url.URL, basePath string) string {
+	return strings.ToUpper(method) + "&" + connectCanonicalPath(u, basePath) + "&" + connectCanonicalQuery(u)
+}
+
+func connectCanonicalPath(u *url.URL, basePath string) string {
+	path := u.EscapedPath()
+	basePath = strings.TrimSuffix(basePath, "/")
+	if basePath != "" && strings.HasPrefix(path, basePath) {
+		path = strings.TrimPrefix(path, basePath)
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return strings.ReplaceAll(path, "&", "%26")
+}
+
+func connectCanonicalQuery(u *url.URL) string {
+	query, _ := url.ParseQuery(u.RawQuery)
+	names := make([]string, 0, len(query))
+	for name := range query {
+		if name != "jwt" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	params := make([]string, 0, len(names))
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for i, value := range values {
+			values[i] = connectEscape(value)
+		}
+		params = append(params, connectEscape(name)+"="+strings.Join(values, ","))
+	}
+	return strings.Join(params, "&")
+}
+
+// connectEscape percent-encodes s as specified by RFC 3986, leaving only
+// unreserved characters as they are.
+func connectEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if 'A' <= ch && ch <= 'Z' || 'a' <= ch && ch <= 'z' || '0' <= ch && ch <= '9' ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' {
+			b.WriteByte(ch)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
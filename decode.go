@@ -0,0 +1,24 @@
+package bitbucket
+
+import (
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// rfc3339TimeHookFunc parses the RFC 3339 timestamps returned by the API into
+// time.Time fields, with or without fractional seconds.
+var rfc3339TimeHookFunc = mapstructure.StringToTimeHookFunc(time.RFC3339)
+
+// decodeWithTimeHook decodes input into result, parsing timestamps with
+// rfc3339TimeHookFunc.
+func decodeWithTimeHook(input interface{}, result interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     result,
+		DecodeHook: rfc3339TimeHookFunc,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(input)
+}
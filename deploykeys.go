@@ -48,7 +48,7 @@ func decodeDeployKeys(deployKeysResponse interface{}) (*DeployKeysRes, error) {
 		return nil, errors.New("not a valid format")
 	}
 
-	repoArray := deployKeysResponseMap["values"].([]interface{})
+	repoArray, _ := deployKeysResponseMap["values"].([]interface{})
 	var deployKeys []DeployKey
 	for _, deployKeyEntry := range repoArray {
 		var deployKey DeployKey
@@ -115,7 +115,11 @@ func (dk *DeployKeys) Create(opt *DeployKeyOptions) (*DeployKey, error) {
 
 func (dk *DeployKeys) Get(opt *DeployKeyOptions) (*DeployKey, error) {
 	urlStr := dk.c.requestUrl("/repositories/%s/%s/deploy-keys/%d", opt.Owner, opt.RepoSlug, opt.Id)
-	response, err := dk.c.execute("GET", urlStr, "")
+	urlStr, err := dk.c.withFieldsParam(urlStr, opt.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := dk.c.executeWithContext("GET", urlStr, "", opt.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +134,11 @@ func (dk *DeployKeys) Delete(opt *DeployKeyOptions) (interface{}, error) {
 
 func (dk *DeployKeys) List(opt *DeployKeyOptions) (*DeployKeysRes, error) {
 	urlStr := dk.c.requestUrl("/repositories/%s/%s/deploy-keys", opt.Owner, opt.RepoSlug)
-	response, err := dk.c.execute("GET", urlStr, "")
+	urlStr, err := dk.c.withFieldsParam(urlStr, opt.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := dk.c.executeWithContext("GET", urlStr, "", opt.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,469 @@
+package bitbucket
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DiffLineKind tells whether a diff line is unchanged, added or deleted.
+type DiffLineKind int
+
+const (
+	DiffLineContext DiffLineKind = iota
+	DiffLineAdded
+	DiffLineDeleted
+)
+
+// DiffLine is a line of a hunk.
+type DiffLine struct {
+	Kind DiffLineKind
+	// Content is the line without its leading marker and line break.
+	Content string
+	// OldLine and NewLine are the numbers of the line in the old and new
+	// file. OldLine is zero for added lines and NewLine for deleted ones.
+	OldLine int
+	NewLine int
+	// Position is the position of the line in the diff of its file: the line
+	// below the first hunk header is 1, and every following line, including
+	// the headers of later hunks, increments it.
+	Position int
+	// NoNewline is set when the line ends the file without a line break.
+	NoNewline bool
+}
+
+// DiffHunk is a "@@ -old +new @@" section of a file diff.
+type DiffHunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	// Section is the text after the header, usually the enclosing function.
+	Section string
+	Lines   []*DiffLine
+}
+
+// FileDiff is the diff of one file.
+type FileDiff struct {
+	// OldPath is empty for added files and NewPath for deleted ones.
+	OldPath string
+	NewPath string
+	// OldMode and NewMode are the git modes, e.g. "100644" or "100755".
+	OldMode    string
+	NewMode    string
+	IsNew      bool
+	IsDeleted  bool
+	IsRename   bool
+	IsCopy     bool
+	IsBinary   bool
+	Similarity int
+	Hunks      []*DiffHunk
+}
+
+// Path returns the new path of the file, or the old one if it was deleted.
+func (f *FileDiff) Path() string {
+	if f.IsDeleted {
+		return f.OldPath
+	}
+	return f.NewPath
+}
+
+// IsModeChange reports whether the mode of the file changed, for example when
+// it became executable.
+func (f *FileDiff) IsModeChange() bool {
+	return f.OldMode != "" && f.NewMode != "" && f.OldMode != f.NewMode
+}
+
+// LineAtPosition returns the line at a position of the file diff.
+func (f *FileDiff) LineAtPosition(position int) (*DiffLine, bool) {
+	for _, hunk := range f.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Position == position {
+				return line, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// NewLine returns the diff line of a line of the new file, if it is part of
+// the diff.
+func (f *FileDiff) NewLine(number int) (*DiffLine, bool) {
+	return f.findLine(func(line *DiffLine) bool { return line.NewLine == number })
+}
+
+// OldLine returns the diff line of a line of the old file, if it is part of
+// the diff.
+func (f *FileDiff) OldLine(number int) (*DiffLine, bool) {
+	return f.findLine(func(line *DiffLine) bool { return line.OldLine == number })
+}
+
+func (f *FileDiff) findLine(match func(*DiffLine) bool) (*DiffLine, bool) {
+	if f == nil || f.IsBinary {
+		return nil, false
+	}
+	for _, hunk := range f.Hunks {
+		for _, line := range hunk.Lines {
+			if match(line) {
+				return line, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// InlineAt returns where an inline comment on the line at a position of the
+// file diff is placed: on the new line for added and unchanged lines, and on
+// the old line for deleted ones.
+func (f *FileDiff) InlineAt(position int) (*CommentInline, error) {
+	line, ok := f.LineAtPosition(position)
+	if !ok {
+		return nil, fmt.Errorf("position %d is not in the diff of %s", position, f.Path())
+	}
+	return f.Inline(line), nil
+}
+
+// Inline returns where an inline comment on a line of the file diff is placed.
+func (f *FileDiff) Inline(line *DiffLine) *CommentInline {
+	inline := &CommentInline{Path: f.Path()}
+	if line.Kind == DiffLineDeleted {
+		from := line.OldLine
+		inline.From = &from
+	} else {
+		to := line.NewLine
+		inline.To = &to
+	}
+	return inline
+}
+
+// ParseDiffResponse parses and closes the body returned by Diff.GetDiff,
+// Diff.GetPatch, PullRequests.Diff or PullRequests.Patch. It takes their
+// results as is, so calls can be wrapped:
+//
+//	files, err := bitbucket.ParseDiffResponse(c.Repositories.PullRequests.Diff(po))
+func ParseDiffResponse(response interface{}, err error) ([]*FileDiff, error) {
+	if err != nil {
+		return nil, err
+	}
+	r, ok := response.(io.Reader)
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+	if closer, ok := r.(io.Closer); ok {
+		defer closer.Close()
+	}
+	return ParseDiff(r)
+}
+
+// ParseDiff parses a git unified diff into its files. Text outside of the
+// files, like the mail headers of a patch, is ignored.
+func ParseDiff(r io.Reader) ([]*FileDiff, error) {
+	p := &diffParser{}
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			p.lineNumber++
+			if perr := p.parseLine(strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")); perr != nil {
+				return nil, perr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if p.hunk != nil && (p.oldRemaining > 0 || p.newRemaining > 0) {
+		return nil, fmt.Errorf("diff ends in the middle of a hunk of %s", p.file.Path())
+	}
+	return p.files, nil
+}
+
+type diffParser struct {
+	files      []*FileDiff
+	file       *FileDiff
+	hunk       *DiffHunk
+	lineNumber int
+	// oldRemaining and newRemaining count the lines the hunk still has.
+	oldRemaining int
+	newRemaining int
+	oldLine      int
+	newLine      int
+	position     int
+}
+
+func (p *diffParser) parseLine(line string) error {
+	if p.hunk != nil && (p.oldRemaining > 0 || p.newRemaining > 0) {
+		return p.parseHunkLine(line)
+	}
+	if p.hunk != nil && strings.HasPrefix(line, `\`) {
+		// "\ No newline at end of file" after the last line of a hunk.
+		if n := len(p.hunk.Lines); n > 0 {
+			p.hunk.Lines[n-1].NoNewline = true
+		}
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(line, "diff --git "):
+		p.file = &FileDiff{}
+		p.hunk = nil
+		p.position = 0
+		p.files = append(p.files, p.file)
+		p.file.OldPath, p.file.NewPath = parseDiffGitPaths(strings.TrimPrefix(line, "diff --git "))
+		return nil
+	case p.file == nil:
+		return nil
+	case strings.HasPrefix(line, "@@ "):
+		return p.parseHunkHeader(line)
+	case p.hunk != nil:
+		// Text after the last hunk of a file, like the signature of a patch.
+		return nil
+	}
+	p.parseExtendedHeader(line)
+	return nil
+}
+
+func (p *diffParser) parseExtendedHeader(line string) {
+	f := p.file
+	switch {
+	case strings.HasPrefix(line, "--- "):
+		if path := parseDiffPath(strings.TrimPrefix(line, "--- "), "a/"); path != "" || f.IsNew {
+			f.OldPath = path
+		}
+	case strings.HasPrefix(line, "+++ "):
+		if path := parseDiffPath(strings.TrimPrefix(line, "+++ "), "b/"); path != "" || f.IsDeleted {
+			f.NewPath = path
+		}
+	case strings.HasPrefix(line, "new file mode "):
+		f.IsNew = true
+		f.OldPath = ""
+		f.NewMode = strings.TrimPrefix(line, "new file mode ")
+	case strings.HasPrefix(line, "deleted file mode "):
+		f.IsDeleted = true
+		f.NewPath = ""
+		f.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+	case strings.HasPrefix(line, "old mode "):
+		f.OldMode = strings.TrimPrefix(line, "old mode ")
+	case strings.HasPrefix(line, "new mode "):
+		f.NewMode = strings.TrimPrefix(line, "new mode ")
+	case strings.HasPrefix(line, "index "):
+		// "index abc..def 100644" carries the mode of a file whose mode did
+		// not change.
+		if fields := strings.Fields(line); len(fields) == 3 && f.OldMode == "" && f.NewMode == "" {
+			f.OldMode, f.NewMode = fields[2], fields[2]
+		}
+	case strings.HasPrefix(line, "similarity index "):
+		f.Similarity, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%"))
+	case strings.HasPrefix(line, "rename from "):
+		f.IsRename = true
+		f.OldPath = unquoteDiffPath(strings.TrimPrefix(line, "rename from "))
+	case strings.HasPrefix(line, "rename to "):
+		f.IsRename = true
+		f.NewPath = unquoteDiffPath(strings.TrimPrefix(line, "rename to "))
+	case strings.HasPrefix(line, "copy from "):
+		f.IsCopy = true
+		f.OldPath = unquoteDiffPath(strings.TrimPrefix(line, "copy from "))
+	case strings.HasPrefix(line, "copy to "):
+		f.IsCopy = true
+		f.NewPath = unquoteDiffPath(strings.TrimPrefix(line, "copy to "))
+	case strings.HasPrefix(line, "Binary files "), line == "GIT binary patch":
+		f.IsBinary = true
+	}
+}
+
+func (p *diffParser) parseHunkHeader(line string) error {
+	end := strings.Index(line[3:], " @@")
+	if end < 0 {
+		return fmt.Errorf("line %d: invalid hunk header %q", p.lineNumber, line)
+	}
+	ranges := strings.Fields(line[3 : 3+end])
+	if len(ranges) != 2 || !strings.HasPrefix(ranges[0], "-") || !strings.HasPrefix(ranges[1], "+") {
+		return fmt.Errorf("line %d: invalid hunk header %q", p.lineNumber, line)
+	}
+	hunk := &DiffHunk{Section: strings.TrimPrefix(line[3+end+3:], " ")}
+	var err1, err2 error
+	hunk.OldStart, hunk.OldLines, err1 = parseHunkRange(ranges[0][1:])
+	hunk.NewStart, hunk.NewLines, err2 = parseHunkRange(ranges[1][1:])
+	if err := errors.Join(err1, err2); err != nil {
+		return fmt.Errorf("line %d: invalid hunk header %q: %w", p.lineNumber, line, err)
+	}
+
+	// The header of the first hunk has no position, later ones do.
+	if p.hunk != nil {
+		p.position++
+	}
+	p.file.Hunks = append(p.file.Hunks, hunk)
+	p.hunk = hunk
+	p.oldRemaining, p.newRemaining = hunk.OldLines, hunk.NewLines
+	p.oldLine, p.newLine = hunk.OldStart, hunk.NewStart
+	return nil
+}
+
+func (p *diffParser) parseHunkLine(line string) error {
+	if strings.HasPrefix(line, `\`) {
+		if n := len(p.hunk.Lines); n > 0 {
+			p.hunk.Lines[n-1].NoNewline = true
+		}
+		return nil
+	}
+
+	p.position++
+	diffLine := &DiffLine{Position: p.position}
+	marker, content := byte(' '), ""
+	if line != "" {
+		marker, content = line[0], line[1:]
+	}
+	diffLine.Content = content
+	switch marker {
+	case ' ':
+		diffLine.Kind = DiffLineContext
+		diffLine.OldLine, diffLine.NewLine = p.oldLine, p.newLine
+		p.oldLine++
+		p.newLine++
+		p.oldRemaining--
+		p.newRemaining--
+	case '+':
+		diffLine.Kind = DiffLineAdded
+		diffLine.NewLine = p.newLine
+		p.newLine++
+		p.newRemaining--
+	case '-':
+		diffLine.Kind = DiffLineDeleted
+		diffLine.OldLine = p.oldLine
+		p.oldLine++
+		p.oldRemaining--
+	default:
+		return fmt.Errorf("line %d: invalid hunk line %q", p.lineNumber, line)
+	}
+	if p.oldRemaining < 0 || p.newRemaining < 0 {
+		return fmt.Errorf("line %d: hunk longer than its header", p.lineNumber)
+	}
+	p.hunk.Lines = append(p.hunk.Lines, diffLine)
+	return nil
+}
+
+// parseHunkRange parses "start,count", where the count defaults to 1.
+func parseHunkRange(s string) (int, int, error) {
+	startStr, countStr, found := strings.Cut(s, ",")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !found {
+		return start, 1, nil
+	}
+	count, err := strconv.Atoi(countStr)
+	return start, count, err
+}
+
+// parseDiffGitPaths parses the "a/old b/new" paths of a "diff --git" line.
+// Unquoted paths containing spaces are ambiguous, in which case both are
+// assumed to be the same, and later headers usually tell them anyway.
+func parseDiffGitPaths(s string) (string, string) {
+	if strings.HasPrefix(s, `"`) {
+		if oldPath, rest, ok := cutQuoted(s); ok {
+			return strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(unquoteDiffPath(strings.TrimSpace(rest)), "b/")
+		}
+	}
+	if i := strings.Index(s, ` "b/`); i >= 0 {
+		return strings.TrimPrefix(s[:i], "a/"), strings.TrimPrefix(unquoteDiffPath(s[i+1:]), "b/")
+	}
+	if len(s)%2 == 1 {
+		half := len(s) / 2
+		oldPath, newPath := s[:half], s[half+1:]
+		if strings.HasPrefix(oldPath, "a/") && strings.HasPrefix(newPath, "b/") && oldPath[2:] == newPath[2:] {
+			return oldPath[2:], newPath[2:]
+		}
+	}
+	oldPath, newPath, _ := strings.Cut(s, " b/")
+	return strings.TrimPrefix(oldPath, "a/"), newPath
+}
+
+// parseDiffPath parses the path of a "---" or "+++" line, which is empty for
+// /dev/null.
+func parseDiffPath(s, prefix string) string {
+	// A tab separates an optional timestamp from the path.
+	if i := strings.IndexByte(s, '\t'); i >= 0 && !strings.HasPrefix(s, `"`) {
+		s = s[:i]
+	}
+	s = unquoteDiffPath(s)
+	if s == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(s, prefix)
+}
+
+// unquoteDiffPath unquotes a path git quoted because of special characters.
+func unquoteDiffPath(s string) string {
+	if !strings.HasPrefix(s, `"`) {
+		return s
+	}
+	if unquoted, _, ok := cutQuoted(s); ok {
+		return unquoted
+	}
+	return s
+}
+
+// cutQuoted unquotes the C-style quoted string s starts with and returns the
+// rest of s.
+func cutQuoted(s string) (string, string, bool) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			unquoted, err := unquoteOctal(s[:i+1])
+			if err != nil {
+				return "", "", false
+			}
+			return unquoted, s[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// unquoteOctal unquotes a git quoted path, whose non-ASCII bytes are octal
+// escapes Go does not combine into UTF-8 on its own.
+func unquoteOctal(s string) (string, error) {
+	var b strings.Builder
+	s = s[1 : len(s)-1]
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch c := s[i]; {
+		case c >= '0' && c <= '7' && i+2 < len(s):
+			n, err := strconv.ParseUint(s[i:i+3], 8, 8)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte(byte(n))
+			i += 2
+		case c == 'n':
+			b.WriteByte('\n')
+		case c == 't':
+			b.WriteByte('\t')
+		case c == 'a':
+			b.WriteByte('\a')
+		case c == 'b':
+			b.WriteByte('\b')
+		case c == 'f':
+			b.WriteByte('\f')
+		case c == 'r':
+			b.WriteByte('\r')
+		case c == 'v':
+			b.WriteByte('\v')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
@@ -23,5 +23,9 @@ func (dl *Downloads) Create(do *DownloadsOptions) (interface{}, error) {
 
 func (dl *Downloads) List(do *DownloadsOptions) (interface{}, error) {
 	urlStr := dl.c.requestUrl("/repositories/%s/%s/downloads", do.Owner, do.RepoSlug)
-	return dl.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := dl.c.withFieldsParam(urlStr, do.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return dl.c.executePaginatedWithContext("GET", urlStr, "", nil, do.ctx)
 }
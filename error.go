@@ -3,6 +3,8 @@ package bitbucket
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 )
@@ -38,3 +40,10 @@ func (e *UnexpectedResponseStatusError) Error() string {
 func (e *UnexpectedResponseStatusError) ErrorWithBody() error {
 	return fmt.Errorf("unexpected status %s, body: %s", e.Status, string(e.Body))
 }
+
+// isNotFound reports whether err is an UnexpectedResponseStatusError for a
+// 404.
+func isNotFound(err error) bool {
+	var unexpected *UnexpectedResponseStatusError
+	return errors.As(err, &unexpected) && strings.HasPrefix(unexpected.Status, fmt.Sprint(http.StatusNotFound))
+}
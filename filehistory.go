@@ -0,0 +1,148 @@
+package bitbucket
+
+import (
+	"errors"
+	"iter"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// lastCommitsConcurrency is the number of history requests LastCommits sends
+// at once.
+const lastCommitsConcurrency = 4
+
+// fileHistoryCommitFields adds the commit details the API leaves out of
+// history entries by default.
+const fileHistoryCommitFields = "+values.commit.message,+values.commit.date,+values.commit.author.raw,+values.commit.author.user.display_name"
+
+// FileHistory returns an iterator over the versions of a file or directory,
+// newest first, one for each commit that changed it up to
+// FileHistoryOptions.Commit. The Commit of each RepositoryFile holds the hash,
+// message, date and author of the commit.
+//
+// Renames are followed, so the history continues with the previous path of a
+// renamed file, unless FileHistoryOptions.NoRenames is set.
+//
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-source/#api-repositories-workspace-repo-slug-filehistory-commit-path-get
+func (r *Repository) FileHistory(fho *FileHistoryOptions) iter.Seq2[RepositoryFile, error] {
+	urlStr := r.c.requestUrl("/repositories/%s/%s/filehistory/%s/%s",
+		fho.Owner, fho.RepoSlug, url.PathEscape(fho.Commit), escapeTreePath(fho.Path))
+
+	query := url.Values{}
+	query.Set("fields", fileHistoryCommitFields)
+	if fho.NoRenames {
+		query.Set("renames", "false")
+	}
+	urlStr += "?" + query.Encode()
+
+	return iteratePages(r.c, fho.ctx, urlStr, decodeFileHistoryEntry)
+}
+
+// LastCommit is the last commit that changed an entry of a directory.
+type LastCommit struct {
+	File    RepositoryFile
+	Hash    string
+	Message string
+	Author  string
+	Date    *time.Time
+}
+
+// LastCommits lists the directory at RepositoryFilesOptions.Path and returns
+// the last commit that changed each of its entries, in listing order, like the
+// "last changed" column of a code browser. The history of the entries is
+// requested concurrently, one request per entry.
+func (r *Repository) LastCommits(ro *RepositoryFilesOptions) ([]LastCommit, error) {
+	files, err := r.ListFiles(ro)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]LastCommit, len(files))
+	errs := make([]error, len(files))
+	sem := make(chan struct{}, lastCommitsConcurrency)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		// Listing entries carry the commit the ref resolved to, so every
+		// history starts from the same commit even if a branch moves.
+		commit, _ := file.Commit["hash"].(string)
+		if commit == "" {
+			commit = ro.Ref
+		}
+
+		wg.Add(1)
+		go func(i int, file RepositoryFile, commit string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = r.lastCommit(ro, file, commit)
+		}(i, file, commit)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *Repository) lastCommit(ro *RepositoryFilesOptions, file RepositoryFile, commit string) (LastCommit, error) {
+	urlStr := r.c.requestUrl("/repositories/%s/%s/filehistory/%s/%s?pagelen=1&fields=%s",
+		ro.Owner, ro.RepoSlug, url.PathEscape(commit), escapeTreePath(file.Path), url.QueryEscape(fileHistoryCommitFields))
+	response, err := r.c.executeWithContext("GET", urlStr, "", ro.ctx)
+	if err != nil {
+		return LastCommit{}, err
+	}
+	responseMap, ok := response.(map[string]interface{})
+	if !ok {
+		return LastCommit{}, errors.New("not a valid format")
+	}
+	if responseMap["type"] == "error" {
+		return LastCommit{}, DecodeError(responseMap)
+	}
+	values, _ := responseMap["values"].([]interface{})
+	if len(values) == 0 {
+		return LastCommit{}, errors.New("no history for " + file.Path)
+	}
+
+	entry, err := decodeFileHistoryEntry(values[0])
+	if err != nil {
+		return LastCommit{}, err
+	}
+	var details fileHistoryCommit
+	if err := decodeWithTimeHook(entry.Commit, &details); err != nil {
+		return LastCommit{}, err
+	}
+
+	author := details.Author.User.DisplayName
+	if author == "" {
+		author = details.Author.Raw
+	}
+	return LastCommit{
+		File:    file,
+		Hash:    details.Hash,
+		Message: details.Message,
+		Author:  author,
+		Date:    details.Date,
+	}, nil
+}
+
+type fileHistoryCommit struct {
+	Hash    string
+	Message string
+	Date    *time.Time
+	Author  struct {
+		Raw  string
+		User struct {
+			DisplayName string `mapstructure:"display_name"`
+		}
+	}
+}
+
+func decodeFileHistoryEntry(value interface{}) (RepositoryFile, error) {
+	var file RepositoryFile
+	err := mapstructure.Decode(value, &file)
+	return file, err
+}
@@ -0,0 +1,316 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+const DEFAULT_FS_CACHE_ENTRIES = 128
+
+// RepositoryFS is a read-only fs.FS of a repository at a commit, backed by
+// ListFiles and GetFileContent. Create one with Repository.FS.
+//
+// Directory listings are kept for the lifetime of the file system, and file
+// contents are kept in a bounded cache. As the file system is pinned to a
+// commit, neither can become stale. Symbolic links are not followed: opening
+// one reads its target.
+type RepositoryFS struct {
+	r        *Repository
+	owner    string
+	repoSlug string
+	commit   string
+	ctx      context.Context
+	cache    ResponseCache
+
+	mu   sync.Mutex
+	dirs map[string][]RepositoryFile
+}
+
+var (
+	_ fs.StatFS     = (*RepositoryFS)(nil)
+	_ fs.ReadDirFS  = (*RepositoryFS)(nil)
+	_ fs.ReadFileFS = (*RepositoryFS)(nil)
+)
+
+// FS returns a file system of the repository at a commit, branch or tag. The
+// ref is resolved to a commit once, so the file system does not change when
+// the branch moves. The context set with WithContext applies to every request
+// of the file system.
+func (r *Repository) FS(fo *RepositoryFSOptions) (*RepositoryFS, error) {
+	root, err := r.srcMeta(fo.ctx, fo.Owner, fo.RepoSlug, fo.Ref, "")
+	if err != nil {
+		return nil, err
+	}
+	commit := root.commitHash()
+	if commit == "" {
+		return nil, fmt.Errorf("cannot resolve %q to a commit", fo.Ref)
+	}
+
+	cache := fo.Cache
+	if cache == nil {
+		cache = NewMemoryCache(DEFAULT_FS_CACHE_ENTRIES)
+	}
+	return &RepositoryFS{
+		r:        r,
+		owner:    fo.Owner,
+		repoSlug: fo.RepoSlug,
+		commit:   commit,
+		ctx:      fo.ctx,
+		cache:    cache,
+		dirs:     make(map[string][]RepositoryFile),
+	}, nil
+}
+
+// Commit returns the hash of the commit the file system is pinned to.
+func (fsys *RepositoryFS) Commit() string {
+	return fsys.commit
+}
+
+func (fsys *RepositoryFS) Open(name string) (fs.File, error) {
+	info, err := fsys.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		entries, err := fsys.readDir("open", name)
+		if err != nil {
+			return nil, err
+		}
+		return &repositoryDir{info: info, entries: entries}, nil
+	}
+	content, err := fsys.content("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return &repositoryFile{Reader: bytes.NewReader(content), info: info}, nil
+}
+
+func (fsys *RepositoryFS) Stat(name string) (fs.FileInfo, error) {
+	return fsys.stat("stat", name)
+}
+
+func (fsys *RepositoryFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	info, err := fsys.stat("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return fsys.readDir("readdir", name)
+}
+
+func (fsys *RepositoryFS) ReadFile(name string) ([]byte, error) {
+	info, err := fsys.stat("read", name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+	content, err := fsys.content("read", name)
+	if err != nil {
+		return nil, err
+	}
+	// The caller may modify the returned slice, so the cached one is copied.
+	return bytes.Clone(content), nil
+}
+
+func (fsys *RepositoryFS) stat(op, name string) (*repositoryFileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return &repositoryFileInfo{file: RepositoryFile{Type: "commit_directory"}}, nil
+	}
+
+	// Listing the parent checks that it is a directory, as the API returns the
+	// content of a file instead of a listing.
+	parent := path.Dir(name)
+	parentInfo, err := fsys.stat(op, parent)
+	if err != nil {
+		return nil, err
+	}
+	if !parentInfo.IsDir() {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	files, err := fsys.list(op, parent)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if file.Path == name {
+			return &repositoryFileInfo{file: file}, nil
+		}
+	}
+	return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+}
+
+func (fsys *RepositoryFS) list(op, dir string) ([]RepositoryFile, error) {
+	fsys.mu.Lock()
+	files, ok := fsys.dirs[dir]
+	fsys.mu.Unlock()
+	if ok {
+		return files, nil
+	}
+
+	p := dir
+	if p == "." {
+		p = ""
+	}
+	files, err := fsys.r.ListFiles((&RepositoryFilesOptions{
+		Owner: fsys.owner, RepoSlug: fsys.repoSlug, Ref: fsys.commit, Path: p,
+	}).WithContext(fsys.ctx))
+	if err != nil {
+		return nil, pathError(op, dir, err)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	fsys.mu.Lock()
+	fsys.dirs[dir] = files
+	fsys.mu.Unlock()
+	return files, nil
+}
+
+func (fsys *RepositoryFS) readDir(op, name string) ([]fs.DirEntry, error) {
+	files, err := fsys.list(op, name)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]fs.DirEntry, len(files))
+	for i, file := range files {
+		entries[i] = &repositoryFileInfo{file: file}
+	}
+	return entries, nil
+}
+
+func (fsys *RepositoryFS) content(op, name string) ([]byte, error) {
+	key := fmt.Sprintf("%s/%s@%s:%s", fsys.owner, fsys.repoSlug, fsys.commit, name)
+	if content, ok := fsys.cache.Get(key); ok {
+		return content, nil
+	}
+
+	content, err := fsys.r.GetFileContent((&RepositoryFilesOptions{
+		Owner: fsys.owner, RepoSlug: fsys.repoSlug, Ref: fsys.commit, Path: name,
+	}).WithContext(fsys.ctx))
+	if err != nil {
+		return nil, pathError(op, name, err)
+	}
+	fsys.cache.Set(key, content)
+	return content, nil
+}
+
+// pathError wraps an API error for a path, turning a 404 into fs.ErrNotExist.
+func pathError(op, name string, err error) error {
+	if isNotFound(err) {
+		err = fs.ErrNotExist
+	}
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// repositoryFileInfo describes a RepositoryFile as both an fs.FileInfo and an
+// fs.DirEntry.
+type repositoryFileInfo struct {
+	file RepositoryFile
+}
+
+func (fi *repositoryFileInfo) Name() string {
+	if fi.file.Path == "" {
+		return "."
+	}
+	return path.Base(fi.file.Path)
+}
+
+func (fi *repositoryFileInfo) Size() int64 {
+	return int64(fi.file.Size)
+}
+
+func (fi *repositoryFileInfo) Mode() fs.FileMode {
+	switch {
+	case fi.IsDir():
+		return fs.ModeDir | 0o555
+	case hasAttribute(fi.file.Attributes, "link"):
+		return fs.ModeSymlink | 0o777
+	case hasAttribute(fi.file.Attributes, "executable"):
+		return 0o555
+	}
+	return 0o444
+}
+
+// ModTime returns the zero time, as listings do not include the time files
+// were last changed.
+func (fi *repositoryFileInfo) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (fi *repositoryFileInfo) IsDir() bool {
+	return fi.file.Type == "commit_directory"
+}
+
+// Sys returns the RepositoryFile.
+func (fi *repositoryFileInfo) Sys() any {
+	return fi.file
+}
+
+func (fi *repositoryFileInfo) Type() fs.FileMode {
+	return fi.Mode().Type()
+}
+
+func (fi *repositoryFileInfo) Info() (fs.FileInfo, error) {
+	return fi, nil
+}
+
+type repositoryFile struct {
+	*bytes.Reader
+	info *repositoryFileInfo
+}
+
+func (f *repositoryFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *repositoryFile) Close() error {
+	return nil
+}
+
+type repositoryDir struct {
+	info    *repositoryFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *repositoryDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *repositoryDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errors.New("is a directory")}
+}
+
+func (d *repositoryDir) Close() error {
+	return nil
+}
+
+func (d *repositoryDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	d.offset += n
+	return remaining[:n], nil
+}
@@ -0,0 +1,204 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// Credential types reported by Identity.CredentialType. They are mapped from
+// the X-Credential-Type response header of Bitbucket; when it is absent or not
+// recognised, the type is derived from how the client was configured.
+const (
+	CredentialTypeBasic                = "basic"
+	CredentialTypeBearer               = "bearer"
+	CredentialTypeOAuth                = "oauth2"
+	CredentialTypeConnect              = "connect_jwt"
+	CredentialTypeRepoAccessToken      = "repo_access_token"
+	CredentialTypeProjectAccessToken   = "project_access_token"
+	CredentialTypeWorkspaceAccessToken = "workspace_access_token"
+	CredentialTypeNone                 = "none"
+)
+
+// Identity describes the principal a client is authenticated as.
+type Identity struct {
+	// Type is the type of the principal, e.g. "user", or "app_user" for the
+	// bot account of an access token.
+	Type        string
+	Uuid        string
+	Username    string
+	AccountId   string `mapstructure:"account_id"`
+	DisplayName string `mapstructure:"display_name"`
+
+	// CredentialType is one of the CredentialType constants.
+	CredentialType string `mapstructure:"-"`
+	// Scopes are the OAuth scopes granted to the credential. ScopesKnown is
+	// false when Bitbucket did not report them, e.g. for basic authentication.
+	Scopes      []string `mapstructure:"-"`
+	ScopesKnown bool     `mapstructure:"-"`
+}
+
+// HasScope reports whether scope is granted, directly or implied by a broader
+// scope, e.g. "repository:write" implies "repository".
+func (i *Identity) HasScope(scope string) bool {
+	for _, granted := range i.Scopes {
+		if granted == scope {
+			return true
+		}
+		for _, implied := range impliedScopes[granted] {
+			if implied == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// impliedScopes lists the scopes implied by each scope.
+var impliedScopes = map[string][]string{
+	"account:write":     {"account"},
+	"team:write":        {"team"},
+	"project:admin":     {"project"},
+	"repository:write":  {"repository"},
+	"repository:admin":  {"repository"},
+	"repository:delete": {"repository"},
+	"pullrequest":       {"repository"},
+	"pullrequest:write": {"pullrequest", "repository", "repository:write"},
+	"issue:write":       {"issue"},
+	"snippet:write":     {"snippet"},
+	"pipeline:write":    {"pipeline"},
+	"runner:write":      {"runner"},
+}
+
+// MissingScopesError is returned by RequireScopes when the credential lacks
+// some of the required scopes.
+type MissingScopesError struct {
+	Missing []string
+	Granted []string
+}
+
+func (e *MissingScopesError) Error() string {
+	return fmt.Sprintf("credential is missing required scopes %s (granted: %s)",
+		strings.Join(e.Missing, ", "), strings.Join(e.Granted, ", "))
+}
+
+// WhoAmI returns the principal the client is authenticated as, together with
+// the scopes granted to its credential. An UnexpectedResponseStatusError with
+// status 401 means the credential was rejected altogether.
+func (c *Client) WhoAmI(ctx context.Context) (*Identity, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.GetApiBaseURL()+"/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.authenticateRequest(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRawResponse(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with %s credentials: %w", c.Auth.credentialType(), err)
+	}
+	defer resp.Body.Close()
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if body["type"] == "error" {
+		return nil, DecodeError(body)
+	}
+
+	identity := new(Identity)
+	if err := mapstructure.Decode(body, identity); err != nil {
+		return nil, err
+	}
+
+	identity.CredentialType = credentialTypeHeaders[strings.ToLower(resp.Header.Get("X-Credential-Type"))]
+	if identity.CredentialType == "" {
+		identity.CredentialType = c.Auth.credentialType()
+	}
+	if scopes, ok := resp.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; ok {
+		identity.ScopesKnown = true
+		identity.Scopes = parseScopes(strings.Join(scopes, ","))
+	}
+	return identity, nil
+}
+
+// RequireScopes checks that the client's credential works and grants every
+// scope in scopes, so a job can fail fast instead of partway through. It
+// returns a *MissingScopesError listing the scopes lacking. Credentials whose
+// scopes Bitbucket does not report, such as account passwords, only have to
+// authenticate successfully.
+func (c *Client) RequireScopes(ctx context.Context, scopes ...string) (*Identity, error) {
+	identity, err := c.WhoAmI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !identity.ScopesKnown {
+		return identity, nil
+	}
+
+	var missing []string
+	for _, scope := range scopes {
+		if !identity.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return identity, &MissingScopesError{Missing: missing, Granted: identity.Scopes}
+	}
+	return identity, nil
+}
+
+func parseScopes(header string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(header, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// credentialTypeHeaders maps the values of the X-Credential-Type response
+// header to the CredentialType constants.
+var credentialTypeHeaders = map[string]string{
+	"basic":                   CredentialTypeBasic,
+	"app_password":            CredentialTypeBasic,
+	"apppassword":             CredentialTypeBasic,
+	"api_token":               CredentialTypeBasic,
+	"oauth":                   CredentialTypeOAuth,
+	"oauth2":                  CredentialTypeOAuth,
+	"jwt":                     CredentialTypeConnect,
+	"connect_jwt":             CredentialTypeConnect,
+	"repo_access_token":       CredentialTypeRepoAccessToken,
+	"repository_access_token": CredentialTypeRepoAccessToken,
+	"project_access_token":    CredentialTypeProjectAccessToken,
+	"workspace_access_token":  CredentialTypeWorkspaceAccessToken,
+}
+
+// credentialType returns the kind of credential configured, following the
+// precedence used by authenticateRequest.
+func (a *Auth) credentialType() string {
+	switch {
+	case a.connectSecret != "":
+		return CredentialTypeConnect
+	case a.user != "" && a.password != "":
+		return CredentialTypeBasic
+	case a.tokenSource != nil || a.token.AccessToken != "":
+		return CredentialTypeOAuth
+	case a.bearerToken != "":
+		return CredentialTypeBearer
+	default:
+		return CredentialTypeNone
+	}
+}
@@ -37,12 +37,22 @@ func (p *Issues) Gets(io *IssuesOptions) (interface{}, error) {
 		url.RawQuery = query.Encode()
 	}
 
+	if len(io.Fields) > 0 {
+		query := url.Query()
+		p.c.addFieldsParam(&query, io.Fields, true)
+		url.RawQuery = query.Encode()
+	}
+
 	return p.c.executePaginated("GET", url.String(), "", nil)
 }
 
 func (p *Issues) Get(io *IssuesOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + io.Owner + "/" + io.RepoSlug + "/issues/" + io.ID
-	return p.c.execute("GET", urlStr, "")
+	urlStr, err := p.c.withFieldsParam(urlStr, io.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executeWithContext("GET", urlStr, "", io.ctx)
 }
 
 func (p *Issues) Delete(io *IssuesOptions) (interface{}, error) {
@@ -71,7 +81,7 @@ func (p *Issues) Create(io *IssuesOptions) (interface{}, error) {
 func (p *Issues) GetVote(io *IssuesOptions) (bool, interface{}, error) {
 	// A 404 indicates that the user hasn't voted
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + io.Owner + "/" + io.RepoSlug + "/issues/" + io.ID + "/vote"
-	data, err := p.c.execute("GET", urlStr, "")
+	data, err := p.c.executeWithContext("GET", urlStr, "", io.ctx)
 	if err != nil && strings.HasPrefix(err.Error(), "404") {
 		return false, data, nil
 	}
@@ -93,7 +103,7 @@ func (p *Issues) DeleteVote(io *IssuesOptions) error {
 func (p *Issues) GetWatch(io *IssuesOptions) (bool, interface{}, error) {
 	// A 404 indicates that the user hasn't watchd
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + io.Owner + "/" + io.RepoSlug + "/issues/" + io.ID + "/watch"
-	data, err := p.c.execute("GET", urlStr, "")
+	data, err := p.c.executeWithContext("GET", urlStr, "", io.ctx)
 	if err != nil && strings.HasPrefix(err.Error(), "404") {
 		return false, data, nil
 	}
@@ -186,6 +196,12 @@ func (p *Issues) GetComments(ico *IssueCommentsOptions) (interface{}, error) {
 		query.Set("sort", ico.Sort)
 		url.RawQuery = query.Encode()
 	}
+
+	if len(ico.Fields) > 0 {
+		query := url.Query()
+		p.c.addFieldsParam(&query, ico.Fields, false)
+		url.RawQuery = query.Encode()
+	}
 	return p.c.execute("GET", url.String(), "")
 }
 
@@ -203,7 +219,11 @@ func (p *Issues) CreateComment(ico *IssueCommentsOptions) (interface{}, error) {
 
 func (p *Issues) GetComment(ico *IssueCommentsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + ico.Owner + "/" + ico.RepoSlug + "/issues/" + ico.ID + "/comments/" + ico.CommentID
-	return p.c.execute("GET", urlStr, "")
+	urlStr, err := p.c.withFieldsParam(urlStr, ico.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executeWithContext("GET", urlStr, "", ico.ctx)
 }
 
 func (p *Issues) UpdateComment(ico *IssueCommentsOptions) (interface{}, error) {
@@ -255,6 +275,12 @@ func (p *Issues) GetChanges(ico *IssueChangesOptions) (interface{}, error) {
 		url.RawQuery = query.Encode()
 	}
 
+	if len(ico.Fields) > 0 {
+		query := url.Query()
+		p.c.addFieldsParam(&query, ico.Fields, false)
+		url.RawQuery = query.Encode()
+	}
+
 	return p.c.execute("GET", url.String(), "")
 }
 
@@ -293,5 +319,9 @@ func (p *Issues) CreateChange(ico *IssueChangesOptions) (interface{}, error) {
 
 func (p *Issues) GetChange(ico *IssueChangesOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + ico.Owner + "/" + ico.RepoSlug + "/issues/" + ico.ID + "/changes/" + ico.ChangeID
-	return p.c.execute("GET", urlStr, "")
+	urlStr, err := p.c.withFieldsParam(urlStr, ico.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executeWithContext("GET", urlStr, "", ico.ctx)
 }
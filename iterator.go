@@ -0,0 +1,77 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// iteratePages returns an iterator over the values of the paginated collection
+// at urlStr. Pages are requested lazily as the iteration advances, following
+// the next links returned by the API, so breaking out of the loop stops
+// fetching. The client's page length and page limit apply. A failed request
+// or decode yields the error and ends the iteration.
+func iteratePages[T any](c *Client, ctx context.Context, urlStr string, decode func(interface{}) (T, error)) iter.Seq2[T, error] {
+	return iteratePagesUpTo(c, ctx, urlStr, c.LimitPages, decode)
+}
+
+// iterateAllPages is like iteratePages, but ignores the client's page limit.
+// It is meant for collections that are only correct when complete, like the
+// branches a caller must not touch.
+func iterateAllPages[T any](c *Client, ctx context.Context, urlStr string, decode func(interface{}) (T, error)) iter.Seq2[T, error] {
+	return iteratePagesUpTo(c, ctx, urlStr, 0, decode)
+}
+
+// iteratePagesUpTo iterates over at most limitPages pages, or every page when
+// limitPages is 0.
+func iteratePagesUpTo[T any](c *Client, ctx context.Context, urlStr string, limitPages int, decode func(interface{}) (T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		if c.Pagelen != DEFAULT_PAGE_LENGTH {
+			urlObj, err := url.Parse(urlStr)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			q := urlObj.Query()
+			q.Set("pagelen", strconv.Itoa(c.Pagelen))
+			keepPaginationFields(&q)
+			urlObj.RawQuery = q.Encode()
+			urlStr = urlObj.String()
+		}
+
+		for page := 1; urlStr != ""; page++ {
+			if limitPages != 0 && page > limitPages {
+				return
+			}
+
+			response, err := c.executeWithContext("GET", urlStr, "", ctx)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			responseMap, ok := response.(map[string]interface{})
+			if !ok {
+				yield(zero, errors.New("not a valid format"))
+				return
+			}
+			if responseMap["type"] == "error" {
+				yield(zero, DecodeError(responseMap))
+				return
+			}
+
+			values, _ := responseMap["values"].([]interface{})
+			for _, value := range values {
+				item, err := decode(value)
+				if !yield(item, err) || err != nil {
+					return
+				}
+			}
+
+			urlStr, _ = responseMap["next"].(string)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package bitbucket
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/bitbucket"
+)
+
+// LoopbackOAuthOptions configures NewOAuthWithLoopback.
+type LoopbackOAuthOptions struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// Endpoint defaults to the Bitbucket Cloud authorization server.
+	Endpoint oauth2.Endpoint
+	// ListenAddr is the address the callback server listens on, "127.0.0.1:0"
+	// (a random port) by default. Use a fixed port if the OAuth consumer only
+	// accepts a specific callback URL.
+	ListenAddr string
+	// CallbackPath is the path of the callback URL, "/callback" by default.
+	CallbackPath string
+	// OpenURL is called with the consent URL. By default the URL is printed
+	// to stderr and opened in the user's browser.
+	OpenURL func(authURL string) error
+	// ClientOptions configure the returned Client.
+	ClientOptions []Option
+
+	ctx context.Context
+}
+
+func (lo *LoopbackOAuthOptions) WithContext(ctx context.Context) *LoopbackOAuthOptions {
+	lo.ctx = ctx
+	return lo
+}
+
+type loopbackResult struct {
+	code string
+	err  error
+}
+
+// NewOAuthWithLoopback runs the OAuth authorization code flow with PKCE for
+// command line tools. It starts an HTTP server on a loopback address to
+// receive the callback, sends the user to the consent page, verifies the
+// returned state and exchanges the code for a token. The returned Client
+// refreshes the token as needed.
+//
+// It blocks until a callback carrying the expected state has been received or
+// the options' context is done. Other requests to the callback path, such as
+// browser prefetches or stale redirects, are answered with 400 Bad Request and
+// do not end the flow.
+func NewOAuthWithLoopback(lo *LoopbackOAuthOptions) (*Client, error) {
+	ctx := lo.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	endpoint := lo.Endpoint
+	if endpoint.AuthURL == "" && endpoint.TokenURL == "" {
+		endpoint = bitbucket.Endpoint
+	}
+	listenAddr := lo.ListenAddr
+	if listenAddr == "" {
+		listenAddr = "127.0.0.1:0"
+	}
+	callbackPath := lo.CallbackPath
+	if callbackPath == "" {
+		callbackPath = "/callback"
+	}
+	openURL := lo.OpenURL
+	if openURL == nil {
+		openURL = printAndOpenURL
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback server: %w", err)
+	}
+	conf := &oauth2.Config{
+		ClientID:     lo.ClientID,
+		ClientSecret: lo.ClientSecret,
+		Endpoint:     endpoint,
+		Scopes:       lo.Scopes,
+		RedirectURL:  "http://" + listener.Addr().String() + callbackPath,
+	}
+
+	results := make(chan loopbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		result, ours := checkLoopbackCallback(r, state)
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Authorization complete. You can close this window.")
+		}
+		if !ours {
+			return
+		}
+		select {
+		case results <- result:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	if err := openURL(conf.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))); err != nil {
+		return nil, fmt.Errorf("failed to open authorization url: %w", err)
+	}
+
+	var result loopbackResult
+	select {
+	case result = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	tok, err := conf.Exchange(ctx, result.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	a := &Auth{appID: lo.ClientID, secret: lo.ClientSecret, token: *tok}
+	// The token source outlives ctx, which may be cancelled once we return.
+	a.tokenSource = conf.TokenSource(context.Background(), tok)
+	return New(a, lo.ClientOptions...)
+}
+
+// checkLoopbackCallback reads the outcome of the authorization from a callback
+// request. ours reports whether the request carries the expected state; only
+// such requests, successful or not, end the flow.
+func checkLoopbackCallback(r *http.Request, state string) (result loopbackResult, ours bool) {
+	q := r.URL.Query()
+	if subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(state)) != 1 {
+		return loopbackResult{err: errors.New("authorization failed: state mismatch")}, false
+	}
+	if errCode := q.Get("error"); errCode != "" {
+		if description := q.Get("error_description"); description != "" {
+			return loopbackResult{err: fmt.Errorf("authorization failed: %s: %s", errCode, description)}, true
+		}
+		return loopbackResult{err: fmt.Errorf("authorization failed: %s", errCode)}, true
+	}
+	code := q.Get("code")
+	if code == "" {
+		return loopbackResult{err: errors.New("authorization failed: no code in callback")}, true
+	}
+	return loopbackResult{code: code}, true
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func printAndOpenURL(authURL string) error {
+	fmt.Fprintf(os.Stderr, "Visit the URL for the auth dialog:\n%v\n", authURL)
+	// The URL has been printed, so failing to start a browser is not fatal.
+	_ = OpenBrowser(authURL)
+	return nil
+}
+
+// OpenBrowser opens url in the user's default browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
@@ -15,9 +15,9 @@ func TestMockPullRequests_List_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockPullRequestInst := mockgen.NewMockpullrequests(ctrl)
+	mockPullRequestInst := mockgen.NewMockPullRequestsService(ctrl)
 
-	inPullRequestOpts := go_bitbucket.PullRequestsOptions{
+	inPullRequestOpts := &go_bitbucket.PullRequestsOptions{
 		Owner:    "test-workspace",
 		RepoSlug: "test-repo",
 	}
@@ -50,10 +50,10 @@ func TestMockPullRequests_List_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockPullRequestInst := mockgen.NewMockpullrequests(ctrl)
+	mockPullRequestInst := mockgen.NewMockPullRequestsService(ctrl)
 	expectedMockError := errors.New("Not Found")
 
-	inPullRequestOpts := go_bitbucket.PullRequestsOptions{
+	inPullRequestOpts := &go_bitbucket.PullRequestsOptions{
 		Owner:    "test?::=workspace",
 		RepoSlug: "test-repo",
 	}
@@ -68,3 +68,29 @@ func TestMockPullRequests_List_Error(t *testing.T) {
 	assert.NotNil(t, actualErr)
 	assert.Nil(t, actualPullRequestList, "The returned list of pull requests should be nil, but got: %v", actualPullRequestList)
 }
+
+func TestMockPullRequests_Approve_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var service go_bitbucket.PullRequestsService = mockgen.NewMockPullRequestsService(ctrl)
+	mockPullRequestInst := service.(*mockgen.MockPullRequestsService)
+
+	inPullRequestOpts := &go_bitbucket.PullRequestsOptions{
+		ID:       "1",
+		Owner:    "test-workspace",
+		RepoSlug: "test-repo",
+	}
+	expectedParticipant := map[string]interface{}{"approved": true}
+
+	mockPullRequestInst.EXPECT().
+		Approve(inPullRequestOpts).
+		Times(1).
+		Return(expectedParticipant, nil)
+
+	actualParticipant, actualErr := service.Approve(inPullRequestOpts)
+
+	assert.Nil(t, actualErr, "No error should have been returned, but got: %v", actualErr)
+	assert.Equal(t, expectedParticipant, actualParticipant)
+}
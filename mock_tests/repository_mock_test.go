@@ -19,10 +19,10 @@ func TestMockRepositoryPipelineVariables_List_Success(t *testing.T) {
 	defer ctrl.Finish() // Assert that all expected calls were made
 
 	// 2. Create an instance of the mock
-	mockRepositoryInst := mockgen.NewMockrepository(ctrl)
+	mockRepositoryInst := mockgen.NewMockRepositoryService(ctrl)
 
 	// 3. Set up expectations on the mock
-	listPipelineVarsOpts := go_bitbucket.RepositoryPipelineVariablesOptions{
+	listPipelineVarsOpts := &go_bitbucket.RepositoryPipelineVariablesOptions{
 		Owner:    "testworkspace",
 		RepoSlug: "testrepo",
 	}
@@ -68,12 +68,12 @@ func TestMockRepositoryPipelineVariables_List_Error(t *testing.T) {
 	defer ctrl.Finish() // Assert that all expected calls were made
 
 	// 2. Create an instance of the mock
-	mockRepositoryInst := mockgen.NewMockrepository(ctrl)
+	mockRepositoryInst := mockgen.NewMockRepositoryService(ctrl)
 	expectedMockError := errors.New("Not Found")
 
 	// 3. Set up expectations on the mock
 
-	listPipelineVarsOpts := go_bitbucket.RepositoryPipelineVariablesOptions{
+	listPipelineVarsOpts := &go_bitbucket.RepositoryPipelineVariablesOptions{
 		Owner:    "testworkspace-not-found",
 		RepoSlug: "testrepo-not-found",
 	}
@@ -95,9 +95,9 @@ func TestMockRepositoryPipelineVariable_Get_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepositoryInst := mockgen.NewMockrepository(ctrl)
+	mockRepositoryInst := mockgen.NewMockRepositoryService(ctrl)
 
-	inGetPipelineVarOpts := go_bitbucket.RepositoryPipelineVariableOptions{
+	inGetPipelineVarOpts := &go_bitbucket.RepositoryPipelineVariableOptions{
 		Owner:    "testworkspace",
 		RepoSlug: "testrepo",
 		Uuid:     "6b98a093-21e3-4e15-ad48-f06aad1d2399",
@@ -126,10 +126,10 @@ func TestMockRepositoryPipelineVariable_Get_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepositoryInst := mockgen.NewMockrepository(ctrl)
+	mockRepositoryInst := mockgen.NewMockRepositoryService(ctrl)
 	expectedMockError := errors.New("Not Found")
 
-	inGetPipelineVarOpts := go_bitbucket.RepositoryPipelineVariableOptions{
+	inGetPipelineVarOpts := &go_bitbucket.RepositoryPipelineVariableOptions{
 		Owner:    "testworkspace",
 		RepoSlug: "testrepo",
 		Uuid:     "6b98a093-21e3-4e15-ad48-f06aad1d2369",
@@ -151,9 +151,9 @@ func TestMockRepositoryPipelineVariable_Update_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepositoryInst := mockgen.NewMockrepository(ctrl)
+	mockRepositoryInst := mockgen.NewMockRepositoryService(ctrl)
 
-	inPipelineVarUpdateOpts := go_bitbucket.RepositoryPipelineVariableOptions{
+	inPipelineVarUpdateOpts := &go_bitbucket.RepositoryPipelineVariableOptions{
 		Owner:    "testworkspace",
 		RepoSlug: "testrepo",
 		Uuid:     "6b98a093-21e3-4e15-ad48-f06aad1d2399",
@@ -182,10 +182,10 @@ func TestMockRepositoryPipelineVariable_Update_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepositoryInst := mockgen.NewMockrepository(ctrl)
+	mockRepositoryInst := mockgen.NewMockRepositoryService(ctrl)
 	expectedMockUpdateError := errors.New("Not Found")
 
-	inPipelineVarUpdateOpts := go_bitbucket.RepositoryPipelineVariableOptions{
+	inPipelineVarUpdateOpts := &go_bitbucket.RepositoryPipelineVariableOptions{
 		Owner:    "testworkspace",
 		RepoSlug: "testrepo",
 		Uuid:     "6b98a093-21e3-4e15-ad48-f06aad1d2399",
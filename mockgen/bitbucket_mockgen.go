@@ -10,38 +10,41 @@
 package mockgen
 
 import (
+	context "context"
+	io "io"
+	iter "iter"
 	reflect "reflect"
 
 	bitbucket "github.com/ktrysmt/go-bitbucket"
 	gomock "go.uber.org/mock/gomock"
 )
 
-// Mockusers is a mock of users interface.
-type Mockusers struct {
+// MockUsersService is a mock of UsersService interface.
+type MockUsersService struct {
 	ctrl     *gomock.Controller
-	recorder *MockusersMockRecorder
+	recorder *MockUsersServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockusersMockRecorder is the mock recorder for Mockusers.
-type MockusersMockRecorder struct {
-	mock *Mockusers
+// MockUsersServiceMockRecorder is the mock recorder for MockUsersService.
+type MockUsersServiceMockRecorder struct {
+	mock *MockUsersService
 }
 
-// NewMockusers creates a new mock instance.
-func NewMockusers(ctrl *gomock.Controller) *Mockusers {
-	mock := &Mockusers{ctrl: ctrl}
-	mock.recorder = &MockusersMockRecorder{mock}
+// NewMockUsersService creates a new mock instance.
+func NewMockUsersService(ctrl *gomock.Controller) *MockUsersService {
+	mock := &MockUsersService{ctrl: ctrl}
+	mock.recorder = &MockUsersServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockusers) EXPECT() *MockusersMockRecorder {
+func (m *MockUsersService) EXPECT() *MockUsersServiceMockRecorder {
 	return m.recorder
 }
 
 // Followers mocks base method.
-func (m *Mockusers) Followers(username string) (any, error) {
+func (m *MockUsersService) Followers(username string) (any, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Followers", username)
 	ret0, _ := ret[0].(any)
@@ -50,28 +53,28 @@ func (m *Mockusers) Followers(username string) (any, error) {
 }
 
 // Followers indicates an expected call of Followers.
-func (mr *MockusersMockRecorder) Followers(username any) *gomock.Call {
+func (mr *MockUsersServiceMockRecorder) Followers(username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Followers", reflect.TypeOf((*Mockusers)(nil).Followers), username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Followers", reflect.TypeOf((*MockUsersService)(nil).Followers), username)
 }
 
 // Following mocks base method.
-func (m *Mockusers) Following(username string) (any, error) {
+func (m *MockUsersService) Following(t string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Following", username)
+	ret := m.ctrl.Call(m, "Following", t)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Following indicates an expected call of Following.
-func (mr *MockusersMockRecorder) Following(username any) *gomock.Call {
+func (mr *MockUsersServiceMockRecorder) Following(t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Following", reflect.TypeOf((*Mockusers)(nil).Following), username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Following", reflect.TypeOf((*MockUsersService)(nil).Following), t)
 }
 
 // Get mocks base method.
-func (m *Mockusers) Get(username string) (*bitbucket.User, error) {
+func (m *MockUsersService) Get(username string) (*bitbucket.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", username)
 	ret0, _ := ret[0].(*bitbucket.User)
@@ -80,52 +83,67 @@ func (m *Mockusers) Get(username string) (*bitbucket.User, error) {
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockusersMockRecorder) Get(username any) *gomock.Call {
+func (mr *MockUsersServiceMockRecorder) Get(username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockusers)(nil).Get), username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUsersService)(nil).Get), username)
+}
+
+// GetWithOptions mocks base method.
+func (m *MockUsersService) GetWithOptions(uo *bitbucket.UserOptions) (*bitbucket.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithOptions", uo)
+	ret0, _ := ret[0].(*bitbucket.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithOptions indicates an expected call of GetWithOptions.
+func (mr *MockUsersServiceMockRecorder) GetWithOptions(uo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithOptions", reflect.TypeOf((*MockUsersService)(nil).GetWithOptions), uo)
 }
 
 // Repositories mocks base method.
-func (m *Mockusers) Repositories(username string) (any, error) {
+func (m *MockUsersService) Repositories(t string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Repositories", username)
+	ret := m.ctrl.Call(m, "Repositories", t)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Repositories indicates an expected call of Repositories.
-func (mr *MockusersMockRecorder) Repositories(username any) *gomock.Call {
+func (mr *MockUsersServiceMockRecorder) Repositories(t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repositories", reflect.TypeOf((*Mockusers)(nil).Repositories), username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repositories", reflect.TypeOf((*MockUsersService)(nil).Repositories), t)
 }
 
-// Mockuser is a mock of user interface.
-type Mockuser struct {
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
 	ctrl     *gomock.Controller
-	recorder *MockuserMockRecorder
+	recorder *MockUserServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockuserMockRecorder is the mock recorder for Mockuser.
-type MockuserMockRecorder struct {
-	mock *Mockuser
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
 }
 
-// NewMockuser creates a new mock instance.
-func NewMockuser(ctrl *gomock.Controller) *Mockuser {
-	mock := &Mockuser{ctrl: ctrl}
-	mock.recorder = &MockuserMockRecorder{mock}
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
 // Emails mocks base method.
-func (m *Mockuser) Emails() (any, error) {
+func (m *MockUserService) Emails() (any, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Emails")
 	ret0, _ := ret[0].(any)
@@ -134,13 +152,13 @@ func (m *Mockuser) Emails() (any, error) {
 }
 
 // Emails indicates an expected call of Emails.
-func (mr *MockuserMockRecorder) Emails() *gomock.Call {
+func (mr *MockUserServiceMockRecorder) Emails() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Emails", reflect.TypeOf((*Mockuser)(nil).Emails))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Emails", reflect.TypeOf((*MockUserService)(nil).Emails))
 }
 
 // Profile mocks base method.
-func (m *Mockuser) Profile() (*bitbucket.User, error) {
+func (m *MockUserService) Profile() (*bitbucket.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Profile")
 	ret0, _ := ret[0].(*bitbucket.User)
@@ -149,241 +167,226 @@ func (m *Mockuser) Profile() (*bitbucket.User, error) {
 }
 
 // Profile indicates an expected call of Profile.
-func (mr *MockuserMockRecorder) Profile() *gomock.Call {
+func (mr *MockUserServiceMockRecorder) Profile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockUserService)(nil).Profile))
+}
+
+// ProfileWithOptions mocks base method.
+func (m *MockUserService) ProfileWithOptions(uo *bitbucket.UserOptions) (*bitbucket.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProfileWithOptions", uo)
+	ret0, _ := ret[0].(*bitbucket.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProfileWithOptions indicates an expected call of ProfileWithOptions.
+func (mr *MockUserServiceMockRecorder) ProfileWithOptions(uo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*Mockuser)(nil).Profile))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProfileWithOptions", reflect.TypeOf((*MockUserService)(nil).ProfileWithOptions), uo)
 }
 
-// Mockpullrequests is a mock of pullrequests interface.
-type Mockpullrequests struct {
+// MockTeamsService is a mock of TeamsService interface.
+type MockTeamsService struct {
 	ctrl     *gomock.Controller
-	recorder *MockpullrequestsMockRecorder
+	recorder *MockTeamsServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockpullrequestsMockRecorder is the mock recorder for Mockpullrequests.
-type MockpullrequestsMockRecorder struct {
-	mock *Mockpullrequests
+// MockTeamsServiceMockRecorder is the mock recorder for MockTeamsService.
+type MockTeamsServiceMockRecorder struct {
+	mock *MockTeamsService
 }
 
-// NewMockpullrequests creates a new mock instance.
-func NewMockpullrequests(ctrl *gomock.Controller) *Mockpullrequests {
-	mock := &Mockpullrequests{ctrl: ctrl}
-	mock.recorder = &MockpullrequestsMockRecorder{mock}
+// NewMockTeamsService creates a new mock instance.
+func NewMockTeamsService(ctrl *gomock.Controller) *MockTeamsService {
+	mock := &MockTeamsService{ctrl: ctrl}
+	mock.recorder = &MockTeamsServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockpullrequests) EXPECT() *MockpullrequestsMockRecorder {
+func (m *MockTeamsService) EXPECT() *MockTeamsServiceMockRecorder {
 	return m.recorder
 }
 
-// Activities mocks base method.
-func (m *Mockpullrequests) Activities(opt bitbucket.PullRequestsOptions) (any, error) {
+// Followers mocks base method.
+func (m *MockTeamsService) Followers(teamname string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Activities", opt)
+	ret := m.ctrl.Call(m, "Followers", teamname)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Activities indicates an expected call of Activities.
-func (mr *MockpullrequestsMockRecorder) Activities(opt any) *gomock.Call {
+// Followers indicates an expected call of Followers.
+func (mr *MockTeamsServiceMockRecorder) Followers(teamname any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activities", reflect.TypeOf((*Mockpullrequests)(nil).Activities), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Followers", reflect.TypeOf((*MockTeamsService)(nil).Followers), teamname)
 }
 
-// Activity mocks base method.
-func (m *Mockpullrequests) Activity(opt bitbucket.PullRequestsOptions) (any, error) {
+// Following mocks base method.
+func (m *MockTeamsService) Following(teamname string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Activity", opt)
+	ret := m.ctrl.Call(m, "Following", teamname)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Activity indicates an expected call of Activity.
-func (mr *MockpullrequestsMockRecorder) Activity(opt any) *gomock.Call {
+// Following indicates an expected call of Following.
+func (mr *MockTeamsServiceMockRecorder) Following(teamname any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activity", reflect.TypeOf((*Mockpullrequests)(nil).Activity), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Following", reflect.TypeOf((*MockTeamsService)(nil).Following), teamname)
 }
 
-// Commits mocks base method.
-func (m *Mockpullrequests) Commits(opt bitbucket.PullRequestsOptions) (any, error) {
+// List mocks base method.
+func (m *MockTeamsService) List(role string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Commits", opt)
+	ret := m.ctrl.Call(m, "List", role)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Commits indicates an expected call of Commits.
-func (mr *MockpullrequestsMockRecorder) Commits(opt any) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockTeamsServiceMockRecorder) List(role any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commits", reflect.TypeOf((*Mockpullrequests)(nil).Commits), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTeamsService)(nil).List), role)
 }
 
-// Create mocks base method.
-func (m *Mockpullrequests) Create(opt bitbucket.PullRequestsOptions) (any, error) {
+// Members mocks base method.
+func (m *MockTeamsService) Members(teamname string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", opt)
+	ret := m.ctrl.Call(m, "Members", teamname)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockpullrequestsMockRecorder) Create(opt any) *gomock.Call {
+// Members indicates an expected call of Members.
+func (mr *MockTeamsServiceMockRecorder) Members(teamname any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockpullrequests)(nil).Create), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockTeamsService)(nil).Members), teamname)
 }
 
-// Decline mocks base method.
-func (m *Mockpullrequests) Decline(opt bitbucket.PullRequestsOptions) (any, error) {
+// Profile mocks base method.
+func (m *MockTeamsService) Profile(teamname string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Decline", opt)
+	ret := m.ctrl.Call(m, "Profile", teamname)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Decline indicates an expected call of Decline.
-func (mr *MockpullrequestsMockRecorder) Decline(opt any) *gomock.Call {
+// Profile indicates an expected call of Profile.
+func (mr *MockTeamsServiceMockRecorder) Profile(teamname any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*Mockpullrequests)(nil).Decline), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*MockTeamsService)(nil).Profile), teamname)
 }
 
-// Diff mocks base method.
-func (m *Mockpullrequests) Diff(opt bitbucket.PullRequestsOptions) (any, error) {
+// Projects mocks base method.
+func (m *MockTeamsService) Projects(teamname string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Diff", opt)
+	ret := m.ctrl.Call(m, "Projects", teamname)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Diff indicates an expected call of Diff.
-func (mr *MockpullrequestsMockRecorder) Diff(opt any) *gomock.Call {
+// Projects indicates an expected call of Projects.
+func (mr *MockTeamsServiceMockRecorder) Projects(teamname any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*Mockpullrequests)(nil).Diff), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Projects", reflect.TypeOf((*MockTeamsService)(nil).Projects), teamname)
 }
 
-// Get mocks base method.
-func (m *Mockpullrequests) Get(opt bitbucket.PullRequestsOptions) (any, error) {
+// Repositories mocks base method.
+func (m *MockTeamsService) Repositories(teamname string) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", opt)
+	ret := m.ctrl.Call(m, "Repositories", teamname)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockpullrequestsMockRecorder) Get(opt any) *gomock.Call {
+// Repositories indicates an expected call of Repositories.
+func (mr *MockTeamsServiceMockRecorder) Repositories(teamname any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockpullrequests)(nil).Get), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repositories", reflect.TypeOf((*MockTeamsService)(nil).Repositories), teamname)
 }
 
-// List mocks base method.
-func (m *Mockpullrequests) List(opt bitbucket.PullRequestsOptions) (any, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", opt)
-	ret0, _ := ret[0].(any)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockWorkspaceService is a mock of WorkspaceService interface.
+type MockWorkspaceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceServiceMockRecorder
+	isgomock struct{}
 }
 
-// List indicates an expected call of List.
-func (mr *MockpullrequestsMockRecorder) List(opt any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*Mockpullrequests)(nil).List), opt)
+// MockWorkspaceServiceMockRecorder is the mock recorder for MockWorkspaceService.
+type MockWorkspaceServiceMockRecorder struct {
+	mock *MockWorkspaceService
 }
 
-// Merge mocks base method.
-func (m *Mockpullrequests) Merge(opt bitbucket.PullRequestsOptions) (any, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Merge", opt)
-	ret0, _ := ret[0].(any)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// NewMockWorkspaceService creates a new mock instance.
+func NewMockWorkspaceService(ctrl *gomock.Controller) *MockWorkspaceService {
+	mock := &MockWorkspaceService{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceServiceMockRecorder{mock}
+	return mock
 }
 
-// Merge indicates an expected call of Merge.
-func (mr *MockpullrequestsMockRecorder) Merge(opt any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*Mockpullrequests)(nil).Merge), opt)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceService) EXPECT() *MockWorkspaceServiceMockRecorder {
+	return m.recorder
 }
 
-// Patch mocks base method.
-func (m *Mockpullrequests) Patch(opt bitbucket.PullRequestsOptions) (any, error) {
+// CreateProject mocks base method.
+func (m *MockWorkspaceService) CreateProject(opt *bitbucket.ProjectOptions) (*bitbucket.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Patch", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "CreateProject", opt)
+	ret0, _ := ret[0].(*bitbucket.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Patch indicates an expected call of Patch.
-func (mr *MockpullrequestsMockRecorder) Patch(opt any) *gomock.Call {
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockWorkspaceServiceMockRecorder) CreateProject(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*Mockpullrequests)(nil).Patch), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockWorkspaceService)(nil).CreateProject), opt)
 }
 
-// Update mocks base method.
-func (m *Mockpullrequests) Update(opt bitbucket.PullRequestsOptions) (any, error) {
+// DeleteProject mocks base method.
+func (m *MockWorkspaceService) DeleteProject(opt *bitbucket.ProjectOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", opt)
+	ret := m.ctrl.Call(m, "DeleteProject", opt)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockpullrequestsMockRecorder) Update(opt any) *gomock.Call {
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockWorkspaceServiceMockRecorder) DeleteProject(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*Mockpullrequests)(nil).Update), opt)
-}
-
-// Mockworkspace is a mock of workspace interface.
-type Mockworkspace struct {
-	ctrl     *gomock.Controller
-	recorder *MockworkspaceMockRecorder
-	isgomock struct{}
-}
-
-// MockworkspaceMockRecorder is the mock recorder for Mockworkspace.
-type MockworkspaceMockRecorder struct {
-	mock *Mockworkspace
-}
-
-// NewMockworkspace creates a new mock instance.
-func NewMockworkspace(ctrl *gomock.Controller) *Mockworkspace {
-	mock := &Mockworkspace{ctrl: ctrl}
-	mock.recorder = &MockworkspaceMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockworkspace) EXPECT() *MockworkspaceMockRecorder {
-	return m.recorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockWorkspaceService)(nil).DeleteProject), opt)
 }
 
-// CreateProject mocks base method.
-func (m *Mockworkspace) CreateProject(opt bitbucket.ProjectOptions) (*bitbucket.Project, error) {
+// Get mocks base method.
+func (m *MockWorkspaceService) Get(workspace string) (*bitbucket.Workspace, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProject", opt)
-	ret0, _ := ret[0].(*bitbucket.Project)
+	ret := m.ctrl.Call(m, "Get", workspace)
+	ret0, _ := ret[0].(*bitbucket.Workspace)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProject indicates an expected call of CreateProject.
-func (mr *MockworkspaceMockRecorder) CreateProject(opt any) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockWorkspaceServiceMockRecorder) Get(workspace any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*Mockworkspace)(nil).CreateProject), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWorkspaceService)(nil).Get), workspace)
 }
 
 // GetProject mocks base method.
-func (m *Mockworkspace) GetProject(opt bitbucket.ProjectOptions) (*bitbucket.Project, error) {
+func (m *MockWorkspaceService) GetProject(opt *bitbucket.ProjectOptions) (*bitbucket.Project, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetProject", opt)
 	ret0, _ := ret[0].(*bitbucket.Project)
@@ -392,359 +395,324 @@ func (m *Mockworkspace) GetProject(opt bitbucket.ProjectOptions) (*bitbucket.Pro
 }
 
 // GetProject indicates an expected call of GetProject.
-func (mr *MockworkspaceMockRecorder) GetProject(opt any) *gomock.Call {
+func (mr *MockWorkspaceServiceMockRecorder) GetProject(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*Mockworkspace)(nil).GetProject), opt)
-}
-
-// Mockissues is a mock of issues interface.
-type Mockissues struct {
-	ctrl     *gomock.Controller
-	recorder *MockissuesMockRecorder
-	isgomock struct{}
-}
-
-// MockissuesMockRecorder is the mock recorder for Mockissues.
-type MockissuesMockRecorder struct {
-	mock *Mockissues
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockWorkspaceService)(nil).GetProject), opt)
 }
 
-// NewMockissues creates a new mock instance.
-func NewMockissues(ctrl *gomock.Controller) *Mockissues {
-	mock := &Mockissues{ctrl: ctrl}
-	mock.recorder = &MockissuesMockRecorder{mock}
-	return mock
+// GetWithOptions mocks base method.
+func (m *MockWorkspaceService) GetWithOptions(wo *bitbucket.WorkspaceOptions) (*bitbucket.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithOptions", wo)
+	ret0, _ := ret[0].(*bitbucket.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockissues) EXPECT() *MockissuesMockRecorder {
-	return m.recorder
+// GetWithOptions indicates an expected call of GetWithOptions.
+func (mr *MockWorkspaceServiceMockRecorder) GetWithOptions(wo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithOptions", reflect.TypeOf((*MockWorkspaceService)(nil).GetWithOptions), wo)
 }
 
-// Create mocks base method.
-func (m *Mockissues) Create(io *bitbucket.IssuesOptions) (any, error) {
+// List mocks base method.
+func (m *MockWorkspaceService) List() (*bitbucket.WorkspaceList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", io)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].(*bitbucket.WorkspaceList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockissuesMockRecorder) Create(io any) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockWorkspaceServiceMockRecorder) List() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockissues)(nil).Create), io)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWorkspaceService)(nil).List))
 }
 
-// CreateChange mocks base method.
-func (m *Mockissues) CreateChange(ico *bitbucket.IssueChangesOptions) (any, error) {
+// ListWithOptions mocks base method.
+func (m *MockWorkspaceService) ListWithOptions(wo *bitbucket.WorkspaceOptions) (*bitbucket.WorkspaceList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateChange", ico)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "ListWithOptions", wo)
+	ret0, _ := ret[0].(*bitbucket.WorkspaceList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateChange indicates an expected call of CreateChange.
-func (mr *MockissuesMockRecorder) CreateChange(ico any) *gomock.Call {
+// ListWithOptions indicates an expected call of ListWithOptions.
+func (mr *MockWorkspaceServiceMockRecorder) ListWithOptions(wo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChange", reflect.TypeOf((*Mockissues)(nil).CreateChange), ico)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWithOptions", reflect.TypeOf((*MockWorkspaceService)(nil).ListWithOptions), wo)
 }
 
-// CreateComment mocks base method.
-func (m *Mockissues) CreateComment(ico *bitbucket.IssueCommentsOptions) (any, error) {
+// Members mocks base method.
+func (m *MockWorkspaceService) Members(teamname string) (*bitbucket.WorkspaceMembers, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateComment", ico)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Members", teamname)
+	ret0, _ := ret[0].(*bitbucket.WorkspaceMembers)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateComment indicates an expected call of CreateComment.
-func (mr *MockissuesMockRecorder) CreateComment(ico any) *gomock.Call {
+// Members indicates an expected call of Members.
+func (mr *MockWorkspaceServiceMockRecorder) Members(teamname any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*Mockissues)(nil).CreateComment), ico)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockWorkspaceService)(nil).Members), teamname)
 }
 
-// Delete mocks base method.
-func (m *Mockissues) Delete(io *bitbucket.IssuesOptions) (any, error) {
+// MembersWithOptions mocks base method.
+func (m *MockWorkspaceService) MembersWithOptions(wo *bitbucket.WorkspaceOptions) (*bitbucket.WorkspaceMembers, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", io)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "MembersWithOptions", wo)
+	ret0, _ := ret[0].(*bitbucket.WorkspaceMembers)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockissuesMockRecorder) Delete(io any) *gomock.Call {
+// MembersWithOptions indicates an expected call of MembersWithOptions.
+func (mr *MockWorkspaceServiceMockRecorder) MembersWithOptions(wo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockissues)(nil).Delete), io)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MembersWithOptions", reflect.TypeOf((*MockWorkspaceService)(nil).MembersWithOptions), wo)
 }
 
-// DeleteComment mocks base method.
-func (m *Mockissues) DeleteComment(ico *bitbucket.IssueCommentsOptions) (any, error) {
+// Projects mocks base method.
+func (m *MockWorkspaceService) Projects(teamname string) (*bitbucket.ProjectsRes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteComment", ico)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Projects", teamname)
+	ret0, _ := ret[0].(*bitbucket.ProjectsRes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteComment indicates an expected call of DeleteComment.
-func (mr *MockissuesMockRecorder) DeleteComment(ico any) *gomock.Call {
+// Projects indicates an expected call of Projects.
+func (mr *MockWorkspaceServiceMockRecorder) Projects(teamname any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*Mockissues)(nil).DeleteComment), ico)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Projects", reflect.TypeOf((*MockWorkspaceService)(nil).Projects), teamname)
 }
 
-// DeleteVote mocks base method.
-func (m *Mockissues) DeleteVote(io *bitbucket.IssuesOptions) error {
+// ProjectsWithOptions mocks base method.
+func (m *MockWorkspaceService) ProjectsWithOptions(wo *bitbucket.WorkspaceOptions) (*bitbucket.ProjectsRes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteVote", io)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ProjectsWithOptions", wo)
+	ret0, _ := ret[0].(*bitbucket.ProjectsRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteVote indicates an expected call of DeleteVote.
-func (mr *MockissuesMockRecorder) DeleteVote(io any) *gomock.Call {
+// ProjectsWithOptions indicates an expected call of ProjectsWithOptions.
+func (mr *MockWorkspaceServiceMockRecorder) ProjectsWithOptions(wo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVote", reflect.TypeOf((*Mockissues)(nil).DeleteVote), io)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectsWithOptions", reflect.TypeOf((*MockWorkspaceService)(nil).ProjectsWithOptions), wo)
 }
 
-// DeleteWatch mocks base method.
-func (m *Mockissues) DeleteWatch(io *bitbucket.IssuesOptions) error {
+// SearchCode mocks base method.
+func (m *MockWorkspaceService) SearchCode(ctx context.Context, workspace, query string) iter.Seq2[bitbucket.CodeSearchResult, error] {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWatch", io)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "SearchCode", ctx, workspace, query)
+	ret0, _ := ret[0].(iter.Seq2[bitbucket.CodeSearchResult, error])
 	return ret0
 }
 
-// DeleteWatch indicates an expected call of DeleteWatch.
-func (mr *MockissuesMockRecorder) DeleteWatch(io any) *gomock.Call {
+// SearchCode indicates an expected call of SearchCode.
+func (mr *MockWorkspaceServiceMockRecorder) SearchCode(ctx, workspace, query any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatch", reflect.TypeOf((*Mockissues)(nil).DeleteWatch), io)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCode", reflect.TypeOf((*MockWorkspaceService)(nil).SearchCode), ctx, workspace, query)
 }
 
-// Get mocks base method.
-func (m *Mockissues) Get(io *bitbucket.IssuesOptions) (any, error) {
+// UpdateProject mocks base method.
+func (m *MockWorkspaceService) UpdateProject(opt *bitbucket.ProjectOptions) (*bitbucket.Project, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", io)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "UpdateProject", opt)
+	ret0, _ := ret[0].(*bitbucket.Project)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockissuesMockRecorder) Get(io any) *gomock.Call {
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockWorkspaceServiceMockRecorder) UpdateProject(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockissues)(nil).Get), io)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockWorkspaceService)(nil).UpdateProject), opt)
 }
 
-// GetChange mocks base method.
-func (m *Mockissues) GetChange(ico *bitbucket.IssueChangesOptions) (any, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChange", ico)
-	ret0, _ := ret[0].(any)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockPermissionService is a mock of PermissionService interface.
+type MockPermissionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPermissionServiceMockRecorder
+	isgomock struct{}
 }
 
-// GetChange indicates an expected call of GetChange.
-func (mr *MockissuesMockRecorder) GetChange(ico any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChange", reflect.TypeOf((*Mockissues)(nil).GetChange), ico)
+// MockPermissionServiceMockRecorder is the mock recorder for MockPermissionService.
+type MockPermissionServiceMockRecorder struct {
+	mock *MockPermissionService
 }
 
-// GetChanges mocks base method.
-func (m *Mockissues) GetChanges(ico *bitbucket.IssueChangesOptions) (any, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChanges", ico)
-	ret0, _ := ret[0].(any)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// NewMockPermissionService creates a new mock instance.
+func NewMockPermissionService(ctrl *gomock.Controller) *MockPermissionService {
+	mock := &MockPermissionService{ctrl: ctrl}
+	mock.recorder = &MockPermissionServiceMockRecorder{mock}
+	return mock
 }
 
-// GetChanges indicates an expected call of GetChanges.
-func (mr *MockissuesMockRecorder) GetChanges(ico any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*Mockissues)(nil).GetChanges), ico)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPermissionService) EXPECT() *MockPermissionServiceMockRecorder {
+	return m.recorder
 }
 
-// GetComment mocks base method.
-func (m *Mockissues) GetComment(ico *bitbucket.IssueCommentsOptions) (any, error) {
+// GetUserPermissions mocks base method.
+func (m *MockPermissionService) GetUserPermissions(organization, member string) (*bitbucket.Permission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetComment", ico)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "GetUserPermissions", organization, member)
+	ret0, _ := ret[0].(*bitbucket.Permission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetComment indicates an expected call of GetComment.
-func (mr *MockissuesMockRecorder) GetComment(ico any) *gomock.Call {
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockPermissionServiceMockRecorder) GetUserPermissions(organization, member any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*Mockissues)(nil).GetComment), ico)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockPermissionService)(nil).GetUserPermissions), organization, member)
 }
 
-// GetComments mocks base method.
-func (m *Mockissues) GetComments(ico *bitbucket.IssueCommentsOptions) (any, error) {
+// GetUserPermissionsByUuid mocks base method.
+func (m *MockPermissionService) GetUserPermissionsByUuid(organization, member string) (*bitbucket.Permission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetComments", ico)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "GetUserPermissionsByUuid", organization, member)
+	ret0, _ := ret[0].(*bitbucket.Permission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetComments indicates an expected call of GetComments.
-func (mr *MockissuesMockRecorder) GetComments(ico any) *gomock.Call {
+// GetUserPermissionsByUuid indicates an expected call of GetUserPermissionsByUuid.
+func (mr *MockPermissionServiceMockRecorder) GetUserPermissionsByUuid(organization, member any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*Mockissues)(nil).GetComments), ico)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissionsByUuid", reflect.TypeOf((*MockPermissionService)(nil).GetUserPermissionsByUuid), organization, member)
 }
 
-// GetVote mocks base method.
-func (m *Mockissues) GetVote(io *bitbucket.IssuesOptions) (bool, any, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVote", io)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(any)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+// MockRepositoriesService is a mock of RepositoriesService interface.
+type MockRepositoriesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoriesServiceMockRecorder
+	isgomock struct{}
 }
 
-// GetVote indicates an expected call of GetVote.
-func (mr *MockissuesMockRecorder) GetVote(io any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVote", reflect.TypeOf((*Mockissues)(nil).GetVote), io)
+// MockRepositoriesServiceMockRecorder is the mock recorder for MockRepositoriesService.
+type MockRepositoriesServiceMockRecorder struct {
+	mock *MockRepositoriesService
 }
 
-// GetWatch mocks base method.
-func (m *Mockissues) GetWatch(io *bitbucket.IssuesOptions) (bool, any, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWatch", io)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(any)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+// NewMockRepositoriesService creates a new mock instance.
+func NewMockRepositoriesService(ctrl *gomock.Controller) *MockRepositoriesService {
+	mock := &MockRepositoriesService{ctrl: ctrl}
+	mock.recorder = &MockRepositoriesServiceMockRecorder{mock}
+	return mock
 }
 
-// GetWatch indicates an expected call of GetWatch.
-func (mr *MockissuesMockRecorder) GetWatch(io any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatch", reflect.TypeOf((*Mockissues)(nil).GetWatch), io)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepositoriesService) EXPECT() *MockRepositoriesServiceMockRecorder {
+	return m.recorder
 }
 
-// Gets mocks base method.
-func (m *Mockissues) Gets(io *bitbucket.IssuesOptions) (any, error) {
+// ListForAccount mocks base method.
+func (m *MockRepositoriesService) ListForAccount(ro *bitbucket.RepositoriesOptions) (*bitbucket.RepositoriesRes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Gets", io)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "ListForAccount", ro)
+	ret0, _ := ret[0].(*bitbucket.RepositoriesRes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Gets indicates an expected call of Gets.
-func (mr *MockissuesMockRecorder) Gets(io any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*Mockissues)(nil).Gets), io)
-}
-
-// PutVote mocks base method.
-func (m *Mockissues) PutVote(io *bitbucket.IssuesOptions) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PutVote", io)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// PutVote indicates an expected call of PutVote.
-func (mr *MockissuesMockRecorder) PutVote(io any) *gomock.Call {
+// ListForAccount indicates an expected call of ListForAccount.
+func (mr *MockRepositoriesServiceMockRecorder) ListForAccount(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutVote", reflect.TypeOf((*Mockissues)(nil).PutVote), io)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForAccount", reflect.TypeOf((*MockRepositoriesService)(nil).ListForAccount), ro)
 }
 
-// PutWatch mocks base method.
-func (m *Mockissues) PutWatch(io *bitbucket.IssuesOptions) error {
+// ListForTeam mocks base method.
+func (m *MockRepositoriesService) ListForTeam(ro *bitbucket.RepositoriesOptions) (*bitbucket.RepositoriesRes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PutWatch", io)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListForTeam", ro)
+	ret0, _ := ret[0].(*bitbucket.RepositoriesRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// PutWatch indicates an expected call of PutWatch.
-func (mr *MockissuesMockRecorder) PutWatch(io any) *gomock.Call {
+// ListForTeam indicates an expected call of ListForTeam.
+func (mr *MockRepositoriesServiceMockRecorder) ListForTeam(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutWatch", reflect.TypeOf((*Mockissues)(nil).PutWatch), io)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForTeam", reflect.TypeOf((*MockRepositoriesService)(nil).ListForTeam), ro)
 }
 
-// Update mocks base method.
-func (m *Mockissues) Update(io *bitbucket.IssuesOptions) (any, error) {
+// ListProject mocks base method.
+func (m *MockRepositoriesService) ListProject(ro *bitbucket.RepositoriesOptions) (*bitbucket.RepositoriesRes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", io)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "ListProject", ro)
+	ret0, _ := ret[0].(*bitbucket.RepositoriesRes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockissuesMockRecorder) Update(io any) *gomock.Call {
+// ListProject indicates an expected call of ListProject.
+func (mr *MockRepositoriesServiceMockRecorder) ListProject(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*Mockissues)(nil).Update), io)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProject", reflect.TypeOf((*MockRepositoriesService)(nil).ListProject), ro)
 }
 
-// UpdateComment mocks base method.
-func (m *Mockissues) UpdateComment(ico *bitbucket.IssueCommentsOptions) (any, error) {
+// ListPublic mocks base method.
+func (m *MockRepositoriesService) ListPublic() (*bitbucket.RepositoriesRes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateComment", ico)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "ListPublic")
+	ret0, _ := ret[0].(*bitbucket.RepositoriesRes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateComment indicates an expected call of UpdateComment.
-func (mr *MockissuesMockRecorder) UpdateComment(ico any) *gomock.Call {
+// ListPublic indicates an expected call of ListPublic.
+func (mr *MockRepositoriesServiceMockRecorder) ListPublic() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*Mockissues)(nil).UpdateComment), ico)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublic", reflect.TypeOf((*MockRepositoriesService)(nil).ListPublic))
 }
 
-// Mockrepository is a mock of repository interface.
-type Mockrepository struct {
+// MockRepositoryService is a mock of RepositoryService interface.
+type MockRepositoryService struct {
 	ctrl     *gomock.Controller
-	recorder *MockrepositoryMockRecorder
+	recorder *MockRepositoryServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockrepositoryMockRecorder is the mock recorder for Mockrepository.
-type MockrepositoryMockRecorder struct {
-	mock *Mockrepository
+// MockRepositoryServiceMockRecorder is the mock recorder for MockRepositoryService.
+type MockRepositoryServiceMockRecorder struct {
+	mock *MockRepositoryService
 }
 
-// NewMockrepository creates a new mock instance.
-func NewMockrepository(ctrl *gomock.Controller) *Mockrepository {
-	mock := &Mockrepository{ctrl: ctrl}
-	mock.recorder = &MockrepositoryMockRecorder{mock}
+// NewMockRepositoryService creates a new mock instance.
+func NewMockRepositoryService(ctrl *gomock.Controller) *MockRepositoryService {
+	mock := &MockRepositoryService{ctrl: ctrl}
+	mock.recorder = &MockRepositoryServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
+func (m *MockRepositoryService) EXPECT() *MockRepositoryServiceMockRecorder {
 	return m.recorder
 }
 
 // AddDefaultReviewer mocks base method.
-func (m *Mockrepository) AddDefaultReviewer(opt bitbucket.RepositoryDefaultReviewerOptions) (*bitbucket.DefaultReviewer, error) {
+func (m *MockRepositoryService) AddDefaultReviewer(rdro *bitbucket.RepositoryDefaultReviewerOptions) (*bitbucket.DefaultReviewer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddDefaultReviewer", opt)
+	ret := m.ctrl.Call(m, "AddDefaultReviewer", rdro)
 	ret0, _ := ret[0].(*bitbucket.DefaultReviewer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddDefaultReviewer indicates an expected call of AddDefaultReviewer.
-func (mr *MockrepositoryMockRecorder) AddDefaultReviewer(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) AddDefaultReviewer(rdro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDefaultReviewer", reflect.TypeOf((*Mockrepository)(nil).AddDefaultReviewer), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDefaultReviewer", reflect.TypeOf((*MockRepositoryService)(nil).AddDefaultReviewer), rdro)
 }
 
 // AddDeploymentVariable mocks base method.
-func (m *Mockrepository) AddDeploymentVariable(opt bitbucket.RepositoryDeploymentVariableOptions) (*bitbucket.DeploymentVariable, error) {
+func (m *MockRepositoryService) AddDeploymentVariable(opt *bitbucket.RepositoryDeploymentVariableOptions) (*bitbucket.DeploymentVariable, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddDeploymentVariable", opt)
 	ret0, _ := ret[0].(*bitbucket.DeploymentVariable)
@@ -753,13 +721,13 @@ func (m *Mockrepository) AddDeploymentVariable(opt bitbucket.RepositoryDeploymen
 }
 
 // AddDeploymentVariable indicates an expected call of AddDeploymentVariable.
-func (mr *MockrepositoryMockRecorder) AddDeploymentVariable(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) AddDeploymentVariable(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeploymentVariable", reflect.TypeOf((*Mockrepository)(nil).AddDeploymentVariable), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeploymentVariable", reflect.TypeOf((*MockRepositoryService)(nil).AddDeploymentVariable), opt)
 }
 
 // AddEnvironment mocks base method.
-func (m *Mockrepository) AddEnvironment(opt bitbucket.RepositoryEnvironmentOptions) (*bitbucket.Environment, error) {
+func (m *MockRepositoryService) AddEnvironment(opt *bitbucket.RepositoryEnvironmentOptions) (*bitbucket.Environment, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddEnvironment", opt)
 	ret0, _ := ret[0].(*bitbucket.Environment)
@@ -768,118 +736,192 @@ func (m *Mockrepository) AddEnvironment(opt bitbucket.RepositoryEnvironmentOptio
 }
 
 // AddEnvironment indicates an expected call of AddEnvironment.
-func (mr *MockrepositoryMockRecorder) AddEnvironment(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) AddEnvironment(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEnvironment", reflect.TypeOf((*Mockrepository)(nil).AddEnvironment), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEnvironment", reflect.TypeOf((*MockRepositoryService)(nil).AddEnvironment), opt)
 }
 
 // AddPipelineKeyPair mocks base method.
-func (m *Mockrepository) AddPipelineKeyPair(opt bitbucket.RepositoryPipelineKeyPairOptions) (*bitbucket.PipelineKeyPair, error) {
+func (m *MockRepositoryService) AddPipelineKeyPair(rpkpo *bitbucket.RepositoryPipelineKeyPairOptions) (*bitbucket.PipelineKeyPair, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPipelineKeyPair", opt)
+	ret := m.ctrl.Call(m, "AddPipelineKeyPair", rpkpo)
 	ret0, _ := ret[0].(*bitbucket.PipelineKeyPair)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddPipelineKeyPair indicates an expected call of AddPipelineKeyPair.
-func (mr *MockrepositoryMockRecorder) AddPipelineKeyPair(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) AddPipelineKeyPair(rpkpo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPipelineKeyPair", reflect.TypeOf((*Mockrepository)(nil).AddPipelineKeyPair), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPipelineKeyPair", reflect.TypeOf((*MockRepositoryService)(nil).AddPipelineKeyPair), rpkpo)
 }
 
 // AddPipelineVariable mocks base method.
-func (m *Mockrepository) AddPipelineVariable(opt bitbucket.RepositoryPipelineVariableOptions) (*bitbucket.PipelineVariable, error) {
+func (m *MockRepositoryService) AddPipelineVariable(rpvo *bitbucket.RepositoryPipelineVariableOptions) (*bitbucket.PipelineVariable, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddPipelineVariable", opt)
+	ret := m.ctrl.Call(m, "AddPipelineVariable", rpvo)
 	ret0, _ := ret[0].(*bitbucket.PipelineVariable)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddPipelineVariable indicates an expected call of AddPipelineVariable.
-func (mr *MockrepositoryMockRecorder) AddPipelineVariable(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) AddPipelineVariable(rpvo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPipelineVariable", reflect.TypeOf((*Mockrepository)(nil).AddPipelineVariable), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPipelineVariable", reflect.TypeOf((*MockRepositoryService)(nil).AddPipelineVariable), rpvo)
 }
 
 // BranchingModel mocks base method.
-func (m *Mockrepository) BranchingModel(opt bitbucket.RepositoryBranchingModelOptions) (*bitbucket.BranchingModel, error) {
+func (m *MockRepositoryService) BranchingModel(rbmo *bitbucket.RepositoryBranchingModelOptions) (*bitbucket.BranchingModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BranchingModel", opt)
+	ret := m.ctrl.Call(m, "BranchingModel", rbmo)
 	ret0, _ := ret[0].(*bitbucket.BranchingModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BranchingModel indicates an expected call of BranchingModel.
-func (mr *MockrepositoryMockRecorder) BranchingModel(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) BranchingModel(rbmo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchingModel", reflect.TypeOf((*Mockrepository)(nil).BranchingModel), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchingModel", reflect.TypeOf((*MockRepositoryService)(nil).BranchingModel), rbmo)
 }
 
-// Create mocks base method.
-func (m *Mockrepository) Create(opt bitbucket.RepositoryOptions) (*bitbucket.Repository, error) {
+// CleanupBranches mocks base method.
+func (m *MockRepositoryService) CleanupBranches(bco *bitbucket.BranchCleanupOptions) (*bitbucket.BranchCleanupReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", opt)
-	ret0, _ := ret[0].(*bitbucket.Repository)
+	ret := m.ctrl.Call(m, "CleanupBranches", bco)
+	ret0, _ := ret[0].(*bitbucket.BranchCleanupReport)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockrepositoryMockRecorder) Create(opt any) *gomock.Call {
+// CleanupBranches indicates an expected call of CleanupBranches.
+func (mr *MockRepositoryServiceMockRecorder) CleanupBranches(bco any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockrepository)(nil).Create), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupBranches", reflect.TypeOf((*MockRepositoryService)(nil).CleanupBranches), bco)
 }
 
-// Delete mocks base method.
-func (m *Mockrepository) Delete(opt bitbucket.RepositoryOptions) (any, error) {
+// Compare mocks base method.
+func (m *MockRepositoryService) Compare(co *bitbucket.CompareOptions) (*bitbucket.Comparison, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Compare", co)
+	ret0, _ := ret[0].(*bitbucket.Comparison)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockrepositoryMockRecorder) Delete(opt any) *gomock.Call {
+// Compare indicates an expected call of Compare.
+func (mr *MockRepositoryServiceMockRecorder) Compare(co any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockrepository)(nil).Delete), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockRepositoryService)(nil).Compare), co)
 }
 
-// DeleteDefaultReviewer mocks base method.
-func (m *Mockrepository) DeleteDefaultReviewer(opt bitbucket.RepositoryDefaultReviewerOptions) (any, error) {
+// Create mocks base method.
+func (m *MockRepositoryService) Create(ro *bitbucket.RepositoryOptions) (*bitbucket.Repository, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDefaultReviewer", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Create", ro)
+	ret0, _ := ret[0].(*bitbucket.Repository)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteDefaultReviewer indicates an expected call of DeleteDefaultReviewer.
-func (mr *MockrepositoryMockRecorder) DeleteDefaultReviewer(opt any) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryServiceMockRecorder) Create(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDefaultReviewer", reflect.TypeOf((*Mockrepository)(nil).DeleteDefaultReviewer), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepositoryService)(nil).Create), ro)
 }
 
-// DeleteDeploymentVariable mocks base method.
-func (m *Mockrepository) DeleteDeploymentVariable(opt bitbucket.RepositoryDeploymentVariableDeleteOptions) (any, error) {
+// CreateBranch mocks base method.
+func (m *MockRepositoryService) CreateBranch(rbo *bitbucket.RepositoryBranchCreationOptions) (*bitbucket.RepositoryBranch, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDeploymentVariable", opt)
+	ret := m.ctrl.Call(m, "CreateBranch", rbo)
+	ret0, _ := ret[0].(*bitbucket.RepositoryBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBranch indicates an expected call of CreateBranch.
+func (mr *MockRepositoryServiceMockRecorder) CreateBranch(rbo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranch", reflect.TypeOf((*MockRepositoryService)(nil).CreateBranch), rbo)
+}
+
+// CreateTag mocks base method.
+func (m *MockRepositoryService) CreateTag(rbo *bitbucket.RepositoryTagCreationOptions) (*bitbucket.RepositoryTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", rbo)
+	ret0, _ := ret[0].(*bitbucket.RepositoryTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockRepositoryServiceMockRecorder) CreateTag(rbo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockRepositoryService)(nil).CreateTag), rbo)
+}
+
+// Delete mocks base method.
+func (m *MockRepositoryService) Delete(ro *bitbucket.RepositoryOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ro)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryServiceMockRecorder) Delete(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepositoryService)(nil).Delete), ro)
+}
+
+// DeleteBranch mocks base method.
+func (m *MockRepositoryService) DeleteBranch(rbo *bitbucket.RepositoryBranchDeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranch", rbo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranch indicates an expected call of DeleteBranch.
+func (mr *MockRepositoryServiceMockRecorder) DeleteBranch(rbo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockRepositoryService)(nil).DeleteBranch), rbo)
+}
+
+// DeleteDefaultReviewer mocks base method.
+func (m *MockRepositoryService) DeleteDefaultReviewer(rdro *bitbucket.RepositoryDefaultReviewerOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDefaultReviewer", rdro)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDefaultReviewer indicates an expected call of DeleteDefaultReviewer.
+func (mr *MockRepositoryServiceMockRecorder) DeleteDefaultReviewer(rdro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDefaultReviewer", reflect.TypeOf((*MockRepositoryService)(nil).DeleteDefaultReviewer), rdro)
+}
+
+// DeleteDeploymentVariable mocks base method.
+func (m *MockRepositoryService) DeleteDeploymentVariable(opt *bitbucket.RepositoryDeploymentVariableDeleteOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeploymentVariable", opt)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteDeploymentVariable indicates an expected call of DeleteDeploymentVariable.
-func (mr *MockrepositoryMockRecorder) DeleteDeploymentVariable(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) DeleteDeploymentVariable(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeploymentVariable", reflect.TypeOf((*Mockrepository)(nil).DeleteDeploymentVariable), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeploymentVariable", reflect.TypeOf((*MockRepositoryService)(nil).DeleteDeploymentVariable), opt)
 }
 
 // DeleteEnvironment mocks base method.
-func (m *Mockrepository) DeleteEnvironment(opt bitbucket.RepositoryEnvironmentDeleteOptions) (any, error) {
+func (m *MockRepositoryService) DeleteEnvironment(opt *bitbucket.RepositoryEnvironmentDeleteOptions) (any, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteEnvironment", opt)
 	ret0, _ := ret[0].(any)
@@ -888,13 +930,43 @@ func (m *Mockrepository) DeleteEnvironment(opt bitbucket.RepositoryEnvironmentDe
 }
 
 // DeleteEnvironment indicates an expected call of DeleteEnvironment.
-func (mr *MockrepositoryMockRecorder) DeleteEnvironment(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) DeleteEnvironment(opt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironment", reflect.TypeOf((*MockRepositoryService)(nil).DeleteEnvironment), opt)
+}
+
+// DeleteGroupPermissions mocks base method.
+func (m *MockRepositoryService) DeleteGroupPermissions(rgo *bitbucket.RepositoryGroupPermissionsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupPermissions", rgo)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGroupPermissions indicates an expected call of DeleteGroupPermissions.
+func (mr *MockRepositoryServiceMockRecorder) DeleteGroupPermissions(rgo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupPermissions", reflect.TypeOf((*MockRepositoryService)(nil).DeleteGroupPermissions), rgo)
+}
+
+// DeletePipelineKeyPair mocks base method.
+func (m *MockRepositoryService) DeletePipelineKeyPair(rpkpo *bitbucket.RepositoryPipelineKeyPairOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePipelineKeyPair", rpkpo)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePipelineKeyPair indicates an expected call of DeletePipelineKeyPair.
+func (mr *MockRepositoryServiceMockRecorder) DeletePipelineKeyPair(rpkpo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironment", reflect.TypeOf((*Mockrepository)(nil).DeleteEnvironment), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePipelineKeyPair", reflect.TypeOf((*MockRepositoryService)(nil).DeletePipelineKeyPair), rpkpo)
 }
 
 // DeletePipelineVariable mocks base method.
-func (m *Mockrepository) DeletePipelineVariable(opt bitbucket.RepositoryPipelineVariableDeleteOptions) (any, error) {
+func (m *MockRepositoryService) DeletePipelineVariable(opt *bitbucket.RepositoryPipelineVariableDeleteOptions) (any, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeletePipelineVariable", opt)
 	ret0, _ := ret[0].(any)
@@ -903,43 +975,161 @@ func (m *Mockrepository) DeletePipelineVariable(opt bitbucket.RepositoryPipeline
 }
 
 // DeletePipelineVariable indicates an expected call of DeletePipelineVariable.
-func (mr *MockrepositoryMockRecorder) DeletePipelineVariable(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) DeletePipelineVariable(opt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePipelineVariable", reflect.TypeOf((*MockRepositoryService)(nil).DeletePipelineVariable), opt)
+}
+
+// DeleteTag mocks base method.
+func (m *MockRepositoryService) DeleteTag(rbo *bitbucket.RepositoryTagOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", rbo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockRepositoryServiceMockRecorder) DeleteTag(rbo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockRepositoryService)(nil).DeleteTag), rbo)
+}
+
+// DeleteUserPermissions mocks base method.
+func (m *MockRepositoryService) DeleteUserPermissions(rgo *bitbucket.RepositoryUserPermissionsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserPermissions", rgo)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserPermissions indicates an expected call of DeleteUserPermissions.
+func (mr *MockRepositoryServiceMockRecorder) DeleteUserPermissions(rgo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserPermissions", reflect.TypeOf((*MockRepositoryService)(nil).DeleteUserPermissions), rgo)
+}
+
+// DownloadArchive mocks base method.
+func (m *MockRepositoryService) DownloadArchive(rao *bitbucket.RepositoryArchiveOptions) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadArchive", rao)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadArchive indicates an expected call of DownloadArchive.
+func (mr *MockRepositoryServiceMockRecorder) DownloadArchive(rao any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadArchive", reflect.TypeOf((*MockRepositoryService)(nil).DownloadArchive), rao)
+}
+
+// FS mocks base method.
+func (m *MockRepositoryService) FS(fo *bitbucket.RepositoryFSOptions) (*bitbucket.RepositoryFS, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FS", fo)
+	ret0, _ := ret[0].(*bitbucket.RepositoryFS)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FS indicates an expected call of FS.
+func (mr *MockRepositoryServiceMockRecorder) FS(fo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FS", reflect.TypeOf((*MockRepositoryService)(nil).FS), fo)
+}
+
+// FileHistory mocks base method.
+func (m *MockRepositoryService) FileHistory(fho *bitbucket.FileHistoryOptions) iter.Seq2[bitbucket.RepositoryFile, error] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileHistory", fho)
+	ret0, _ := ret[0].(iter.Seq2[bitbucket.RepositoryFile, error])
+	return ret0
+}
+
+// FileHistory indicates an expected call of FileHistory.
+func (mr *MockRepositoryServiceMockRecorder) FileHistory(fho any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileHistory", reflect.TypeOf((*MockRepositoryService)(nil).FileHistory), fho)
+}
+
+// FindTagsForCommit mocks base method.
+func (m *MockRepositoryService) FindTagsForCommit(rbo *bitbucket.RepositoryTagOptions, commit string) ([]bitbucket.RepositoryTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTagsForCommit", rbo, commit)
+	ret0, _ := ret[0].([]bitbucket.RepositoryTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTagsForCommit indicates an expected call of FindTagsForCommit.
+func (mr *MockRepositoryServiceMockRecorder) FindTagsForCommit(rbo, commit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTagsForCommit", reflect.TypeOf((*MockRepositoryService)(nil).FindTagsForCommit), rbo, commit)
+}
+
+// Fork mocks base method.
+func (m *MockRepositoryService) Fork(fo *bitbucket.RepositoryForkOptions) (*bitbucket.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fork", fo)
+	ret0, _ := ret[0].(*bitbucket.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fork indicates an expected call of Fork.
+func (mr *MockRepositoryServiceMockRecorder) Fork(fo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePipelineVariable", reflect.TypeOf((*Mockrepository)(nil).DeletePipelineVariable), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fork", reflect.TypeOf((*MockRepositoryService)(nil).Fork), fo)
 }
 
 // Get mocks base method.
-func (m *Mockrepository) Get(opt bitbucket.RepositoryOptions) (*bitbucket.Repository, error) {
+func (m *MockRepositoryService) Get(ro *bitbucket.RepositoryOptions) (*bitbucket.Repository, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", opt)
+	ret := m.ctrl.Call(m, "Get", ro)
 	ret0, _ := ret[0].(*bitbucket.Repository)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockrepositoryMockRecorder) Get(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) Get(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRepositoryService)(nil).Get), ro)
+}
+
+// GetBranch mocks base method.
+func (m *MockRepositoryService) GetBranch(rbo *bitbucket.RepositoryBranchOptions) (*bitbucket.RepositoryBranch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranch", rbo)
+	ret0, _ := ret[0].(*bitbucket.RepositoryBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranch indicates an expected call of GetBranch.
+func (mr *MockRepositoryServiceMockRecorder) GetBranch(rbo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockrepository)(nil).Get), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockRepositoryService)(nil).GetBranch), rbo)
 }
 
 // GetDefaultReviewer mocks base method.
-func (m *Mockrepository) GetDefaultReviewer(opt bitbucket.RepositoryDefaultReviewerOptions) (*bitbucket.DefaultReviewer, error) {
+func (m *MockRepositoryService) GetDefaultReviewer(rdro *bitbucket.RepositoryDefaultReviewerOptions) (*bitbucket.DefaultReviewer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDefaultReviewer", opt)
+	ret := m.ctrl.Call(m, "GetDefaultReviewer", rdro)
 	ret0, _ := ret[0].(*bitbucket.DefaultReviewer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDefaultReviewer indicates an expected call of GetDefaultReviewer.
-func (mr *MockrepositoryMockRecorder) GetDefaultReviewer(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) GetDefaultReviewer(rdro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultReviewer", reflect.TypeOf((*Mockrepository)(nil).GetDefaultReviewer), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultReviewer", reflect.TypeOf((*MockRepositoryService)(nil).GetDefaultReviewer), rdro)
 }
 
 // GetEnvironment mocks base method.
-func (m *Mockrepository) GetEnvironment(opt bitbucket.RepositoryEnvironmentOptions) (*bitbucket.Environment, error) {
+func (m *MockRepositoryService) GetEnvironment(opt *bitbucket.RepositoryEnvironmentOptions) (*bitbucket.Environment, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetEnvironment", opt)
 	ret0, _ := ret[0].(*bitbucket.Environment)
@@ -948,28 +1138,88 @@ func (m *Mockrepository) GetEnvironment(opt bitbucket.RepositoryEnvironmentOptio
 }
 
 // GetEnvironment indicates an expected call of GetEnvironment.
-func (mr *MockrepositoryMockRecorder) GetEnvironment(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) GetEnvironment(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironment", reflect.TypeOf((*Mockrepository)(nil).GetEnvironment), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironment", reflect.TypeOf((*MockRepositoryService)(nil).GetEnvironment), opt)
 }
 
 // GetFileBlob mocks base method.
-func (m *Mockrepository) GetFileBlob(opt bitbucket.RepositoryBlobOptions) (*bitbucket.RepositoryBlob, error) {
+func (m *MockRepositoryService) GetFileBlob(ro *bitbucket.RepositoryBlobOptions) (*bitbucket.RepositoryBlob, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileBlob", opt)
+	ret := m.ctrl.Call(m, "GetFileBlob", ro)
 	ret0, _ := ret[0].(*bitbucket.RepositoryBlob)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFileBlob indicates an expected call of GetFileBlob.
-func (mr *MockrepositoryMockRecorder) GetFileBlob(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) GetFileBlob(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileBlob", reflect.TypeOf((*MockRepositoryService)(nil).GetFileBlob), ro)
+}
+
+// GetFileContent mocks base method.
+func (m *MockRepositoryService) GetFileContent(ro *bitbucket.RepositoryFilesOptions) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileContent", ro)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileContent indicates an expected call of GetFileContent.
+func (mr *MockRepositoryServiceMockRecorder) GetFileContent(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileContent", reflect.TypeOf((*MockRepositoryService)(nil).GetFileContent), ro)
+}
+
+// GetGroupPermissions mocks base method.
+func (m *MockRepositoryService) GetGroupPermissions(rgo *bitbucket.RepositoryGroupPermissionsOptions) (*bitbucket.GroupPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupPermissions", rgo)
+	ret0, _ := ret[0].(*bitbucket.GroupPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupPermissions indicates an expected call of GetGroupPermissions.
+func (mr *MockRepositoryServiceMockRecorder) GetGroupPermissions(rgo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupPermissions", reflect.TypeOf((*MockRepositoryService)(nil).GetGroupPermissions), rgo)
+}
+
+// GetPipelineConfig mocks base method.
+func (m *MockRepositoryService) GetPipelineConfig(rpo *bitbucket.RepositoryPipelineOptions) (*bitbucket.Pipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPipelineConfig", rpo)
+	ret0, _ := ret[0].(*bitbucket.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPipelineConfig indicates an expected call of GetPipelineConfig.
+func (mr *MockRepositoryServiceMockRecorder) GetPipelineConfig(rpo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipelineConfig", reflect.TypeOf((*MockRepositoryService)(nil).GetPipelineConfig), rpo)
+}
+
+// GetPipelineKeyPair mocks base method.
+func (m *MockRepositoryService) GetPipelineKeyPair(rpkpo *bitbucket.RepositoryPipelineKeyPairOptions) (*bitbucket.PipelineKeyPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPipelineKeyPair", rpkpo)
+	ret0, _ := ret[0].(*bitbucket.PipelineKeyPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPipelineKeyPair indicates an expected call of GetPipelineKeyPair.
+func (mr *MockRepositoryServiceMockRecorder) GetPipelineKeyPair(rpkpo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileBlob", reflect.TypeOf((*Mockrepository)(nil).GetFileBlob), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipelineKeyPair", reflect.TypeOf((*MockRepositoryService)(nil).GetPipelineKeyPair), rpkpo)
 }
 
 // GetPipelineVariable mocks base method.
-func (m *Mockrepository) GetPipelineVariable(opt bitbucket.RepositoryPipelineVariableOptions) (*bitbucket.PipelineVariable, error) {
+func (m *MockRepositoryService) GetPipelineVariable(opt *bitbucket.RepositoryPipelineVariableOptions) (*bitbucket.PipelineVariable, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPipelineVariable", opt)
 	ret0, _ := ret[0].(*bitbucket.PipelineVariable)
@@ -978,43 +1228,88 @@ func (m *Mockrepository) GetPipelineVariable(opt bitbucket.RepositoryPipelineVar
 }
 
 // GetPipelineVariable indicates an expected call of GetPipelineVariable.
-func (mr *MockrepositoryMockRecorder) GetPipelineVariable(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) GetPipelineVariable(opt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipelineVariable", reflect.TypeOf((*MockRepositoryService)(nil).GetPipelineVariable), opt)
+}
+
+// GetTag mocks base method.
+func (m *MockRepositoryService) GetTag(rbo *bitbucket.RepositoryTagOptions) (*bitbucket.RepositoryTag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTag", rbo)
+	ret0, _ := ret[0].(*bitbucket.RepositoryTag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTag indicates an expected call of GetTag.
+func (mr *MockRepositoryServiceMockRecorder) GetTag(rbo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTag", reflect.TypeOf((*MockRepositoryService)(nil).GetTag), rbo)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockRepositoryService) GetUserPermissions(rgo *bitbucket.RepositoryUserPermissionsOptions) (*bitbucket.UserPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPermissions", rgo)
+	ret0, _ := ret[0].(*bitbucket.UserPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockRepositoryServiceMockRecorder) GetUserPermissions(rgo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockRepositoryService)(nil).GetUserPermissions), rgo)
+}
+
+// LastCommits mocks base method.
+func (m *MockRepositoryService) LastCommits(ro *bitbucket.RepositoryFilesOptions) ([]bitbucket.LastCommit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastCommits", ro)
+	ret0, _ := ret[0].([]bitbucket.LastCommit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastCommits indicates an expected call of LastCommits.
+func (mr *MockRepositoryServiceMockRecorder) LastCommits(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipelineVariable", reflect.TypeOf((*Mockrepository)(nil).GetPipelineVariable), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastCommits", reflect.TypeOf((*MockRepositoryService)(nil).LastCommits), ro)
 }
 
 // ListBranches mocks base method.
-func (m *Mockrepository) ListBranches(opt bitbucket.RepositoryBranchOptions) (*bitbucket.RepositoryBranches, error) {
+func (m *MockRepositoryService) ListBranches(rbo *bitbucket.RepositoryBranchOptions) (*bitbucket.RepositoryBranches, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListBranches", opt)
+	ret := m.ctrl.Call(m, "ListBranches", rbo)
 	ret0, _ := ret[0].(*bitbucket.RepositoryBranches)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListBranches indicates an expected call of ListBranches.
-func (mr *MockrepositoryMockRecorder) ListBranches(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) ListBranches(rbo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*Mockrepository)(nil).ListBranches), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*MockRepositoryService)(nil).ListBranches), rbo)
 }
 
 // ListDefaultReviewers mocks base method.
-func (m *Mockrepository) ListDefaultReviewers(opt bitbucket.RepositoryOptions) (*bitbucket.DefaultReviewers, error) {
+func (m *MockRepositoryService) ListDefaultReviewers(ro *bitbucket.RepositoryOptions) (*bitbucket.DefaultReviewers, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListDefaultReviewers", opt)
+	ret := m.ctrl.Call(m, "ListDefaultReviewers", ro)
 	ret0, _ := ret[0].(*bitbucket.DefaultReviewers)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListDefaultReviewers indicates an expected call of ListDefaultReviewers.
-func (mr *MockrepositoryMockRecorder) ListDefaultReviewers(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) ListDefaultReviewers(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDefaultReviewers", reflect.TypeOf((*Mockrepository)(nil).ListDefaultReviewers), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDefaultReviewers", reflect.TypeOf((*MockRepositoryService)(nil).ListDefaultReviewers), ro)
 }
 
 // ListDeploymentVariables mocks base method.
-func (m *Mockrepository) ListDeploymentVariables(opt bitbucket.RepositoryDeploymentVariablesOptions) (*bitbucket.DeploymentVariables, error) {
+func (m *MockRepositoryService) ListDeploymentVariables(opt *bitbucket.RepositoryDeploymentVariablesOptions) (*bitbucket.DeploymentVariables, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListDeploymentVariables", opt)
 	ret0, _ := ret[0].(*bitbucket.DeploymentVariables)
@@ -1023,13 +1318,28 @@ func (m *Mockrepository) ListDeploymentVariables(opt bitbucket.RepositoryDeploym
 }
 
 // ListDeploymentVariables indicates an expected call of ListDeploymentVariables.
-func (mr *MockrepositoryMockRecorder) ListDeploymentVariables(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) ListDeploymentVariables(opt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploymentVariables", reflect.TypeOf((*MockRepositoryService)(nil).ListDeploymentVariables), opt)
+}
+
+// ListEffectiveDefaultReviewers mocks base method.
+func (m *MockRepositoryService) ListEffectiveDefaultReviewers(ro *bitbucket.RepositoryOptions) (*bitbucket.EffectiveDefaultReviewers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEffectiveDefaultReviewers", ro)
+	ret0, _ := ret[0].(*bitbucket.EffectiveDefaultReviewers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEffectiveDefaultReviewers indicates an expected call of ListEffectiveDefaultReviewers.
+func (mr *MockRepositoryServiceMockRecorder) ListEffectiveDefaultReviewers(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploymentVariables", reflect.TypeOf((*Mockrepository)(nil).ListDeploymentVariables), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEffectiveDefaultReviewers", reflect.TypeOf((*MockRepositoryService)(nil).ListEffectiveDefaultReviewers), ro)
 }
 
 // ListEnvironments mocks base method.
-func (m *Mockrepository) ListEnvironments(opt bitbucket.RepositoryEnvironmentsOptions) (*bitbucket.Environments, error) {
+func (m *MockRepositoryService) ListEnvironments(opt *bitbucket.RepositoryEnvironmentsOptions) (*bitbucket.Environments, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListEnvironments", opt)
 	ret0, _ := ret[0].(*bitbucket.Environments)
@@ -1038,43 +1348,58 @@ func (m *Mockrepository) ListEnvironments(opt bitbucket.RepositoryEnvironmentsOp
 }
 
 // ListEnvironments indicates an expected call of ListEnvironments.
-func (mr *MockrepositoryMockRecorder) ListEnvironments(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) ListEnvironments(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironments", reflect.TypeOf((*Mockrepository)(nil).ListEnvironments), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironments", reflect.TypeOf((*MockRepositoryService)(nil).ListEnvironments), opt)
 }
 
 // ListFiles mocks base method.
-func (m *Mockrepository) ListFiles(opt bitbucket.RepositoryFilesOptions) (*[]bitbucket.RepositoryFile, error) {
+func (m *MockRepositoryService) ListFiles(ro *bitbucket.RepositoryFilesOptions) ([]bitbucket.RepositoryFile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFiles", opt)
-	ret0, _ := ret[0].(*[]bitbucket.RepositoryFile)
+	ret := m.ctrl.Call(m, "ListFiles", ro)
+	ret0, _ := ret[0].([]bitbucket.RepositoryFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListFiles indicates an expected call of ListFiles.
-func (mr *MockrepositoryMockRecorder) ListFiles(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) ListFiles(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*Mockrepository)(nil).ListFiles), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockRepositoryService)(nil).ListFiles), ro)
 }
 
 // ListForks mocks base method.
-func (m *Mockrepository) ListForks(opt bitbucket.RepositoryOptions) (any, error) {
+func (m *MockRepositoryService) ListForks(ro *bitbucket.RepositoryOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListForks", opt)
+	ret := m.ctrl.Call(m, "ListForks", ro)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListForks indicates an expected call of ListForks.
-func (mr *MockrepositoryMockRecorder) ListForks(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) ListForks(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForks", reflect.TypeOf((*MockRepositoryService)(nil).ListForks), ro)
+}
+
+// ListGroupPermissions mocks base method.
+func (m *MockRepositoryService) ListGroupPermissions(ro *bitbucket.RepositoryOptions) (*bitbucket.GroupPermissions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGroupPermissions", ro)
+	ret0, _ := ret[0].(*bitbucket.GroupPermissions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGroupPermissions indicates an expected call of ListGroupPermissions.
+func (mr *MockRepositoryServiceMockRecorder) ListGroupPermissions(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForks", reflect.TypeOf((*Mockrepository)(nil).ListForks), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGroupPermissions", reflect.TypeOf((*MockRepositoryService)(nil).ListGroupPermissions), ro)
 }
 
 // ListPipelineVariables mocks base method.
-func (m *Mockrepository) ListPipelineVariables(opt bitbucket.RepositoryPipelineVariablesOptions) (*bitbucket.PipelineVariables, error) {
+func (m *MockRepositoryService) ListPipelineVariables(opt *bitbucket.RepositoryPipelineVariablesOptions) (*bitbucket.PipelineVariables, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListPipelineVariables", opt)
 	ret0, _ := ret[0].(*bitbucket.PipelineVariables)
@@ -1083,508 +1408,1574 @@ func (m *Mockrepository) ListPipelineVariables(opt bitbucket.RepositoryPipelineV
 }
 
 // ListPipelineVariables indicates an expected call of ListPipelineVariables.
-func (mr *MockrepositoryMockRecorder) ListPipelineVariables(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) ListPipelineVariables(opt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPipelineVariables", reflect.TypeOf((*MockRepositoryService)(nil).ListPipelineVariables), opt)
+}
+
+// ListRefs mocks base method.
+func (m *MockRepositoryService) ListRefs(rbo *bitbucket.RepositoryRefOptions) (*bitbucket.RepositoryRefs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefs", rbo)
+	ret0, _ := ret[0].(*bitbucket.RepositoryRefs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefs indicates an expected call of ListRefs.
+func (mr *MockRepositoryServiceMockRecorder) ListRefs(rbo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefs", reflect.TypeOf((*MockRepositoryService)(nil).ListRefs), rbo)
+}
+
+// ListTags mocks base method.
+func (m *MockRepositoryService) ListTags(rbo *bitbucket.RepositoryTagOptions) (*bitbucket.RepositoryTags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", rbo)
+	ret0, _ := ret[0].(*bitbucket.RepositoryTags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRepositoryServiceMockRecorder) ListTags(rbo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRepositoryService)(nil).ListTags), rbo)
+}
+
+// ListUserPermissions mocks base method.
+func (m *MockRepositoryService) ListUserPermissions(ro *bitbucket.RepositoryOptions) (*bitbucket.UserPermissions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserPermissions", ro)
+	ret0, _ := ret[0].(*bitbucket.UserPermissions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserPermissions indicates an expected call of ListUserPermissions.
+func (mr *MockRepositoryServiceMockRecorder) ListUserPermissions(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPipelineVariables", reflect.TypeOf((*Mockrepository)(nil).ListPipelineVariables), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPermissions", reflect.TypeOf((*MockRepositoryService)(nil).ListUserPermissions), ro)
 }
 
 // ListWatchers mocks base method.
-func (m *Mockrepository) ListWatchers(opt bitbucket.RepositoryOptions) (any, error) {
+func (m *MockRepositoryService) ListWatchers(ro *bitbucket.RepositoryOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWatchers", opt)
+	ret := m.ctrl.Call(m, "ListWatchers", ro)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListWatchers indicates an expected call of ListWatchers.
-func (mr *MockrepositoryMockRecorder) ListWatchers(opt any) *gomock.Call {
+func (mr *MockRepositoryServiceMockRecorder) ListWatchers(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWatchers", reflect.TypeOf((*Mockrepository)(nil).ListWatchers), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWatchers", reflect.TypeOf((*MockRepositoryService)(nil).ListWatchers), ro)
 }
 
-// UpdateDeploymentVariable mocks base method.
-func (m *Mockrepository) UpdateDeploymentVariable(opt bitbucket.RepositoryDeploymentVariableOptions) (*bitbucket.DeploymentVariable, error) {
+// MergeBase mocks base method.
+func (m *MockRepositoryService) MergeBase(co *bitbucket.CompareOptions) (*bitbucket.Commit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDeploymentVariable", opt)
-	ret0, _ := ret[0].(*bitbucket.DeploymentVariable)
+	ret := m.ctrl.Call(m, "MergeBase", co)
+	ret0, _ := ret[0].(*bitbucket.Commit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateDeploymentVariable indicates an expected call of UpdateDeploymentVariable.
-func (mr *MockrepositoryMockRecorder) UpdateDeploymentVariable(opt any) *gomock.Call {
+// MergeBase indicates an expected call of MergeBase.
+func (mr *MockRepositoryServiceMockRecorder) MergeBase(co any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeploymentVariable", reflect.TypeOf((*Mockrepository)(nil).UpdateDeploymentVariable), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeBase", reflect.TypeOf((*MockRepositoryService)(nil).MergeBase), co)
 }
 
-// UpdatePipelineBuildNumber mocks base method.
-func (m *Mockrepository) UpdatePipelineBuildNumber(opt bitbucket.RepositoryPipelineBuildNumberOptions) (*bitbucket.PipelineBuildNumber, error) {
+// NewCommit mocks base method.
+func (m *MockRepositoryService) NewCommit(owner, repoSlug string) *bitbucket.CommitBuilder {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePipelineBuildNumber", opt)
-	ret0, _ := ret[0].(*bitbucket.PipelineBuildNumber)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "NewCommit", owner, repoSlug)
+	ret0, _ := ret[0].(*bitbucket.CommitBuilder)
+	return ret0
 }
 
-// UpdatePipelineBuildNumber indicates an expected call of UpdatePipelineBuildNumber.
-func (mr *MockrepositoryMockRecorder) UpdatePipelineBuildNumber(opt any) *gomock.Call {
+// NewCommit indicates an expected call of NewCommit.
+func (mr *MockRepositoryServiceMockRecorder) NewCommit(owner, repoSlug any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineBuildNumber", reflect.TypeOf((*Mockrepository)(nil).UpdatePipelineBuildNumber), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewCommit", reflect.TypeOf((*MockRepositoryService)(nil).NewCommit), owner, repoSlug)
 }
 
-// UpdatePipelineConfig mocks base method.
-func (m *Mockrepository) UpdatePipelineConfig(opt bitbucket.RepositoryPipelineOptions) (*bitbucket.Pipeline, error) {
+// ReleaseNotes mocks base method.
+func (m *MockRepositoryService) ReleaseNotes(rno *bitbucket.ReleaseNotesOptions) (*bitbucket.ReleaseNotes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePipelineConfig", opt)
-	ret0, _ := ret[0].(*bitbucket.Pipeline)
+	ret := m.ctrl.Call(m, "ReleaseNotes", rno)
+	ret0, _ := ret[0].(*bitbucket.ReleaseNotes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdatePipelineConfig indicates an expected call of UpdatePipelineConfig.
-func (mr *MockrepositoryMockRecorder) UpdatePipelineConfig(opt any) *gomock.Call {
+// ReleaseNotes indicates an expected call of ReleaseNotes.
+func (mr *MockRepositoryServiceMockRecorder) ReleaseNotes(rno any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineConfig", reflect.TypeOf((*Mockrepository)(nil).UpdatePipelineConfig), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseNotes", reflect.TypeOf((*MockRepositoryService)(nil).ReleaseNotes), rno)
 }
 
-// UpdatePipelineVariable mocks base method.
-func (m *Mockrepository) UpdatePipelineVariable(opt bitbucket.RepositoryPipelineVariableOptions) (*bitbucket.PipelineVariable, error) {
+// SetGroupPermissions mocks base method.
+func (m *MockRepositoryService) SetGroupPermissions(rgo *bitbucket.RepositoryGroupPermissionsOptions) (*bitbucket.GroupPermission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePipelineVariable", opt)
-	ret0, _ := ret[0].(*bitbucket.PipelineVariable)
+	ret := m.ctrl.Call(m, "SetGroupPermissions", rgo)
+	ret0, _ := ret[0].(*bitbucket.GroupPermission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdatePipelineVariable indicates an expected call of UpdatePipelineVariable.
-func (mr *MockrepositoryMockRecorder) UpdatePipelineVariable(opt any) *gomock.Call {
+// SetGroupPermissions indicates an expected call of SetGroupPermissions.
+func (mr *MockRepositoryServiceMockRecorder) SetGroupPermissions(rgo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineVariable", reflect.TypeOf((*Mockrepository)(nil).UpdatePipelineVariable), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGroupPermissions", reflect.TypeOf((*MockRepositoryService)(nil).SetGroupPermissions), rgo)
 }
 
-// Mockrepositories is a mock of repositories interface.
-type Mockrepositories struct {
-	ctrl     *gomock.Controller
-	recorder *MockrepositoriesMockRecorder
-	isgomock struct{}
+// SetUserPermissions mocks base method.
+func (m *MockRepositoryService) SetUserPermissions(rgo *bitbucket.RepositoryUserPermissionsOptions) (*bitbucket.UserPermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserPermissions", rgo)
+	ret0, _ := ret[0].(*bitbucket.UserPermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockrepositoriesMockRecorder is the mock recorder for Mockrepositories.
-type MockrepositoriesMockRecorder struct {
-	mock *Mockrepositories
+// SetUserPermissions indicates an expected call of SetUserPermissions.
+func (mr *MockRepositoryServiceMockRecorder) SetUserPermissions(rgo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserPermissions", reflect.TypeOf((*MockRepositoryService)(nil).SetUserPermissions), rgo)
 }
 
-// NewMockrepositories creates a new mock instance.
-func NewMockrepositories(ctrl *gomock.Controller) *Mockrepositories {
-	mock := &Mockrepositories{ctrl: ctrl}
-	mock.recorder = &MockrepositoriesMockRecorder{mock}
-	return mock
+// Update mocks base method.
+func (m *MockRepositoryService) Update(ro *bitbucket.RepositoryOptions) (*bitbucket.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ro)
+	ret0, _ := ret[0].(*bitbucket.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryServiceMockRecorder) Update(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepositoryService)(nil).Update), ro)
+}
+
+// UpdateDeploymentVariable mocks base method.
+func (m *MockRepositoryService) UpdateDeploymentVariable(opt *bitbucket.RepositoryDeploymentVariableOptions) (*bitbucket.DeploymentVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeploymentVariable", opt)
+	ret0, _ := ret[0].(*bitbucket.DeploymentVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDeploymentVariable indicates an expected call of UpdateDeploymentVariable.
+func (mr *MockRepositoryServiceMockRecorder) UpdateDeploymentVariable(opt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeploymentVariable", reflect.TypeOf((*MockRepositoryService)(nil).UpdateDeploymentVariable), opt)
+}
+
+// UpdatePipelineBuildNumber mocks base method.
+func (m *MockRepositoryService) UpdatePipelineBuildNumber(rpbno *bitbucket.RepositoryPipelineBuildNumberOptions) (*bitbucket.PipelineBuildNumber, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePipelineBuildNumber", rpbno)
+	ret0, _ := ret[0].(*bitbucket.PipelineBuildNumber)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePipelineBuildNumber indicates an expected call of UpdatePipelineBuildNumber.
+func (mr *MockRepositoryServiceMockRecorder) UpdatePipelineBuildNumber(rpbno any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineBuildNumber", reflect.TypeOf((*MockRepositoryService)(nil).UpdatePipelineBuildNumber), rpbno)
+}
+
+// UpdatePipelineConfig mocks base method.
+func (m *MockRepositoryService) UpdatePipelineConfig(rpo *bitbucket.RepositoryPipelineOptions) (*bitbucket.Pipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePipelineConfig", rpo)
+	ret0, _ := ret[0].(*bitbucket.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePipelineConfig indicates an expected call of UpdatePipelineConfig.
+func (mr *MockRepositoryServiceMockRecorder) UpdatePipelineConfig(rpo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineConfig", reflect.TypeOf((*MockRepositoryService)(nil).UpdatePipelineConfig), rpo)
+}
+
+// UpdatePipelineVariable mocks base method.
+func (m *MockRepositoryService) UpdatePipelineVariable(opt *bitbucket.RepositoryPipelineVariableOptions) (*bitbucket.PipelineVariable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePipelineVariable", opt)
+	ret0, _ := ret[0].(*bitbucket.PipelineVariable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePipelineVariable indicates an expected call of UpdatePipelineVariable.
+func (mr *MockRepositoryServiceMockRecorder) UpdatePipelineVariable(opt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineVariable", reflect.TypeOf((*MockRepositoryService)(nil).UpdatePipelineVariable), opt)
+}
+
+// WalkTree mocks base method.
+func (m *MockRepositoryService) WalkTree(ctx context.Context, wo *bitbucket.WalkTreeOptions, fn bitbucket.WalkTreeFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalkTree", ctx, wo, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalkTree indicates an expected call of WalkTree.
+func (mr *MockRepositoryServiceMockRecorder) WalkTree(ctx, wo, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkTree", reflect.TypeOf((*MockRepositoryService)(nil).WalkTree), ctx, wo, fn)
+}
+
+// WriteFileBlob mocks base method.
+func (m *MockRepositoryService) WriteFileBlob(ro *bitbucket.RepositoryBlobWriteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteFileBlob", ro)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteFileBlob indicates an expected call of WriteFileBlob.
+func (mr *MockRepositoryServiceMockRecorder) WriteFileBlob(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteFileBlob", reflect.TypeOf((*MockRepositoryService)(nil).WriteFileBlob), ro)
+}
+
+// MockPullRequestsService is a mock of PullRequestsService interface.
+type MockPullRequestsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPullRequestsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPullRequestsServiceMockRecorder is the mock recorder for MockPullRequestsService.
+type MockPullRequestsServiceMockRecorder struct {
+	mock *MockPullRequestsService
+}
+
+// NewMockPullRequestsService creates a new mock instance.
+func NewMockPullRequestsService(ctrl *gomock.Controller) *MockPullRequestsService {
+	mock := &MockPullRequestsService{ctrl: ctrl}
+	mock.recorder = &MockPullRequestsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPullRequestsService) EXPECT() *MockPullRequestsServiceMockRecorder {
+	return m.recorder
+}
+
+// Activities mocks base method.
+func (m *MockPullRequestsService) Activities(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activities", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Activities indicates an expected call of Activities.
+func (mr *MockPullRequestsServiceMockRecorder) Activities(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activities", reflect.TypeOf((*MockPullRequestsService)(nil).Activities), po)
+}
+
+// Activity mocks base method.
+func (m *MockPullRequestsService) Activity(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activity", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Activity indicates an expected call of Activity.
+func (mr *MockPullRequestsServiceMockRecorder) Activity(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activity", reflect.TypeOf((*MockPullRequestsService)(nil).Activity), po)
+}
+
+// AddComment mocks base method.
+func (m *MockPullRequestsService) AddComment(co *bitbucket.PullRequestCommentOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", co)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockPullRequestsServiceMockRecorder) AddComment(co any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockPullRequestsService)(nil).AddComment), co)
+}
+
+// Approve mocks base method.
+func (m *MockPullRequestsService) Approve(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockPullRequestsServiceMockRecorder) Approve(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockPullRequestsService)(nil).Approve), po)
+}
+
+// Commits mocks base method.
+func (m *MockPullRequestsService) Commits(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commits", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Commits indicates an expected call of Commits.
+func (mr *MockPullRequestsServiceMockRecorder) Commits(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commits", reflect.TypeOf((*MockPullRequestsService)(nil).Commits), po)
+}
+
+// Create mocks base method.
+func (m *MockPullRequestsService) Create(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPullRequestsServiceMockRecorder) Create(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPullRequestsService)(nil).Create), po)
+}
+
+// Decline mocks base method.
+func (m *MockPullRequestsService) Decline(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockPullRequestsServiceMockRecorder) Decline(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockPullRequestsService)(nil).Decline), po)
+}
+
+// DeleteComment mocks base method.
+func (m *MockPullRequestsService) DeleteComment(co *bitbucket.PullRequestCommentOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", co)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockPullRequestsServiceMockRecorder) DeleteComment(co any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockPullRequestsService)(nil).DeleteComment), co)
+}
+
+// Diff mocks base method.
+func (m *MockPullRequestsService) Diff(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockPullRequestsServiceMockRecorder) Diff(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockPullRequestsService)(nil).Diff), po)
+}
+
+// Get mocks base method.
+func (m *MockPullRequestsService) Get(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPullRequestsServiceMockRecorder) Get(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPullRequestsService)(nil).Get), po)
+}
+
+// GetByCommit mocks base method.
+func (m *MockPullRequestsService) GetByCommit(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCommit", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCommit indicates an expected call of GetByCommit.
+func (mr *MockPullRequestsServiceMockRecorder) GetByCommit(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCommit", reflect.TypeOf((*MockPullRequestsService)(nil).GetByCommit), po)
+}
+
+// GetComment mocks base method.
+func (m *MockPullRequestsService) GetComment(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockPullRequestsServiceMockRecorder) GetComment(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockPullRequestsService)(nil).GetComment), po)
+}
+
+// GetComments mocks base method.
+func (m *MockPullRequestsService) GetComments(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockPullRequestsServiceMockRecorder) GetComments(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockPullRequestsService)(nil).GetComments), po)
+}
+
+// GetCommits mocks base method.
+func (m *MockPullRequestsService) GetCommits(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommits", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommits indicates an expected call of GetCommits.
+func (mr *MockPullRequestsServiceMockRecorder) GetCommits(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommits", reflect.TypeOf((*MockPullRequestsService)(nil).GetCommits), po)
+}
+
+// Gets mocks base method.
+func (m *MockPullRequestsService) Gets(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gets", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Gets indicates an expected call of Gets.
+func (mr *MockPullRequestsServiceMockRecorder) Gets(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockPullRequestsService)(nil).Gets), po)
+}
+
+// List mocks base method.
+func (m *MockPullRequestsService) List(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPullRequestsServiceMockRecorder) List(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPullRequestsService)(nil).List), po)
+}
+
+// Merge mocks base method.
+func (m *MockPullRequestsService) Merge(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockPullRequestsServiceMockRecorder) Merge(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockPullRequestsService)(nil).Merge), po)
+}
+
+// Patch mocks base method.
+func (m *MockPullRequestsService) Patch(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockPullRequestsServiceMockRecorder) Patch(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockPullRequestsService)(nil).Patch), po)
+}
+
+// RequestChanges mocks base method.
+func (m *MockPullRequestsService) RequestChanges(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestChanges", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestChanges indicates an expected call of RequestChanges.
+func (mr *MockPullRequestsServiceMockRecorder) RequestChanges(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestChanges", reflect.TypeOf((*MockPullRequestsService)(nil).RequestChanges), po)
+}
+
+// Statuses mocks base method.
+func (m *MockPullRequestsService) Statuses(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statuses", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Statuses indicates an expected call of Statuses.
+func (mr *MockPullRequestsServiceMockRecorder) Statuses(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statuses", reflect.TypeOf((*MockPullRequestsService)(nil).Statuses), po)
+}
+
+// UnApprove mocks base method.
+func (m *MockPullRequestsService) UnApprove(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnApprove", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnApprove indicates an expected call of UnApprove.
+func (mr *MockPullRequestsServiceMockRecorder) UnApprove(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnApprove", reflect.TypeOf((*MockPullRequestsService)(nil).UnApprove), po)
+}
+
+// UnRequestChanges mocks base method.
+func (m *MockPullRequestsService) UnRequestChanges(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnRequestChanges", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnRequestChanges indicates an expected call of UnRequestChanges.
+func (mr *MockPullRequestsServiceMockRecorder) UnRequestChanges(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRequestChanges", reflect.TypeOf((*MockPullRequestsService)(nil).UnRequestChanges), po)
+}
+
+// Update mocks base method.
+func (m *MockPullRequestsService) Update(po *bitbucket.PullRequestsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPullRequestsServiceMockRecorder) Update(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPullRequestsService)(nil).Update), po)
+}
+
+// UpdateComment mocks base method.
+func (m *MockPullRequestsService) UpdateComment(co *bitbucket.PullRequestCommentOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", co)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockPullRequestsServiceMockRecorder) UpdateComment(co any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockPullRequestsService)(nil).UpdateComment), co)
+}
+
+// MockIssuesService is a mock of IssuesService interface.
+type MockIssuesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIssuesServiceMockRecorder is the mock recorder for MockIssuesService.
+type MockIssuesServiceMockRecorder struct {
+	mock *MockIssuesService
+}
+
+// NewMockIssuesService creates a new mock instance.
+func NewMockIssuesService(ctrl *gomock.Controller) *MockIssuesService {
+	mock := &MockIssuesService{ctrl: ctrl}
+	mock.recorder = &MockIssuesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssuesService) EXPECT() *MockIssuesServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIssuesService) Create(arg0 *bitbucket.IssuesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIssuesServiceMockRecorder) Create(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIssuesService)(nil).Create), arg0)
+}
+
+// CreateChange mocks base method.
+func (m *MockIssuesService) CreateChange(ico *bitbucket.IssueChangesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChange", ico)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChange indicates an expected call of CreateChange.
+func (mr *MockIssuesServiceMockRecorder) CreateChange(ico any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChange", reflect.TypeOf((*MockIssuesService)(nil).CreateChange), ico)
+}
+
+// CreateComment mocks base method.
+func (m *MockIssuesService) CreateComment(ico *bitbucket.IssueCommentsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ico)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockIssuesServiceMockRecorder) CreateComment(ico any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockIssuesService)(nil).CreateComment), ico)
+}
+
+// Delete mocks base method.
+func (m *MockIssuesService) Delete(arg0 *bitbucket.IssuesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIssuesServiceMockRecorder) Delete(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIssuesService)(nil).Delete), arg0)
+}
+
+// DeleteComment mocks base method.
+func (m *MockIssuesService) DeleteComment(ico *bitbucket.IssueCommentsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ico)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockIssuesServiceMockRecorder) DeleteComment(ico any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockIssuesService)(nil).DeleteComment), ico)
+}
+
+// DeleteVote mocks base method.
+func (m *MockIssuesService) DeleteVote(arg0 *bitbucket.IssuesOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVote", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVote indicates an expected call of DeleteVote.
+func (mr *MockIssuesServiceMockRecorder) DeleteVote(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVote", reflect.TypeOf((*MockIssuesService)(nil).DeleteVote), arg0)
+}
+
+// DeleteWatch mocks base method.
+func (m *MockIssuesService) DeleteWatch(arg0 *bitbucket.IssuesOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWatch indicates an expected call of DeleteWatch.
+func (mr *MockIssuesServiceMockRecorder) DeleteWatch(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWatch", reflect.TypeOf((*MockIssuesService)(nil).DeleteWatch), arg0)
+}
+
+// Get mocks base method.
+func (m *MockIssuesService) Get(arg0 *bitbucket.IssuesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIssuesServiceMockRecorder) Get(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIssuesService)(nil).Get), arg0)
+}
+
+// GetChange mocks base method.
+func (m *MockIssuesService) GetChange(ico *bitbucket.IssueChangesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChange", ico)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChange indicates an expected call of GetChange.
+func (mr *MockIssuesServiceMockRecorder) GetChange(ico any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChange", reflect.TypeOf((*MockIssuesService)(nil).GetChange), ico)
+}
+
+// GetChanges mocks base method.
+func (m *MockIssuesService) GetChanges(ico *bitbucket.IssueChangesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", ico)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockIssuesServiceMockRecorder) GetChanges(ico any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockIssuesService)(nil).GetChanges), ico)
+}
+
+// GetComment mocks base method.
+func (m *MockIssuesService) GetComment(ico *bitbucket.IssueCommentsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", ico)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockIssuesServiceMockRecorder) GetComment(ico any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockIssuesService)(nil).GetComment), ico)
+}
+
+// GetComments mocks base method.
+func (m *MockIssuesService) GetComments(ico *bitbucket.IssueCommentsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ico)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockIssuesServiceMockRecorder) GetComments(ico any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockIssuesService)(nil).GetComments), ico)
+}
+
+// GetVote mocks base method.
+func (m *MockIssuesService) GetVote(arg0 *bitbucket.IssuesOptions) (bool, any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVote", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(any)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetVote indicates an expected call of GetVote.
+func (mr *MockIssuesServiceMockRecorder) GetVote(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVote", reflect.TypeOf((*MockIssuesService)(nil).GetVote), arg0)
+}
+
+// GetWatch mocks base method.
+func (m *MockIssuesService) GetWatch(arg0 *bitbucket.IssuesOptions) (bool, any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWatch", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(any)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWatch indicates an expected call of GetWatch.
+func (mr *MockIssuesServiceMockRecorder) GetWatch(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWatch", reflect.TypeOf((*MockIssuesService)(nil).GetWatch), arg0)
+}
+
+// Gets mocks base method.
+func (m *MockIssuesService) Gets(arg0 *bitbucket.IssuesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gets", arg0)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Gets indicates an expected call of Gets.
+func (mr *MockIssuesServiceMockRecorder) Gets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockIssuesService)(nil).Gets), arg0)
+}
+
+// PutVote mocks base method.
+func (m *MockIssuesService) PutVote(arg0 *bitbucket.IssuesOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutVote", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutVote indicates an expected call of PutVote.
+func (mr *MockIssuesServiceMockRecorder) PutVote(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutVote", reflect.TypeOf((*MockIssuesService)(nil).PutVote), arg0)
+}
+
+// PutWatch mocks base method.
+func (m *MockIssuesService) PutWatch(arg0 *bitbucket.IssuesOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutWatch", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutWatch indicates an expected call of PutWatch.
+func (mr *MockIssuesServiceMockRecorder) PutWatch(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutWatch", reflect.TypeOf((*MockIssuesService)(nil).PutWatch), arg0)
+}
+
+// Update mocks base method.
+func (m *MockIssuesService) Update(arg0 *bitbucket.IssuesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIssuesServiceMockRecorder) Update(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIssuesService)(nil).Update), arg0)
+}
+
+// UpdateComment mocks base method.
+func (m *MockIssuesService) UpdateComment(ico *bitbucket.IssueCommentsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ico)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockIssuesServiceMockRecorder) UpdateComment(ico any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockIssuesService)(nil).UpdateComment), ico)
+}
+
+// MockPipelinesService is a mock of PipelinesService interface.
+type MockPipelinesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPipelinesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPipelinesServiceMockRecorder is the mock recorder for MockPipelinesService.
+type MockPipelinesServiceMockRecorder struct {
+	mock *MockPipelinesService
+}
+
+// NewMockPipelinesService creates a new mock instance.
+func NewMockPipelinesService(ctrl *gomock.Controller) *MockPipelinesService {
+	mock := &MockPipelinesService{ctrl: ctrl}
+	mock.recorder = &MockPipelinesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPipelinesService) EXPECT() *MockPipelinesServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPipelinesService) Get(po *bitbucket.PipelinesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPipelinesServiceMockRecorder) Get(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPipelinesService)(nil).Get), po)
+}
+
+// GetLog mocks base method.
+func (m *MockPipelinesService) GetLog(po *bitbucket.PipelinesOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLog", po)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLog indicates an expected call of GetLog.
+func (mr *MockPipelinesServiceMockRecorder) GetLog(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLog", reflect.TypeOf((*MockPipelinesService)(nil).GetLog), po)
+}
+
+// GetStep mocks base method.
+func (m *MockPipelinesService) GetStep(po *bitbucket.PipelinesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStep", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStep indicates an expected call of GetStep.
+func (mr *MockPipelinesServiceMockRecorder) GetStep(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStep", reflect.TypeOf((*MockPipelinesService)(nil).GetStep), po)
+}
+
+// List mocks base method.
+func (m *MockPipelinesService) List(po *bitbucket.PipelinesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPipelinesServiceMockRecorder) List(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPipelinesService)(nil).List), po)
+}
+
+// ListSteps mocks base method.
+func (m *MockPipelinesService) ListSteps(po *bitbucket.PipelinesOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSteps", po)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSteps indicates an expected call of ListSteps.
+func (mr *MockPipelinesServiceMockRecorder) ListSteps(po any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSteps", reflect.TypeOf((*MockPipelinesService)(nil).ListSteps), po)
+}
+
+// MockCommitsService is a mock of CommitsService interface.
+type MockCommitsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommitsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockCommitsServiceMockRecorder is the mock recorder for MockCommitsService.
+type MockCommitsServiceMockRecorder struct {
+	mock *MockCommitsService
+}
+
+// NewMockCommitsService creates a new mock instance.
+func NewMockCommitsService(ctrl *gomock.Controller) *MockCommitsService {
+	mock := &MockCommitsService{ctrl: ctrl}
+	mock.recorder = &MockCommitsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommitsService) EXPECT() *MockCommitsServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCommitStatus mocks base method.
+func (m *MockCommitsService) CreateCommitStatus(cmo *bitbucket.CommitsOptions, cso *bitbucket.CommitStatusOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCommitStatus", cmo, cso)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCommitStatus indicates an expected call of CreateCommitStatus.
+func (mr *MockCommitsServiceMockRecorder) CreateCommitStatus(cmo, cso any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommitStatus", reflect.TypeOf((*MockCommitsService)(nil).CreateCommitStatus), cmo, cso)
+}
+
+// GetCommit mocks base method.
+func (m *MockCommitsService) GetCommit(cmo *bitbucket.CommitsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommit", cmo)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommit indicates an expected call of GetCommit.
+func (mr *MockCommitsServiceMockRecorder) GetCommit(cmo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommit", reflect.TypeOf((*MockCommitsService)(nil).GetCommit), cmo)
+}
+
+// GetCommitComment mocks base method.
+func (m *MockCommitsService) GetCommitComment(cmo *bitbucket.CommitsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitComment", cmo)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitComment indicates an expected call of GetCommitComment.
+func (mr *MockCommitsServiceMockRecorder) GetCommitComment(cmo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitComment", reflect.TypeOf((*MockCommitsService)(nil).GetCommitComment), cmo)
+}
+
+// GetCommitComments mocks base method.
+func (m *MockCommitsService) GetCommitComments(cmo *bitbucket.CommitsOptions) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitComments", cmo)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommitComments indicates an expected call of GetCommitComments.
+func (mr *MockCommitsServiceMockRecorder) GetCommitComments(cmo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitComments", reflect.TypeOf((*MockCommitsService)(nil).GetCommitComments), cmo)
+}
+
+// GetCommitStatus mocks base method.
+func (m *MockCommitsService) GetCommitStatus(cmo *bitbucket.CommitsOptions, commitStatusKey string) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommitStatus", cmo, commitStatusKey)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockrepositories) EXPECT() *MockrepositoriesMockRecorder {
-	return m.recorder
+// GetCommitStatus indicates an expected call of GetCommitStatus.
+func (mr *MockCommitsServiceMockRecorder) GetCommitStatus(cmo, commitStatusKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitStatus", reflect.TypeOf((*MockCommitsService)(nil).GetCommitStatus), cmo, commitStatusKey)
 }
 
-// ListForAccount mocks base method.
-func (m *Mockrepositories) ListForAccount(opt bitbucket.RepositoriesOptions) (any, error) {
+// GetCommitStatuses mocks base method.
+func (m *MockCommitsService) GetCommitStatuses(cmo *bitbucket.CommitsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListForAccount", opt)
+	ret := m.ctrl.Call(m, "GetCommitStatuses", cmo)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListForAccount indicates an expected call of ListForAccount.
-func (mr *MockrepositoriesMockRecorder) ListForAccount(opt any) *gomock.Call {
+// GetCommitStatuses indicates an expected call of GetCommitStatuses.
+func (mr *MockCommitsServiceMockRecorder) GetCommitStatuses(cmo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForAccount", reflect.TypeOf((*Mockrepositories)(nil).ListForAccount), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitStatuses", reflect.TypeOf((*MockCommitsService)(nil).GetCommitStatuses), cmo)
 }
 
-// ListForTeam mocks base method.
-func (m *Mockrepositories) ListForTeam(opt bitbucket.RepositoriesOptions) (any, error) {
+// GetCommits mocks base method.
+func (m *MockCommitsService) GetCommits(cmo *bitbucket.CommitsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListForTeam", opt)
+	ret := m.ctrl.Call(m, "GetCommits", cmo)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListForTeam indicates an expected call of ListForTeam.
-func (mr *MockrepositoriesMockRecorder) ListForTeam(opt any) *gomock.Call {
+// GetCommits indicates an expected call of GetCommits.
+func (mr *MockCommitsServiceMockRecorder) GetCommits(cmo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListForTeam", reflect.TypeOf((*Mockrepositories)(nil).ListForTeam), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommits", reflect.TypeOf((*MockCommitsService)(nil).GetCommits), cmo)
 }
 
-// ListProject mocks base method.
-func (m *Mockrepositories) ListProject(opt bitbucket.RepositoriesOptions) (any, error) {
+// GiveApprove mocks base method.
+func (m *MockCommitsService) GiveApprove(cmo *bitbucket.CommitsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProject", opt)
+	ret := m.ctrl.Call(m, "GiveApprove", cmo)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListProject indicates an expected call of ListProject.
-func (mr *MockrepositoriesMockRecorder) ListProject(opt any) *gomock.Call {
+// GiveApprove indicates an expected call of GiveApprove.
+func (mr *MockCommitsServiceMockRecorder) GiveApprove(cmo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProject", reflect.TypeOf((*Mockrepositories)(nil).ListProject), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiveApprove", reflect.TypeOf((*MockCommitsService)(nil).GiveApprove), cmo)
 }
 
-// ListPublic mocks base method.
-func (m *Mockrepositories) ListPublic() (any, error) {
+// RemoveApprove mocks base method.
+func (m *MockCommitsService) RemoveApprove(cmo *bitbucket.CommitsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPublic")
+	ret := m.ctrl.Call(m, "RemoveApprove", cmo)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPublic indicates an expected call of ListPublic.
-func (mr *MockrepositoriesMockRecorder) ListPublic() *gomock.Call {
+// RemoveApprove indicates an expected call of RemoveApprove.
+func (mr *MockCommitsServiceMockRecorder) RemoveApprove(cmo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublic", reflect.TypeOf((*Mockrepositories)(nil).ListPublic))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveApprove", reflect.TypeOf((*MockCommitsService)(nil).RemoveApprove), cmo)
 }
 
-// Mockcommits is a mock of commits interface.
-type Mockcommits struct {
+// MockBranchRestrictionsService is a mock of BranchRestrictionsService interface.
+type MockBranchRestrictionsService struct {
 	ctrl     *gomock.Controller
-	recorder *MockcommitsMockRecorder
+	recorder *MockBranchRestrictionsServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockcommitsMockRecorder is the mock recorder for Mockcommits.
-type MockcommitsMockRecorder struct {
-	mock *Mockcommits
+// MockBranchRestrictionsServiceMockRecorder is the mock recorder for MockBranchRestrictionsService.
+type MockBranchRestrictionsServiceMockRecorder struct {
+	mock *MockBranchRestrictionsService
 }
 
-// NewMockcommits creates a new mock instance.
-func NewMockcommits(ctrl *gomock.Controller) *Mockcommits {
-	mock := &Mockcommits{ctrl: ctrl}
-	mock.recorder = &MockcommitsMockRecorder{mock}
+// NewMockBranchRestrictionsService creates a new mock instance.
+func NewMockBranchRestrictionsService(ctrl *gomock.Controller) *MockBranchRestrictionsService {
+	mock := &MockBranchRestrictionsService{ctrl: ctrl}
+	mock.recorder = &MockBranchRestrictionsServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockcommits) EXPECT() *MockcommitsMockRecorder {
+func (m *MockBranchRestrictionsService) EXPECT() *MockBranchRestrictionsServiceMockRecorder {
 	return m.recorder
 }
 
-// CreateCommitStatus mocks base method.
-func (m *Mockcommits) CreateCommitStatus(cmo bitbucket.CommitsOptions, cso bitbucket.CommitStatusOptions) (any, error) {
+// Create mocks base method.
+func (m *MockBranchRestrictionsService) Create(bo *bitbucket.BranchRestrictionsOptions) (*bitbucket.BranchRestrictions, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCommitStatus", cmo, cso)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Create", bo)
+	ret0, _ := ret[0].(*bitbucket.BranchRestrictions)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCommitStatus indicates an expected call of CreateCommitStatus.
-func (mr *MockcommitsMockRecorder) CreateCommitStatus(cmo, cso any) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockBranchRestrictionsServiceMockRecorder) Create(bo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommitStatus", reflect.TypeOf((*Mockcommits)(nil).CreateCommitStatus), cmo, cso)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBranchRestrictionsService)(nil).Create), bo)
 }
 
-// GetCommit mocks base method.
-func (m *Mockcommits) GetCommit(opt bitbucket.CommitsOptions) (any, error) {
+// Delete mocks base method.
+func (m *MockBranchRestrictionsService) Delete(bo *bitbucket.BranchRestrictionsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommit", opt)
+	ret := m.ctrl.Call(m, "Delete", bo)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommit indicates an expected call of GetCommit.
-func (mr *MockcommitsMockRecorder) GetCommit(opt any) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockBranchRestrictionsServiceMockRecorder) Delete(bo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommit", reflect.TypeOf((*Mockcommits)(nil).GetCommit), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBranchRestrictionsService)(nil).Delete), bo)
 }
 
-// GetCommitComment mocks base method.
-func (m *Mockcommits) GetCommitComment(opt bitbucket.CommitsOptions) (any, error) {
+// Get mocks base method.
+func (m *MockBranchRestrictionsService) Get(bo *bitbucket.BranchRestrictionsOptions) (*bitbucket.BranchRestrictions, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommitComment", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Get", bo)
+	ret0, _ := ret[0].(*bitbucket.BranchRestrictions)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommitComment indicates an expected call of GetCommitComment.
-func (mr *MockcommitsMockRecorder) GetCommitComment(opt any) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockBranchRestrictionsServiceMockRecorder) Get(bo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitComment", reflect.TypeOf((*Mockcommits)(nil).GetCommitComment), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBranchRestrictionsService)(nil).Get), bo)
 }
 
-// GetCommitComments mocks base method.
-func (m *Mockcommits) GetCommitComments(opt bitbucket.CommitsOptions) (any, error) {
+// Gets mocks base method.
+func (m *MockBranchRestrictionsService) Gets(bo *bitbucket.BranchRestrictionsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommitComments", opt)
+	ret := m.ctrl.Call(m, "Gets", bo)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommitComments indicates an expected call of GetCommitComments.
-func (mr *MockcommitsMockRecorder) GetCommitComments(opt any) *gomock.Call {
+// Gets indicates an expected call of Gets.
+func (mr *MockBranchRestrictionsServiceMockRecorder) Gets(bo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitComments", reflect.TypeOf((*Mockcommits)(nil).GetCommitComments), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockBranchRestrictionsService)(nil).Gets), bo)
 }
 
-// GetCommitStatus mocks base method.
-func (m *Mockcommits) GetCommitStatus(opt bitbucket.CommitsOptions) (any, error) {
+// Update mocks base method.
+func (m *MockBranchRestrictionsService) Update(bo *bitbucket.BranchRestrictionsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommitStatus", opt)
+	ret := m.ctrl.Call(m, "Update", bo)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommitStatus indicates an expected call of GetCommitStatus.
-func (mr *MockcommitsMockRecorder) GetCommitStatus(opt any) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockBranchRestrictionsServiceMockRecorder) Update(bo any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitStatus", reflect.TypeOf((*Mockcommits)(nil).GetCommitStatus), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBranchRestrictionsService)(nil).Update), bo)
 }
 
-// GetCommits mocks base method.
-func (m *Mockcommits) GetCommits(opt bitbucket.CommitsOptions) (any, error) {
+// MockDiffService is a mock of DiffService interface.
+type MockDiffService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiffServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDiffServiceMockRecorder is the mock recorder for MockDiffService.
+type MockDiffServiceMockRecorder struct {
+	mock *MockDiffService
+}
+
+// NewMockDiffService creates a new mock instance.
+func NewMockDiffService(ctrl *gomock.Controller) *MockDiffService {
+	mock := &MockDiffService{ctrl: ctrl}
+	mock.recorder = &MockDiffServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiffService) EXPECT() *MockDiffServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDiff mocks base method.
+func (m *MockDiffService) GetDiff(do *bitbucket.DiffOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommits", opt)
+	ret := m.ctrl.Call(m, "GetDiff", do)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommits indicates an expected call of GetCommits.
-func (mr *MockcommitsMockRecorder) GetCommits(opt any) *gomock.Call {
+// GetDiff indicates an expected call of GetDiff.
+func (mr *MockDiffServiceMockRecorder) GetDiff(do any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommits", reflect.TypeOf((*Mockcommits)(nil).GetCommits), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiff", reflect.TypeOf((*MockDiffService)(nil).GetDiff), do)
 }
 
-// GiveApprove mocks base method.
-func (m *Mockcommits) GiveApprove(opt bitbucket.CommitsOptions) (any, error) {
+// GetDiffStat mocks base method.
+func (m *MockDiffService) GetDiffStat(dso *bitbucket.DiffStatOptions) (*bitbucket.DiffStatRes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GiveApprove", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "GetDiffStat", dso)
+	ret0, _ := ret[0].(*bitbucket.DiffStatRes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GiveApprove indicates an expected call of GiveApprove.
-func (mr *MockcommitsMockRecorder) GiveApprove(opt any) *gomock.Call {
+// GetDiffStat indicates an expected call of GetDiffStat.
+func (mr *MockDiffServiceMockRecorder) GetDiffStat(dso any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GiveApprove", reflect.TypeOf((*Mockcommits)(nil).GiveApprove), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiffStat", reflect.TypeOf((*MockDiffService)(nil).GetDiffStat), dso)
 }
 
-// RemoveApprove mocks base method.
-func (m *Mockcommits) RemoveApprove(opt bitbucket.CommitsOptions) (any, error) {
+// GetPatch mocks base method.
+func (m *MockDiffService) GetPatch(do *bitbucket.DiffOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveApprove", opt)
+	ret := m.ctrl.Call(m, "GetPatch", do)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RemoveApprove indicates an expected call of RemoveApprove.
-func (mr *MockcommitsMockRecorder) RemoveApprove(opt any) *gomock.Call {
+// GetPatch indicates an expected call of GetPatch.
+func (mr *MockDiffServiceMockRecorder) GetPatch(do any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveApprove", reflect.TypeOf((*Mockcommits)(nil).RemoveApprove), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatch", reflect.TypeOf((*MockDiffService)(nil).GetPatch), do)
 }
 
-// Mockbranchrestrictions is a mock of branchrestrictions interface.
-type Mockbranchrestrictions struct {
+// MockWebhooksService is a mock of WebhooksService interface.
+type MockWebhooksService struct {
 	ctrl     *gomock.Controller
-	recorder *MockbranchrestrictionsMockRecorder
+	recorder *MockWebhooksServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockbranchrestrictionsMockRecorder is the mock recorder for Mockbranchrestrictions.
-type MockbranchrestrictionsMockRecorder struct {
-	mock *Mockbranchrestrictions
+// MockWebhooksServiceMockRecorder is the mock recorder for MockWebhooksService.
+type MockWebhooksServiceMockRecorder struct {
+	mock *MockWebhooksService
 }
 
-// NewMockbranchrestrictions creates a new mock instance.
-func NewMockbranchrestrictions(ctrl *gomock.Controller) *Mockbranchrestrictions {
-	mock := &Mockbranchrestrictions{ctrl: ctrl}
-	mock.recorder = &MockbranchrestrictionsMockRecorder{mock}
+// NewMockWebhooksService creates a new mock instance.
+func NewMockWebhooksService(ctrl *gomock.Controller) *MockWebhooksService {
+	mock := &MockWebhooksService{ctrl: ctrl}
+	mock.recorder = &MockWebhooksServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockbranchrestrictions) EXPECT() *MockbranchrestrictionsMockRecorder {
+func (m *MockWebhooksService) EXPECT() *MockWebhooksServiceMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *Mockbranchrestrictions) Create(opt bitbucket.BranchRestrictionsOptions) (any, error) {
+func (m *MockWebhooksService) Create(ro *bitbucket.WebhooksOptions) (*bitbucket.Webhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Create", ro)
+	ret0, _ := ret[0].(*bitbucket.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockbranchrestrictionsMockRecorder) Create(opt any) *gomock.Call {
+func (mr *MockWebhooksServiceMockRecorder) Create(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockbranchrestrictions)(nil).Create), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhooksService)(nil).Create), ro)
 }
 
 // Delete mocks base method.
-func (m *Mockbranchrestrictions) Delete(opt bitbucket.BranchRestrictionsOptions) (any, error) {
+func (m *MockWebhooksService) Delete(ro *bitbucket.WebhooksOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", opt)
+	ret := m.ctrl.Call(m, "Delete", ro)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockbranchrestrictionsMockRecorder) Delete(opt any) *gomock.Call {
+func (mr *MockWebhooksServiceMockRecorder) Delete(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockbranchrestrictions)(nil).Delete), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhooksService)(nil).Delete), ro)
 }
 
 // Get mocks base method.
-func (m *Mockbranchrestrictions) Get(opt bitbucket.BranchRestrictionsOptions) (any, error) {
+func (m *MockWebhooksService) Get(ro *bitbucket.WebhooksOptions) (*bitbucket.Webhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Get", ro)
+	ret0, _ := ret[0].(*bitbucket.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockbranchrestrictionsMockRecorder) Get(opt any) *gomock.Call {
+func (mr *MockWebhooksServiceMockRecorder) Get(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockbranchrestrictions)(nil).Get), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockWebhooksService)(nil).Get), ro)
 }
 
 // Gets mocks base method.
-func (m *Mockbranchrestrictions) Gets(opt bitbucket.BranchRestrictionsOptions) (any, error) {
+func (m *MockWebhooksService) Gets(ro *bitbucket.WebhooksOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Gets", opt)
+	ret := m.ctrl.Call(m, "Gets", ro)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Gets indicates an expected call of Gets.
-func (mr *MockbranchrestrictionsMockRecorder) Gets(opt any) *gomock.Call {
+func (mr *MockWebhooksServiceMockRecorder) Gets(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*MockWebhooksService)(nil).Gets), ro)
+}
+
+// List mocks base method.
+func (m *MockWebhooksService) List(ro *bitbucket.WebhooksOptions) ([]bitbucket.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ro)
+	ret0, _ := ret[0].([]bitbucket.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhooksServiceMockRecorder) List(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*Mockbranchrestrictions)(nil).Gets), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhooksService)(nil).List), ro)
 }
 
 // Update mocks base method.
-func (m *Mockbranchrestrictions) Update(opt bitbucket.BranchRestrictionsOptions) (any, error) {
+func (m *MockWebhooksService) Update(ro *bitbucket.WebhooksOptions) (*bitbucket.Webhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Update", ro)
+	ret0, _ := ret[0].(*bitbucket.Webhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockbranchrestrictionsMockRecorder) Update(opt any) *gomock.Call {
+func (mr *MockWebhooksServiceMockRecorder) Update(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*Mockbranchrestrictions)(nil).Update), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhooksService)(nil).Update), ro)
 }
 
-// Mockdiff is a mock of diff interface.
-type Mockdiff struct {
+// MockDownloadsService is a mock of DownloadsService interface.
+type MockDownloadsService struct {
 	ctrl     *gomock.Controller
-	recorder *MockdiffMockRecorder
+	recorder *MockDownloadsServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockdiffMockRecorder is the mock recorder for Mockdiff.
-type MockdiffMockRecorder struct {
-	mock *Mockdiff
+// MockDownloadsServiceMockRecorder is the mock recorder for MockDownloadsService.
+type MockDownloadsServiceMockRecorder struct {
+	mock *MockDownloadsService
 }
 
-// NewMockdiff creates a new mock instance.
-func NewMockdiff(ctrl *gomock.Controller) *Mockdiff {
-	mock := &Mockdiff{ctrl: ctrl}
-	mock.recorder = &MockdiffMockRecorder{mock}
+// NewMockDownloadsService creates a new mock instance.
+func NewMockDownloadsService(ctrl *gomock.Controller) *MockDownloadsService {
+	mock := &MockDownloadsService{ctrl: ctrl}
+	mock.recorder = &MockDownloadsServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockdiff) EXPECT() *MockdiffMockRecorder {
+func (m *MockDownloadsService) EXPECT() *MockDownloadsServiceMockRecorder {
 	return m.recorder
 }
 
-// GetDiff mocks base method.
-func (m *Mockdiff) GetDiff(opt bitbucket.DiffOptions) (any, error) {
+// Create mocks base method.
+func (m *MockDownloadsService) Create(do *bitbucket.DownloadsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDiff", opt)
+	ret := m.ctrl.Call(m, "Create", do)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDiff indicates an expected call of GetDiff.
-func (mr *MockdiffMockRecorder) GetDiff(opt any) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockDownloadsServiceMockRecorder) Create(do any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDiff", reflect.TypeOf((*Mockdiff)(nil).GetDiff), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDownloadsService)(nil).Create), do)
 }
 
-// GetPatch mocks base method.
-func (m *Mockdiff) GetPatch(opt bitbucket.DiffOptions) (any, error) {
+// List mocks base method.
+func (m *MockDownloadsService) List(do *bitbucket.DownloadsOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPatch", opt)
+	ret := m.ctrl.Call(m, "List", do)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPatch indicates an expected call of GetPatch.
-func (mr *MockdiffMockRecorder) GetPatch(opt any) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockDownloadsServiceMockRecorder) List(do any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatch", reflect.TypeOf((*Mockdiff)(nil).GetPatch), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDownloadsService)(nil).List), do)
 }
 
-// Mockwebhooks is a mock of webhooks interface.
-type Mockwebhooks struct {
+// MockDeployKeysService is a mock of DeployKeysService interface.
+type MockDeployKeysService struct {
 	ctrl     *gomock.Controller
-	recorder *MockwebhooksMockRecorder
+	recorder *MockDeployKeysServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockwebhooksMockRecorder is the mock recorder for Mockwebhooks.
-type MockwebhooksMockRecorder struct {
-	mock *Mockwebhooks
+// MockDeployKeysServiceMockRecorder is the mock recorder for MockDeployKeysService.
+type MockDeployKeysServiceMockRecorder struct {
+	mock *MockDeployKeysService
 }
 
-// NewMockwebhooks creates a new mock instance.
-func NewMockwebhooks(ctrl *gomock.Controller) *Mockwebhooks {
-	mock := &Mockwebhooks{ctrl: ctrl}
-	mock.recorder = &MockwebhooksMockRecorder{mock}
+// NewMockDeployKeysService creates a new mock instance.
+func NewMockDeployKeysService(ctrl *gomock.Controller) *MockDeployKeysService {
+	mock := &MockDeployKeysService{ctrl: ctrl}
+	mock.recorder = &MockDeployKeysServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockwebhooks) EXPECT() *MockwebhooksMockRecorder {
+func (m *MockDeployKeysService) EXPECT() *MockDeployKeysServiceMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *Mockwebhooks) Create(opt bitbucket.WebhooksOptions) (any, error) {
+func (m *MockDeployKeysService) Create(opt *bitbucket.DeployKeyOptions) (*bitbucket.DeployKey, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", opt)
-	ret0, _ := ret[0].(any)
+	ret0, _ := ret[0].(*bitbucket.DeployKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockwebhooksMockRecorder) Create(opt any) *gomock.Call {
+func (mr *MockDeployKeysServiceMockRecorder) Create(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockwebhooks)(nil).Create), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDeployKeysService)(nil).Create), opt)
 }
 
 // Delete mocks base method.
-func (m *Mockwebhooks) Delete(opt bitbucket.WebhooksOptions) (any, error) {
+func (m *MockDeployKeysService) Delete(opt *bitbucket.DeployKeyOptions) (any, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", opt)
 	ret0, _ := ret[0].(any)
@@ -1593,280 +2984,396 @@ func (m *Mockwebhooks) Delete(opt bitbucket.WebhooksOptions) (any, error) {
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockwebhooksMockRecorder) Delete(opt any) *gomock.Call {
+func (mr *MockDeployKeysServiceMockRecorder) Delete(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockwebhooks)(nil).Delete), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDeployKeysService)(nil).Delete), opt)
 }
 
 // Get mocks base method.
-func (m *Mockwebhooks) Get(opt bitbucket.WebhooksOptions) (any, error) {
+func (m *MockDeployKeysService) Get(opt *bitbucket.DeployKeyOptions) (*bitbucket.DeployKey, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", opt)
-	ret0, _ := ret[0].(any)
+	ret0, _ := ret[0].(*bitbucket.DeployKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockwebhooksMockRecorder) Get(opt any) *gomock.Call {
+func (mr *MockDeployKeysServiceMockRecorder) Get(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockwebhooks)(nil).Get), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeployKeysService)(nil).Get), opt)
 }
 
-// Gets mocks base method.
-func (m *Mockwebhooks) Gets(opt bitbucket.WebhooksOptions) (any, error) {
+// List mocks base method.
+func (m *MockDeployKeysService) List(opt *bitbucket.DeployKeyOptions) (*bitbucket.DeployKeysRes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Gets", opt)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "List", opt)
+	ret0, _ := ret[0].(*bitbucket.DeployKeysRes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Gets indicates an expected call of Gets.
-func (mr *MockwebhooksMockRecorder) Gets(opt any) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockDeployKeysServiceMockRecorder) List(opt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gets", reflect.TypeOf((*Mockwebhooks)(nil).Gets), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeployKeysService)(nil).List), opt)
 }
 
-// Update mocks base method.
-func (m *Mockwebhooks) Update(opt bitbucket.WebhooksOptions) (any, error) {
+// MockSSHKeysService is a mock of SSHKeysService interface.
+type MockSSHKeysService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSSHKeysServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSSHKeysServiceMockRecorder is the mock recorder for MockSSHKeysService.
+type MockSSHKeysServiceMockRecorder struct {
+	mock *MockSSHKeysService
+}
+
+// NewMockSSHKeysService creates a new mock instance.
+func NewMockSSHKeysService(ctrl *gomock.Controller) *MockSSHKeysService {
+	mock := &MockSSHKeysService{ctrl: ctrl}
+	mock.recorder = &MockSSHKeysServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSSHKeysService) EXPECT() *MockSSHKeysServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSSHKeysService) Create(ro *bitbucket.SSHKeyOptions) (*bitbucket.SSHKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ro)
+	ret0, _ := ret[0].(*bitbucket.SSHKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSSHKeysServiceMockRecorder) Create(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSSHKeysService)(nil).Create), ro)
+}
+
+// Delete mocks base method.
+func (m *MockSSHKeysService) Delete(ro *bitbucket.SSHKeyOptions) (any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", opt)
+	ret := m.ctrl.Call(m, "Delete", ro)
 	ret0, _ := ret[0].(any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockwebhooksMockRecorder) Update(opt any) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockSSHKeysServiceMockRecorder) Delete(ro any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSSHKeysService)(nil).Delete), ro)
+}
+
+// Get mocks base method.
+func (m *MockSSHKeysService) Get(ro *bitbucket.SSHKeyOptions) (*bitbucket.SSHKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ro)
+	ret0, _ := ret[0].(*bitbucket.SSHKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSSHKeysServiceMockRecorder) Get(ro any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*Mockwebhooks)(nil).Update), opt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSSHKeysService)(nil).Get), ro)
 }
 
-// Mockteams is a mock of teams interface.
-type Mockteams struct {
+// MockSnippetsService is a mock of SnippetsService interface.
+type MockSnippetsService struct {
 	ctrl     *gomock.Controller
-	recorder *MockteamsMockRecorder
+	recorder *MockSnippetsServiceMockRecorder
 	isgomock struct{}
 }
 
-// MockteamsMockRecorder is the mock recorder for Mockteams.
-type MockteamsMockRecorder struct {
-	mock *Mockteams
+// MockSnippetsServiceMockRecorder is the mock recorder for MockSnippetsService.
+type MockSnippetsServiceMockRecorder struct {
+	mock *MockSnippetsService
 }
 
-// NewMockteams creates a new mock instance.
-func NewMockteams(ctrl *gomock.Controller) *Mockteams {
-	mock := &Mockteams{ctrl: ctrl}
-	mock.recorder = &MockteamsMockRecorder{mock}
+// NewMockSnippetsService creates a new mock instance.
+func NewMockSnippetsService(ctrl *gomock.Controller) *MockSnippetsService {
+	mock := &MockSnippetsService{ctrl: ctrl}
+	mock.recorder = &MockSnippetsServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockteams) EXPECT() *MockteamsMockRecorder {
+func (m *MockSnippetsService) EXPECT() *MockSnippetsServiceMockRecorder {
 	return m.recorder
 }
 
-// Followers mocks base method.
-func (m *Mockteams) Followers(teamname string) (any, error) {
+// AddComment mocks base method.
+func (m *MockSnippetsService) AddComment(sco *bitbucket.SnippetCommentOptions) (*bitbucket.SnippetComment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Followers", teamname)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "AddComment", sco)
+	ret0, _ := ret[0].(*bitbucket.SnippetComment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Followers indicates an expected call of Followers.
-func (mr *MockteamsMockRecorder) Followers(teamname any) *gomock.Call {
+// AddComment indicates an expected call of AddComment.
+func (mr *MockSnippetsServiceMockRecorder) AddComment(sco any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Followers", reflect.TypeOf((*Mockteams)(nil).Followers), teamname)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockSnippetsService)(nil).AddComment), sco)
 }
 
-// Following mocks base method.
-func (m *Mockteams) Following(teamname string) (any, error) {
+// Create mocks base method.
+func (m *MockSnippetsService) Create(so *bitbucket.SnippetOptions) (*bitbucket.Snippet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Following", teamname)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Create", so)
+	ret0, _ := ret[0].(*bitbucket.Snippet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Following indicates an expected call of Following.
-func (mr *MockteamsMockRecorder) Following(teamname any) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockSnippetsServiceMockRecorder) Create(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Following", reflect.TypeOf((*Mockteams)(nil).Following), teamname)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSnippetsService)(nil).Create), so)
 }
 
-// List mocks base method.
-func (m *Mockteams) List(role string) (any, error) {
+// Delete mocks base method.
+func (m *MockSnippetsService) Delete(so *bitbucket.SnippetOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", role)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Delete", so)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSnippetsServiceMockRecorder) Delete(so any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSnippetsService)(nil).Delete), so)
+}
+
+// DeleteComment mocks base method.
+func (m *MockSnippetsService) DeleteComment(sco *bitbucket.SnippetCommentOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", sco)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockSnippetsServiceMockRecorder) DeleteComment(sco any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockSnippetsService)(nil).DeleteComment), sco)
+}
+
+// Diff mocks base method.
+func (m *MockSnippetsService) Diff(so *bitbucket.SnippetOptions) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", so)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// List indicates an expected call of List.
-func (mr *MockteamsMockRecorder) List(role any) *gomock.Call {
+// Diff indicates an expected call of Diff.
+func (mr *MockSnippetsServiceMockRecorder) Diff(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*Mockteams)(nil).List), role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockSnippetsService)(nil).Diff), so)
 }
 
-// Members mocks base method.
-func (m *Mockteams) Members(teamname string) (any, error) {
+// Get mocks base method.
+func (m *MockSnippetsService) Get(so *bitbucket.SnippetOptions) (*bitbucket.Snippet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Members", teamname)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Get", so)
+	ret0, _ := ret[0].(*bitbucket.Snippet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Members indicates an expected call of Members.
-func (mr *MockteamsMockRecorder) Members(teamname any) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockSnippetsServiceMockRecorder) Get(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*Mockteams)(nil).Members), teamname)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSnippetsService)(nil).Get), so)
 }
 
-// Profile mocks base method.
-func (m *Mockteams) Profile(teamname string) (any, error) {
+// GetComment mocks base method.
+func (m *MockSnippetsService) GetComment(sco *bitbucket.SnippetCommentOptions) (*bitbucket.SnippetComment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Profile", teamname)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "GetComment", sco)
+	ret0, _ := ret[0].(*bitbucket.SnippetComment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Profile indicates an expected call of Profile.
-func (mr *MockteamsMockRecorder) Profile(teamname any) *gomock.Call {
+// GetComment indicates an expected call of GetComment.
+func (mr *MockSnippetsServiceMockRecorder) GetComment(sco any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Profile", reflect.TypeOf((*Mockteams)(nil).Profile), teamname)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockSnippetsService)(nil).GetComment), sco)
 }
 
-// Projects mocks base method.
-func (m *Mockteams) Projects(teamname string) (any, error) {
+// GetFile mocks base method.
+func (m *MockSnippetsService) GetFile(so *bitbucket.SnippetOptions) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Projects", teamname)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "GetFile", so)
+	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Projects indicates an expected call of Projects.
-func (mr *MockteamsMockRecorder) Projects(teamname any) *gomock.Call {
+// GetFile indicates an expected call of GetFile.
+func (mr *MockSnippetsServiceMockRecorder) GetFile(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Projects", reflect.TypeOf((*Mockteams)(nil).Projects), teamname)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockSnippetsService)(nil).GetFile), so)
 }
 
-// Repositories mocks base method.
-func (m *Mockteams) Repositories(teamname string) (any, error) {
+// GetRevision mocks base method.
+func (m *MockSnippetsService) GetRevision(so *bitbucket.SnippetOptions) (*bitbucket.SnippetCommit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Repositories", teamname)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "GetRevision", so)
+	ret0, _ := ret[0].(*bitbucket.SnippetCommit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Repositories indicates an expected call of Repositories.
-func (mr *MockteamsMockRecorder) Repositories(teamname any) *gomock.Call {
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockSnippetsServiceMockRecorder) GetRevision(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repositories", reflect.TypeOf((*Mockteams)(nil).Repositories), teamname)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockSnippetsService)(nil).GetRevision), so)
 }
 
-// Mockpipelines is a mock of pipelines interface.
-type Mockpipelines struct {
-	ctrl     *gomock.Controller
-	recorder *MockpipelinesMockRecorder
-	isgomock struct{}
+// IsWatching mocks base method.
+func (m *MockSnippetsService) IsWatching(so *bitbucket.SnippetOptions) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsWatching", so)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockpipelinesMockRecorder is the mock recorder for Mockpipelines.
-type MockpipelinesMockRecorder struct {
-	mock *Mockpipelines
+// IsWatching indicates an expected call of IsWatching.
+func (mr *MockSnippetsServiceMockRecorder) IsWatching(so any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWatching", reflect.TypeOf((*MockSnippetsService)(nil).IsWatching), so)
 }
 
-// NewMockpipelines creates a new mock instance.
-func NewMockpipelines(ctrl *gomock.Controller) *Mockpipelines {
-	mock := &Mockpipelines{ctrl: ctrl}
-	mock.recorder = &MockpipelinesMockRecorder{mock}
-	return mock
+// List mocks base method.
+func (m *MockSnippetsService) List(so *bitbucket.SnippetOptions) (*bitbucket.SnippetsRes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", so)
+	ret0, _ := ret[0].(*bitbucket.SnippetsRes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *Mockpipelines) EXPECT() *MockpipelinesMockRecorder {
-	return m.recorder
+// List indicates an expected call of List.
+func (mr *MockSnippetsServiceMockRecorder) List(so any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSnippetsService)(nil).List), so)
 }
 
-// Get mocks base method.
-func (m *Mockpipelines) Get(po *bitbucket.PipelinesOptions) (any, error) {
+// ListComments mocks base method.
+func (m *MockSnippetsService) ListComments(sco *bitbucket.SnippetCommentOptions) ([]bitbucket.SnippetComment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", po)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "ListComments", sco)
+	ret0, _ := ret[0].([]bitbucket.SnippetComment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockpipelinesMockRecorder) Get(po any) *gomock.Call {
+// ListComments indicates an expected call of ListComments.
+func (mr *MockSnippetsServiceMockRecorder) ListComments(sco any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockpipelines)(nil).Get), po)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*MockSnippetsService)(nil).ListComments), sco)
 }
 
-// GetLog mocks base method.
-func (m *Mockpipelines) GetLog(po *bitbucket.PipelinesOptions) (string, error) {
+// ListRevisions mocks base method.
+func (m *MockSnippetsService) ListRevisions(so *bitbucket.SnippetOptions) ([]bitbucket.SnippetCommit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLog", po)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ListRevisions", so)
+	ret0, _ := ret[0].([]bitbucket.SnippetCommit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetLog indicates an expected call of GetLog.
-func (mr *MockpipelinesMockRecorder) GetLog(po any) *gomock.Call {
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockSnippetsServiceMockRecorder) ListRevisions(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLog", reflect.TypeOf((*Mockpipelines)(nil).GetLog), po)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockSnippetsService)(nil).ListRevisions), so)
 }
 
-// GetStep mocks base method.
-func (m *Mockpipelines) GetStep(po *bitbucket.PipelinesOptions) (any, error) {
+// Patch mocks base method.
+func (m *MockSnippetsService) Patch(so *bitbucket.SnippetOptions) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStep", po)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Patch", so)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetStep indicates an expected call of GetStep.
-func (mr *MockpipelinesMockRecorder) GetStep(po any) *gomock.Call {
+// Patch indicates an expected call of Patch.
+func (mr *MockSnippetsServiceMockRecorder) Patch(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStep", reflect.TypeOf((*Mockpipelines)(nil).GetStep), po)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockSnippetsService)(nil).Patch), so)
 }
 
-// List mocks base method.
-func (m *Mockpipelines) List(po *bitbucket.PipelinesOptions) (any, error) {
+// Unwatch mocks base method.
+func (m *MockSnippetsService) Unwatch(so *bitbucket.SnippetOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", po)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "Unwatch", so)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unwatch indicates an expected call of Unwatch.
+func (mr *MockSnippetsServiceMockRecorder) Unwatch(so any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unwatch", reflect.TypeOf((*MockSnippetsService)(nil).Unwatch), so)
+}
+
+// Update mocks base method.
+func (m *MockSnippetsService) Update(so *bitbucket.SnippetOptions) (*bitbucket.Snippet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", so)
+	ret0, _ := ret[0].(*bitbucket.Snippet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// List indicates an expected call of List.
-func (mr *MockpipelinesMockRecorder) List(po any) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockSnippetsServiceMockRecorder) Update(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*Mockpipelines)(nil).List), po)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSnippetsService)(nil).Update), so)
 }
 
-// ListSteps mocks base method.
-func (m *Mockpipelines) ListSteps(po *bitbucket.PipelinesOptions) (any, error) {
+// UpdateComment mocks base method.
+func (m *MockSnippetsService) UpdateComment(sco *bitbucket.SnippetCommentOptions) (*bitbucket.SnippetComment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSteps", po)
-	ret0, _ := ret[0].(any)
+	ret := m.ctrl.Call(m, "UpdateComment", sco)
+	ret0, _ := ret[0].(*bitbucket.SnippetComment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSteps indicates an expected call of ListSteps.
-func (mr *MockpipelinesMockRecorder) ListSteps(po any) *gomock.Call {
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockSnippetsServiceMockRecorder) UpdateComment(sco any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockSnippetsService)(nil).UpdateComment), sco)
+}
+
+// Watch mocks base method.
+func (m *MockSnippetsService) Watch(so *bitbucket.SnippetOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", so)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockSnippetsServiceMockRecorder) Watch(so any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSteps", reflect.TypeOf((*Mockpipelines)(nil).ListSteps), po)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockSnippetsService)(nil).Watch), so)
 }
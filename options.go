@@ -0,0 +1,473 @@
+package bitbucket
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// Option configures a Client created with New.
+type Option func(*clientConfig) error
+
+// Middleware wraps the transport used by a Client, e.g. to log or instrument requests.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type clientConfig struct {
+	baseURL     *url.URL
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	caPool      *x509.CertPool
+	caCerts     [][]byte
+	proxy       func(*http.Request) (*url.URL, error)
+	timeout     time.Duration
+	userAgent   string
+	pagelen     int
+	retry       *retryPolicy
+	middleware  []Middleware
+	cache       ResponseCache
+	rateLimiter *RateLimiter
+
+	cacheNamespace string
+	onTokenRefresh TokenRefreshFunc
+}
+
+// BasicCredentials returns an Auth using HTTP basic authentication, e.g. with an
+// app password.
+func BasicCredentials(user, password string) *Auth {
+	return &Auth{user: user, password: password}
+}
+
+// BearerTokenCredentials returns an Auth sending token as a bearer token, e.g.
+// a repository, project or workspace access token.
+func BearerTokenCredentials(token string) *Auth {
+	return &Auth{bearerToken: token}
+}
+
+// OAuthTokenCredentials returns an Auth using an OAuth2 token obtained elsewhere.
+func OAuthTokenCredentials(token oauth2.Token) *Auth {
+	return &Auth{token: token}
+}
+
+// New returns a Client authenticating with a and configured by opts.
+//
+// Every Client created by New owns its transport: options never modify
+// http.DefaultTransport or any other process-wide state, so clients talking
+// to different servers can be used side by side. Likewise, the client works on
+// a copy of a, which can be reused for other clients.
+//
+// Unless WithBaseURL is given, the API base URL is taken from the
+// BITBUCKET_API_BASE_URL environment variable, falling back to
+// DEFAULT_BITBUCKET_API_BASE_URL.
+func New(a *Auth, opts ...Option) (*Client, error) {
+	if a == nil {
+		a = &Auth{}
+	} else {
+		a = a.clone()
+	}
+	cfg := &clientConfig{pagelen: DEFAULT_PAGE_LENGTH}
+	if a.caCerts != nil {
+		cfg.caCerts = append(cfg.caCerts, a.caCerts)
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.baseURL == nil {
+		bitbucketUrl, err := apiBaseUrl()
+		if err != nil {
+			return nil, fmt.Errorf("invalid bitbucket url: %w", err)
+		}
+		cfg.baseURL = bitbucketUrl
+	}
+
+	httpClient, err := cfg.buildHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.onTokenRefresh != nil {
+		a.onTokenRefresh = cfg.onTokenRefresh
+	}
+
+	c := &Client{Auth: a, Pagelen: cfg.pagelen, MaxDepth: DEFAULT_MAX_DEPTH,
+		apiBaseURL: cfg.baseURL, LimitPages: DEFAULT_LIMIT_PAGES, HttpClient: httpClient,
+		cacheNamespace: cfg.cacheNamespace}
+	c.Repositories = &Repositories{
+		c:                  c,
+		PullRequests:       &PullRequests{c: c},
+		Pipelines:          &Pipelines{c: c},
+		Repository:         &Repository{c: c},
+		Issues:             &Issues{c: c},
+		Commits:            &Commits{c: c},
+		Diff:               &Diff{c: c},
+		BranchRestrictions: &BranchRestrictions{c: c},
+		Webhooks:           &Webhooks{c: c},
+		Downloads:          &Downloads{c: c},
+		DeployKeys:         &DeployKeys{c: c},
+	}
+	c.Users = &Users{
+		c:       c,
+		SSHKeys: &SSHKeys{c: c},
+	}
+	c.User = &User{c: c}
+	c.Teams = &Teams{c: c}
+	c.Workspaces = &Workspace{c: c, Repositories: c.Repositories, Permissions: &Permission{c: c}}
+	c.Snippets = &Snippets{c: c}
+	return c, nil
+}
+
+// clone returns a copy of a, without its mutex.
+func (a *Auth) clone() *Auth {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return &Auth{
+		appID: a.appID, secret: a.secret,
+		user: a.user, password: a.password,
+		token:       a.token,
+		bearerToken: a.bearerToken,
+		caCerts:     a.caCerts,
+
+		connectAppKey:    a.connectAppKey,
+		connectClientKey: a.connectClientKey,
+		connectSecret:    a.connectSecret,
+
+		tokenSource:    a.tokenSource,
+		onTokenRefresh: a.onTokenRefresh,
+	}
+}
+
+// buildHTTPClient assembles the transport chain. From the outside in, requests
+// go through the user agent, the cache, the middleware in the order given, the
+// retry policy and the rate limiter before reaching the base transport, so every
+// retried attempt is throttled and cache hits never consume rate limit budget.
+func (cfg *clientConfig) buildHTTPClient() (*http.Client, error) {
+	httpClient := new(http.Client)
+	if cfg.httpClient != nil {
+		copied := *cfg.httpClient
+		httpClient = &copied
+	}
+
+	base := cfg.transport
+	if base == nil {
+		base = httpClient.Transport
+	}
+	base, err := cfg.configureTransport(base)
+	if err != nil {
+		return nil, err
+	}
+
+	rt := base
+	if cfg.rateLimiter != nil {
+		rt = cfg.rateLimiter.Transport(rt)
+	}
+	if cfg.retry != nil {
+		rt = &retryTransport{base: rt, policy: *cfg.retry}
+	}
+	for i := len(cfg.middleware) - 1; i >= 0; i-- {
+		rt = cfg.middleware[i](rt)
+	}
+	if cfg.cache != nil {
+		rt = NewCacheTransport(cfg.cache, rt)
+	}
+	if cfg.userAgent != "" {
+		rt = &userAgentTransport{base: rt, userAgent: cfg.userAgent}
+	}
+
+	httpClient.Transport = rt
+	if cfg.timeout > 0 {
+		httpClient.Timeout = cfg.timeout
+	}
+	return httpClient, nil
+}
+
+// configureTransport applies the TLS and proxy settings to a copy of base, or of
+// http.DefaultTransport when base is nil. The original transport is left untouched.
+func (cfg *clientConfig) configureTransport(base http.RoundTripper) (http.RoundTripper, error) {
+	if cfg.caPool == nil && len(cfg.caCerts) == 0 && cfg.proxy == nil {
+		if base == nil {
+			return http.DefaultTransport, nil
+		}
+		return base, nil
+	}
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t, ok := base.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("CA certificates and proxies can only be configured on an *http.Transport, got %T", base)
+	}
+	t = t.Clone()
+
+	if cfg.caPool != nil || len(cfg.caCerts) > 0 {
+		pool := cfg.caPool
+		if pool == nil {
+			// Start from a copy of the system pool so the custom CAs are trusted in addition to it.
+			var err error
+			if pool, err = x509.SystemCertPool(); err != nil {
+				pool = x509.NewCertPool()
+			}
+		} else {
+			pool = pool.Clone()
+		}
+		for _, pem := range cfg.caCerts {
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("unable to append CA Certs to cert pool")
+			}
+		}
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+	if cfg.proxy != nil {
+		t.Proxy = cfg.proxy
+	}
+	return t, nil
+}
+
+// WithBaseURL sets the API base URL, e.g. "https://bitbucket.example.com/2.0".
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *clientConfig) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return fmt.Errorf("invalid bitbucket url: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid bitbucket url: %q is not absolute", baseURL)
+		}
+		cfg.baseURL = u
+		return nil
+	}
+}
+
+// WithCACerts adds the PEM encoded certificates to the CAs trusted by the client.
+// Unless WithCAPool is also given, they are trusted in addition to the system pool.
+func WithCACerts(pem []byte) Option {
+	return func(cfg *clientConfig) error {
+		cfg.caCerts = append(cfg.caCerts, pem)
+		return nil
+	}
+}
+
+// WithCAPool makes the client trust only the CAs in pool, plus any added with WithCACerts.
+func WithCAPool(pool *x509.CertPool) Option {
+	return func(cfg *clientConfig) error {
+		cfg.caPool = pool
+		return nil
+	}
+}
+
+// WithProxy sends every request through the proxy at proxyURL. By default the
+// client honours the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxyURL string) Option {
+	return func(cfg *clientConfig) error {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy url: %w", err)
+		}
+		cfg.proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithTimeout limits the time a single request may take, including reading
+// the response body. Requests made while auto paging are limited individually.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		cfg.timeout = timeout
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithPageLength sets the number of items requested per page by list methods.
+func WithPageLength(pagelen int) Option {
+	return func(cfg *clientConfig) error {
+		if pagelen < 1 {
+			return fmt.Errorf("page length must be positive, got %d", pagelen)
+		}
+		cfg.pagelen = pagelen
+		return nil
+	}
+}
+
+// WithRetry retries requests failing with a network error, a 429 or a 5xx
+// response up to maxRetries times, waiting with exponential backoff between
+// minBackoff and maxBackoff. A Retry-After header sent by the server takes
+// precedence over the backoff. Only requests whose body can be replayed are
+// retried, and POST and PATCH requests are only retried after a 429.
+func WithRetry(maxRetries int, minBackoff, maxBackoff time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		if maxRetries < 0 || minBackoff < 0 || maxBackoff < minBackoff {
+			return fmt.Errorf("invalid retry policy")
+		}
+		cfg.retry = &retryPolicy{maxRetries: maxRetries, minBackoff: minBackoff, maxBackoff: maxBackoff}
+		return nil
+	}
+}
+
+// WithMiddleware wraps the client's transport with mw. The first middleware
+// given sees each request first.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(cfg *clientConfig) error {
+		cfg.middleware = append(cfg.middleware, mw...)
+		return nil
+	}
+}
+
+// WithHTTPClient uses a copy of httpClient to send requests. Its transport is
+// used as the base transport unless WithTransport is also given.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(cfg *clientConfig) error {
+		cfg.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithTransport sets the base transport used to send requests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *clientConfig) error {
+		cfg.transport = transport
+		return nil
+	}
+}
+
+// WithCache caches responses in cache, see CacheTransport.
+func WithCache(cache ResponseCache) Option {
+	return func(cfg *clientConfig) error {
+		cfg.cache = cache
+		return nil
+	}
+}
+
+// WithCacheNamespace keys the responses the client caches by namespace along
+// with its credentials. It must be unique to the account the client acts for,
+// e.g. a tenant, and is required to cache the responses of clients using an
+// OAuth token source, whose rotating tokens cannot be told apart.
+func WithCacheNamespace(namespace string) Option {
+	return func(cfg *clientConfig) error {
+		cfg.cacheNamespace = namespace
+		return nil
+	}
+}
+
+// WithRateLimiter throttles requests with l, see RateLimiter.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(cfg *clientConfig) error {
+		cfg.rateLimiter = l
+		return nil
+	}
+}
+
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
+
+type retryPolicy struct {
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+type retryTransport struct {
+	base   http.RoundTripper
+	policy retryPolicy
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.policy.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if req.GetBody != nil && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	idempotent := req.Method != http.MethodPost && req.Method != http.MethodPatch
+	if err != nil {
+		return idempotent
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	delay := t.policy.minBackoff << uint(attempt)
+	if delay <= 0 || delay > t.policy.maxBackoff {
+		delay = t.policy.maxBackoff
+	}
+	if delay > 0 {
+		// Add up to 50% jitter so concurrent clients don't retry in lockstep.
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	return delay
+}
@@ -46,11 +46,19 @@ func (p *Pipelines) List(po *PipelinesOptions) (interface{}, error) {
 		urlStr = parsed.String()
 	}
 
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 	return p.c.executePaginated("GET", urlStr, "", nil)
 }
 
 func (p *Pipelines) Get(po *PipelinesOptions) (interface{}, error) {
 	urlStr := p.c.requestUrl("/repositories/%s/%s/pipelines/%s", po.Owner, po.RepoSlug, po.IDOrUuid)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	return p.c.execute("GET", urlStr, "")
 }
 
@@ -90,11 +98,19 @@ func (p *Pipelines) ListSteps(po *PipelinesOptions) (interface{}, error) {
 		urlStr = parsed.String()
 	}
 
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 	return p.c.executePaginated("GET", urlStr, "", nil)
 }
 
 func (p *Pipelines) GetStep(po *PipelinesOptions) (interface{}, error) {
 	urlStr := p.c.requestUrl("/repositories/%s/%s/pipelines/%s/steps/%s", po.Owner, po.RepoSlug, po.IDOrUuid, po.StepUuid)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	return p.c.execute("GET", urlStr, "")
 }
 
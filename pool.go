@@ -0,0 +1,182 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the credentials of a tenant, e.g. a workspace,
+// to a ClientPool.
+type CredentialProvider interface {
+	Credentials(ctx context.Context, tenant string) (*Auth, error)
+}
+
+// CredentialProviderFunc adapts a function to the CredentialProvider interface.
+type CredentialProviderFunc func(ctx context.Context, tenant string) (*Auth, error)
+
+func (f CredentialProviderFunc) Credentials(ctx context.Context, tenant string) (*Auth, error) {
+	return f(ctx, tenant)
+}
+
+// ClientPoolOptions configures a ClientPool.
+type ClientPoolOptions struct {
+	// Transport is shared by all clients of the pool, so they share one
+	// connection pool. It defaults to a clone of http.DefaultTransport.
+	// Configure CA certificates and proxies on it rather than with
+	// ClientOptions, which would give every client its own copy.
+	Transport http.RoundTripper
+	// IdleTimeout is how long a client may go unused before it is evicted.
+	// Zero keeps clients until they are evicted explicitly.
+	IdleTimeout time.Duration
+	// RateLimit returns the rate limiter for a tenant's client, or nil for none.
+	// It is called once each time a tenant's client is built.
+	RateLimit func(tenant string) *RateLimiter
+	// ClientOptions are applied to every client. A cache given with WithCache
+	// can be shared: each client caches responses under the namespace of its
+	// tenant, see WithCacheNamespace.
+	ClientOptions []Option
+}
+
+// ClientPool lazily builds and caches a Client per tenant for services acting
+// on behalf of many workspaces. It is safe for concurrent use.
+type ClientPool struct {
+	provider  CredentialProvider
+	opts      ClientPoolOptions
+	transport http.RoundTripper
+
+	mu        sync.Mutex
+	entries   map[string]*poolEntry
+	lastSweep time.Time
+}
+
+type poolEntry struct {
+	ready    chan struct{}
+	client   *Client
+	err      error
+	lastUsed time.Time
+}
+
+// NewClientPool returns a pool building clients with credentials from provider.
+// opts may be nil.
+func NewClientPool(provider CredentialProvider, opts *ClientPoolOptions) *ClientPool {
+	p := &ClientPool{provider: provider, entries: make(map[string]*poolEntry)}
+	if opts != nil {
+		p.opts = *opts
+	}
+	p.transport = p.opts.Transport
+	if p.transport == nil {
+		if t, ok := http.DefaultTransport.(*http.Transport); ok {
+			p.transport = t.Clone()
+		} else {
+			p.transport = http.DefaultTransport
+		}
+	}
+	return p
+}
+
+// Get returns the client of tenant, building it on first use. Concurrent calls
+// for the same tenant share a single call to the credential provider, which
+// gets the values of ctx but not its cancellation: a caller giving up returns
+// ctx.Err() without failing the others. If the provider fails, the error is
+// returned and the next call tries again.
+func (p *ClientPool) Get(ctx context.Context, tenant string) (*Client, error) {
+	now := time.Now()
+
+	p.mu.Lock()
+	p.sweep(now)
+	entry, ok := p.entries[tenant]
+	if !ok {
+		entry = &poolEntry{ready: make(chan struct{})}
+		p.entries[tenant] = entry
+	}
+	entry.lastUsed = now
+	p.mu.Unlock()
+
+	if !ok {
+		// The client is shared, so it is built without the cancellation of
+		// the caller that happens to ask first.
+		go func() {
+			entry.client, entry.err = p.build(context.WithoutCancel(ctx), tenant)
+			if entry.err != nil {
+				p.mu.Lock()
+				if p.entries[tenant] == entry {
+					delete(p.entries, tenant)
+				}
+				p.mu.Unlock()
+			}
+			close(entry.ready)
+		}()
+	}
+
+	select {
+	case <-entry.ready:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return entry.client, entry.err
+}
+
+func (p *ClientPool) build(ctx context.Context, tenant string) (*Client, error) {
+	auth, err := p.provider.Credentials(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clients of different tenants may share a cache, and those using a token
+	// source can only be told apart by their tenant.
+	opts := []Option{WithTransport(p.transport), WithCacheNamespace(tenant)}
+	if p.opts.RateLimit != nil {
+		if limiter := p.opts.RateLimit(tenant); limiter != nil {
+			opts = append(opts, WithRateLimiter(limiter))
+		}
+	}
+	return New(auth, append(opts, p.opts.ClientOptions...)...)
+}
+
+// Evict drops the client of tenant, e.g. after its credentials were revoked or
+// rotated. The next call to Get builds a new one.
+func (p *ClientPool) Evict(tenant string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.entries, tenant)
+}
+
+// EvictIdle drops every client unused for longer than the pool's IdleTimeout
+// and returns how many were dropped. Get calls it periodically.
+func (p *ClientPool) EvictIdle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.evictIdle(time.Now())
+}
+
+// Len returns the number of clients in the pool.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.entries)
+}
+
+// sweep evicts idle clients at most every half IdleTimeout.
+func (p *ClientPool) sweep(now time.Time) {
+	if p.opts.IdleTimeout <= 0 || now.Sub(p.lastSweep) < p.opts.IdleTimeout/2 {
+		return
+	}
+	p.lastSweep = now
+	p.evictIdle(now)
+}
+
+func (p *ClientPool) evictIdle(now time.Time) int {
+	if p.opts.IdleTimeout <= 0 {
+		return 0
+	}
+	evicted := 0
+	for tenant, entry := range p.entries {
+		if now.Sub(entry.lastUsed) > p.opts.IdleTimeout {
+			delete(p.entries, tenant)
+			evicted++
+		}
+	}
+	return evicted
+}
@@ -24,6 +24,7 @@ type ProjectOptions struct {
 	Key         string `json:"key"`
 	Description string `json:"description"`
 	IsPrivate   bool   `json:"is_private"`
+	Fields      []string
 	ctx         context.Context
 }
 
@@ -34,7 +35,11 @@ func (po *ProjectOptions) WithContext(ctx context.Context) *ProjectOptions {
 
 func (t *Workspace) GetProject(opt *ProjectOptions) (*Project, error) {
 	urlStr := t.c.requestUrl("/workspaces/%s/projects/%s", opt.Owner, opt.Key)
-	response, err := t.c.execute("GET", urlStr, "")
+	urlStr, err := t.c.withFieldsParam(urlStr, opt.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := t.c.executeWithContext("GET", urlStr, "", opt.ctx)
 	if err != nil {
 		return nil, err
 	}
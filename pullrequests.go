@@ -29,12 +29,20 @@ func (p *PullRequests) Update(po *PullRequestsOptions) (interface{}, error) {
 
 func (p *PullRequests) GetByCommit(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/commit/" + po.Commit + "/pullrequests/"
-	return p.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executePaginatedWithContext("GET", urlStr, "", nil, po.ctx)
 }
 
 func (p *PullRequests) GetCommits(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/" + po.ID + "/commits/"
-	return p.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executePaginatedWithContext("GET", urlStr, "", nil, po.ctx)
 }
 
 func (p *PullRequests) List(po *PullRequestsOptions) (interface{}, error) {
@@ -75,7 +83,11 @@ func (p *PullRequests) List(po *PullRequestsOptions) (interface{}, error) {
 		urlStr = parsed.String()
 	}
 
-	return p.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executePaginatedWithContext("GET", urlStr, "", nil, po.ctx)
 }
 
 /*
@@ -89,32 +101,48 @@ func (p *PullRequests) Gets(po *PullRequestsOptions) (interface{}, error) {
 
 func (p *PullRequests) Get(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/" + po.ID
-	return p.c.execute("GET", urlStr, "")
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executeWithContext("GET", urlStr, "", po.ctx)
 }
 
 func (p *PullRequests) Activities(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/activity"
-	return p.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executePaginatedWithContext("GET", urlStr, "", nil, po.ctx)
 }
 
 func (p *PullRequests) Activity(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/" + po.ID + "/activity"
-	return p.c.execute("GET", urlStr, "")
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executeWithContext("GET", urlStr, "", po.ctx)
 }
 
 func (p *PullRequests) Commits(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/" + po.ID + "/commits"
-	return p.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executePaginatedWithContext("GET", urlStr, "", nil, po.ctx)
 }
 
 func (p *PullRequests) Patch(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/" + po.ID + "/patch"
-	return p.c.executeRaw("GET", urlStr, "")
+	return p.c.executeRawWithContext("GET", urlStr, "", po.ctx)
 }
 
 func (p *PullRequests) Diff(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/" + po.ID + "/diff"
-	return p.c.executeRaw("GET", urlStr, "")
+	return p.c.executeRawWithContext("GET", urlStr, "", po.ctx)
 }
 
 func (p *PullRequests) Merge(po *PullRequestsOptions) (interface{}, error) {
@@ -182,12 +210,20 @@ func (p *PullRequests) DeleteComment(co *PullRequestCommentOptions) (interface{}
 
 func (p *PullRequests) GetComments(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/" + po.ID + "/comments/"
-	return p.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executePaginatedWithContext("GET", urlStr, "", nil, po.ctx)
 }
 
 func (p *PullRequests) GetComment(po *PullRequestsOptions) (interface{}, error) {
 	urlStr := p.c.GetApiBaseURL() + "/repositories/" + po.Owner + "/" + po.RepoSlug + "/pullrequests/" + po.ID + "/comments/" + po.CommentID
-	return p.c.execute("GET", urlStr, "")
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executeWithContext("GET", urlStr, "", po.ctx)
 }
 
 func (p *PullRequests) Statuses(po *PullRequestsOptions) (interface{}, error) {
@@ -213,7 +249,11 @@ func (p *PullRequests) Statuses(po *PullRequestsOptions) (interface{}, error) {
 		parsed.RawQuery = query.Encode()
 		urlStr = parsed.String()
 	}
-	return p.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := p.c.withFieldsParam(urlStr, po.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.c.executePaginatedWithContext("GET", urlStr, "", nil, po.ctx)
 }
 
 func (p *PullRequests) buildPullRequestBody(po *PullRequestsOptions) (string, error) {
@@ -289,6 +329,10 @@ func (p *PullRequests) buildPullRequestCommentBody(co *PullRequestCommentOptions
 		}
 	}
 
+	if co.Inline != nil {
+		body["inline"] = co.Inline
+	}
+
 	data, err := json.Marshal(body)
 	if err != nil {
 		return "", err
@@ -0,0 +1,316 @@
+package bitbucket
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter throttles requests sent through a Client with a token bucket and
+// caps how many of them are in flight at once. It adapts its rate to the
+// rate-limit headers returned by Bitbucket and backs off after a 429 response.
+//
+// A RateLimiter is safe for concurrent use and is meant to be shared by every
+// goroutine using the same Client.
+type RateLimiter struct {
+	mu          sync.Mutex
+	baseRate    float64
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	limit       int
+	remaining   int
+	resetAt     time.Time
+	nearLimit   bool
+
+	slots    chan struct{}
+	waiting  int
+	inFlight int
+}
+
+// RateLimitStats is a snapshot of a RateLimiter, suitable for exporting as metrics.
+type RateLimitStats struct {
+	// Rate is the current number of requests per second allowed by the limiter.
+	Rate float64
+	// Tokens is the number of requests that can be sent right now without waiting.
+	Tokens float64
+	// InFlight is the number of requests that have been sent and not yet completed.
+	InFlight int
+	// Waiting is the number of requests queued for a token or an in-flight slot.
+	Waiting int
+	// Limit and Remaining are the last values reported by the API, or -1 when unknown.
+	Limit     int
+	Remaining int
+	// NearLimit reports whether the API flagged the last response as close to the limit.
+	NearLimit bool
+	// PausedUntil is set while requests are held back after a 429 response.
+	PausedUntil time.Time
+}
+
+// NewRateLimiter returns a limiter allowing requestsPerSecond on average with
+// bursts of up to burst requests, and at most maxInFlight concurrent requests.
+// A requestsPerSecond or maxInFlight of zero disables the respective limit.
+func NewRateLimiter(requestsPerSecond float64, burst int, maxInFlight int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &RateLimiter{
+		baseRate:  requestsPerSecond,
+		rate:      requestsPerSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		last:      time.Now(),
+		limit:     -1,
+		remaining: -1,
+	}
+	if maxInFlight > 0 {
+		l.slots = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// SetRateLimiter routes every request of the client through l.
+func (c *Client) SetRateLimiter(l *RateLimiter) {
+	if c.HttpClient == nil {
+		c.HttpClient = new(http.Client)
+	}
+	c.HttpClient.Transport = l.Transport(c.HttpClient.Transport)
+}
+
+// Stats returns the current state of the limiter.
+func (l *RateLimiter) Stats() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.renewBudget(now)
+	l.refill(now)
+	return RateLimitStats{
+		Rate:        l.rate,
+		Tokens:      l.tokens,
+		InFlight:    l.inFlight,
+		Waiting:     l.waiting,
+		Limit:       l.limit,
+		Remaining:   l.remaining,
+		NearLimit:   l.nearLimit,
+		PausedUntil: l.pausedUntil,
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done. Callers of Wait must
+// call Done once the request has completed.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.waiting++
+	l.mu.Unlock()
+
+	err := l.wait(ctx)
+
+	l.mu.Lock()
+	l.waiting--
+	if err == nil {
+		l.inFlight++
+	}
+	l.mu.Unlock()
+	return err
+}
+
+func (l *RateLimiter) wait(ctx context.Context) error {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for {
+		delay := l.reserve(time.Now())
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			if l.slots != nil {
+				<-l.slots
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// reserve takes a token if one is available and otherwise returns how long to
+// wait before trying again.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.baseRate <= 0 {
+		return 0
+	}
+	l.renewBudget(now)
+	l.refill(now)
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	if l.rate <= 0 {
+		return time.Second
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// renewBudget forgets the remaining budget once its reset time has passed,
+// and restores the rate it was spread over.
+func (l *RateLimiter) renewBudget(now time.Time) {
+	if l.resetAt.IsZero() || now.Before(l.resetAt) {
+		return
+	}
+	l.resetAt = time.Time{}
+	l.remaining = -1
+	l.rate = l.baseRate
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+		l.last = now
+	}
+}
+
+// Done releases the in-flight slot taken by Wait.
+func (l *RateLimiter) Done() {
+	l.mu.Lock()
+	l.inFlight--
+	l.mu.Unlock()
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// Observe adjusts the limiter to the rate-limit information in resp.
+//
+// Bitbucket reports X-RateLimit-Limit (requests per hour) and sets
+// X-RateLimit-NearLimit once less than 20% of the budget is left; some
+// endpoints also send X-RateLimit-Remaining and X-RateLimit-Reset, in which
+// case the remaining requests are spread until the reset, and none are sent
+// once the budget is spent. A 429 response pauses all requests for
+// Retry-After, or a minute when it is absent.
+func (l *RateLimiter) Observe(resp *http.Response) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.renewBudget(now)
+	l.refill(now)
+
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil {
+		l.limit = v
+	}
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		l.remaining = v
+	}
+	if v, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		l.resetAt = time.Unix(v, 0)
+	}
+	l.nearLimit = resp.Header.Get("X-RateLimit-NearLimit") == "true"
+
+	rate := l.baseRate
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		pause := time.Minute
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			pause = time.Duration(seconds) * time.Second
+		}
+		l.pausedUntil = now.Add(pause)
+		l.tokens = 0
+		rate = l.rate / 2
+	case l.nearLimit:
+		rate = l.rate / 2
+	case l.rate < l.baseRate:
+		// Recover gradually once the API stops signalling pressure.
+		rate = l.rate * 1.25
+	}
+
+	if l.remaining == 0 && l.resetAt.After(now) {
+		// The budget is spent: wait for it to be renewed.
+		if l.resetAt.After(l.pausedUntil) {
+			l.pausedUntil = l.resetAt
+		}
+		l.tokens = 0
+	} else if l.remaining > 0 && l.resetAt.After(now) {
+		rate = math.Min(rate, float64(l.remaining)/l.resetAt.Sub(now).Seconds())
+	} else if l.limit > 0 {
+		// Without the remaining budget, backing off below the hourly limit
+		// spread evenly over the hour only slows requests down.
+		rate = math.Max(rate, float64(l.limit)/time.Hour.Seconds())
+	}
+	if l.baseRate > 0 {
+		l.rate = math.Min(rate, l.baseRate)
+	}
+}
+
+// Transport returns an http.RoundTripper applying the limiter to every request
+// before handing it to base, or http.DefaultTransport when base is nil.
+func (l *RateLimiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{limiter: l, base: base}
+}
+
+type rateLimitTransport struct {
+	limiter *RateLimiter
+	base    http.RoundTripper
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.limiter.Done()
+		return nil, err
+	}
+	t.limiter.Observe(resp)
+
+	// The request stays in flight until its body has been read or closed.
+	resp.Body = &rateLimitedBody{ReadCloser: resp.Body, done: t.limiter.Done}
+	return resp, nil
+}
+
+type rateLimitedBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *rateLimitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.once.Do(b.done)
+	}
+	return n, err
+}
+
+func (b *rateLimitedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
@@ -0,0 +1,377 @@
+// Package recorder provides an http.RoundTripper that records HTTP
+// interactions to a JSON cassette and replays them, so tests written against
+// the live Bitbucket API can run without network access or credentials.
+//
+// Credentials are never written to a cassette: authentication headers and
+// cookies are dropped, well-known secret query parameters and JSON fields are
+// redacted, and any value registered in Options.Secrets is replaced by its
+// placeholder wherever it appears.
+package recorder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects what a Recorder does with requests.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette and never touches the network.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the network and records them to the cassette.
+	ModeRecord
+	// ModePassthrough sends requests to the network without recording them.
+	ModePassthrough
+)
+
+// ParseMode parses "replay", "record" or "passthrough".
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "replay":
+		return ModeReplay, nil
+	case "record":
+		return ModeRecord, nil
+	case "passthrough", "off", "":
+		return ModePassthrough, nil
+	}
+	return 0, fmt.Errorf("recorder: unknown mode %q", s)
+}
+
+const cassetteVersion = 1
+
+// Redacted replaces secret values in recorded interactions.
+const Redacted = "REDACTED"
+
+// Cassette is the on-disk format of recorded interactions.
+type Cassette struct {
+	Version int `json:"version"`
+	// Metadata holds free-form values recorded alongside the interactions,
+	// e.g. the workspace a suite was recorded against.
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Interactions []*Interaction    `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+type Response struct {
+	Status       string      `json:"status"`
+	StatusCode   int         `json:"status_code"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// Options configures a Recorder.
+type Options struct {
+	Mode Mode
+	// Transport sends requests in record and passthrough mode. It defaults to
+	// http.DefaultTransport as of the call to New.
+	Transport http.RoundTripper
+	// Secrets maps placeholders to secret values, e.g. a username or access
+	// token. Each value is replaced by its placeholder in recorded URLs,
+	// headers and bodies, and in requests matched against the cassette.
+	Secrets map[string]string
+	// Filter reports whether a request goes through the recorder at all.
+	// Requests it rejects are sent with Transport in every mode.
+	Filter func(*http.Request) bool
+	// Scrub is called on each interaction before it is recorded.
+	Scrub func(*Interaction)
+}
+
+// Recorder is an http.RoundTripper recording to or replaying from a cassette.
+// It is safe for concurrent use.
+type Recorder struct {
+	path string
+	opts Options
+
+	mu       sync.Mutex
+	cassette *Cassette
+	// replay queues the unused interactions for each request key in recorded order.
+	replay map[string][]*Interaction
+}
+
+// New returns a Recorder for the cassette at path. In replay mode the cassette
+// must exist; in record mode it is written by Stop.
+func New(path string, opts Options) (*Recorder, error) {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, opts: opts, cassette: &Cassette{Version: cassetteVersion, Interactions: []*Interaction{}}}
+
+	if opts.Mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("recorder: %w", err)
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("recorder: invalid cassette %s: %w", path, err)
+		}
+		if r.cassette.Version != cassetteVersion {
+			return nil, fmt.Errorf("recorder: unsupported cassette version %d", r.cassette.Version)
+		}
+		r.replay = make(map[string][]*Interaction)
+		for _, interaction := range r.cassette.Interactions {
+			key := requestKey(interaction.Request.Method, interaction.Request.URL)
+			r.replay[key] = append(r.replay[key], interaction)
+		}
+	}
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.opts.Mode
+}
+
+// Metadata returns the value recorded for key.
+func (r *Recorder) Metadata(key string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Metadata[key]
+}
+
+// SetMetadata records value for key. Metadata is written with the
+// interactions, so secrets must not be stored in it.
+func (r *Recorder) SetMetadata(key, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cassette.Metadata == nil {
+		r.cassette.Metadata = make(map[string]string)
+	}
+	r.cassette.Metadata[key] = value
+}
+
+// Stop writes the cassette in record mode. It is a no-op in other modes.
+func (r *Recorder) Stop() error {
+	if r.opts.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.opts.Mode == ModePassthrough || (r.opts.Filter != nil && !r.opts.Filter(req)) {
+		return r.opts.Transport.RoundTrip(req)
+	}
+	if r.opts.Mode == ModeReplay {
+		return r.play(req)
+	}
+	return r.record(req)
+}
+
+func (r *Recorder) play(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	key := requestKey(req.Method, r.scrubString(req.URL.String()))
+
+	r.mu.Lock()
+	queue := r.replay[key]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("recorder: no recorded interaction left for %s", key)
+	}
+	interaction := queue[0]
+	r.replay[key] = queue[1:]
+	r.mu.Unlock()
+
+	body, err := decodeBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        interaction.Response.Status,
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: req.Header.Clone(),
+		},
+		Response: Response{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header.Clone(),
+		},
+	}
+	interaction.Request.Body, interaction.Request.BodyEncoding = encodeBody(reqBody)
+	interaction.Response.Body, interaction.Response.BodyEncoding = encodeBody(respBody)
+	r.scrub(interaction)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// sensitiveHeaders are dropped from recorded interactions.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveParams are query parameters whose values are redacted.
+var sensitiveParams = []string{"access_token", "refresh_token", "client_secret", "password", "jwt", "token"}
+
+var sensitiveJSONFields = regexp.MustCompile(`"(access_token|refresh_token|client_secret|password|secret|shared_secret|token)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+func (r *Recorder) scrub(interaction *Interaction) {
+	for _, name := range sensitiveHeaders {
+		interaction.Request.Headers.Del(name)
+		interaction.Response.Headers.Del(name)
+	}
+
+	interaction.Request.URL = r.scrubString(interaction.Request.URL)
+	for _, headers := range []http.Header{interaction.Request.Headers, interaction.Response.Headers} {
+		for name, values := range headers {
+			for i, value := range values {
+				values[i] = r.scrubString(value)
+			}
+			headers[name] = values
+		}
+	}
+	if interaction.Request.BodyEncoding == "" {
+		interaction.Request.Body = r.scrubBody(interaction.Request.Body)
+	}
+	if interaction.Response.BodyEncoding == "" {
+		interaction.Response.Body = r.scrubBody(interaction.Response.Body)
+	}
+
+	if r.opts.Scrub != nil {
+		r.opts.Scrub(interaction)
+	}
+}
+
+func (r *Recorder) scrubBody(body string) string {
+	body = sensitiveJSONFields.ReplaceAllString(body, `"$1"$2"`+Redacted+`"`)
+	return r.scrubString(body)
+}
+
+// scrubString replaces registered secrets by their placeholders and redacts
+// sensitive query parameters if s is a URL.
+func (r *Recorder) scrubString(s string) string {
+	// Replace longer secrets first, in case one contains another.
+	placeholders := make([]string, 0, len(r.opts.Secrets))
+	for placeholder, secret := range r.opts.Secrets {
+		if secret != "" {
+			placeholders = append(placeholders, placeholder)
+		}
+	}
+	sort.Slice(placeholders, func(i, j int) bool {
+		return len(r.opts.Secrets[placeholders[i]]) > len(r.opts.Secrets[placeholders[j]])
+	})
+	for _, placeholder := range placeholders {
+		secret := r.opts.Secrets[placeholder]
+		s = strings.ReplaceAll(s, secret, placeholder)
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			s = strings.ReplaceAll(s, escaped, placeholder)
+		}
+	}
+
+	if u, err := url.Parse(s); err == nil && u.Scheme != "" && u.RawQuery != "" {
+		query := u.Query()
+		changed := false
+		for _, name := range sensitiveParams {
+			if query.Has(name) {
+				query.Set(name, Redacted)
+				changed = true
+			}
+		}
+		if changed {
+			u.RawQuery = query.Encode()
+			s = u.String()
+		}
+	}
+	return s
+}
+
+// requestKey identifies a request for replay. The query is normalized so
+// parameter order does not matter.
+func requestKey(method, rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		u.RawQuery = u.Query().Encode()
+		rawURL = u.String()
+	}
+	return method + " " + rawURL
+}
+
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+func decodeBody(body, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return []byte(body), nil
+	case "base64":
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return nil, fmt.Errorf("recorder: unknown body encoding %q", encoding)
+}
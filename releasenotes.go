@@ -0,0 +1,431 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// releaseNotesConcurrency is the number of pull request lookups ReleaseNotes
+// sends at once.
+const releaseNotesConcurrency = 4
+
+// ReleaseNoteType is a section of release notes.
+type ReleaseNoteType struct {
+	Type  string
+	Title string
+}
+
+// DefaultReleaseNoteTypes are the sections of release notes, in order, when
+// ReleaseNotesOptions.Types is not set. Changes of other types are listed
+// under "Other Changes".
+var DefaultReleaseNoteTypes = []ReleaseNoteType{
+	{Type: "feat", Title: "Features"},
+	{Type: "fix", Title: "Bug Fixes"},
+	{Type: "perf", Title: "Performance Improvements"},
+	{Type: "refactor", Title: "Refactoring"},
+	{Type: "docs", Title: "Documentation"},
+}
+
+const otherReleaseNoteType = "other"
+
+// ReleaseNotes are the changes between two tags, grouped in sections.
+type ReleaseNotes struct {
+	Repository string                `json:"repository"`
+	From       string                `json:"from,omitempty"`
+	To         string                `json:"to"`
+	FromCommit string                `json:"from_commit,omitempty"`
+	ToCommit   string                `json:"to_commit"`
+	Date       *time.Time            `json:"date,omitempty"`
+	Sections   []ReleaseNotesSection `json:"sections"`
+}
+
+// ReleaseNotesSection lists the changes of one type.
+type ReleaseNotesSection struct {
+	Type  string         `json:"type"`
+	Title string         `json:"title"`
+	Notes []*ReleaseNote `json:"notes"`
+}
+
+// ReleaseNote is a merged pull request, or a commit that was not part of any.
+type ReleaseNote struct {
+	// Type and Scope come from the conventional commit prefix of the pull
+	// request title or commit message, e.g. "feat(api)!: Add tokens". Type is
+	// "other" without a prefix.
+	Type     string `json:"type"`
+	Scope    string `json:"scope,omitempty"`
+	Breaking bool   `json:"breaking"`
+	// Summary is the title or first line of the message, without the prefix.
+	Summary     string                  `json:"summary"`
+	PullRequest *ReleaseNotePullRequest `json:"pull_request,omitempty"`
+	// Commits are the hashes of the commits of the change, newest first.
+	Commits []string `json:"commits"`
+	Author  string   `json:"author,omitempty"`
+}
+
+// ReleaseNotePullRequest is the merged pull request of a ReleaseNote.
+type ReleaseNotePullRequest struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+	URL    string `json:"url"`
+}
+
+var conventionalCommitRe = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// ReleaseNotes collects the changes between ReleaseNotesOptions.From and
+// ReleaseNotesOptions.To: the merged pull requests their commits belong to,
+// and the commits that were pushed without one. The notes can be rendered
+// with Render, or encoded to JSON.
+//
+// Pull requests are looked up for every commit, which requires the pull
+// request commit links of the repository to be indexed. When they are not,
+// the commits are listed on their own.
+//
+// The API doesn't return the labels of pull requests, so changes can only be
+// grouped by label through ReleaseNotesOptions.Classify.
+func (r *Repository) ReleaseNotes(rno *ReleaseNotesOptions) (*ReleaseNotes, error) {
+	to, err := r.findTag(rno, rno.To)
+	if err != nil {
+		return nil, err
+	}
+	from := rno.From
+	var fromTag *RepositoryTag
+	if from != "" {
+		if fromTag, err = r.findTag(rno, from); err != nil {
+			return nil, err
+		}
+	} else if fromTag, err = r.previousTag(rno, to); err != nil {
+		return nil, err
+	} else if fromTag != nil {
+		from = fromTag.Name
+	}
+
+	notes := &ReleaseNotes{
+		Repository: rno.Owner + "/" + rno.RepoSlug,
+		From:       from,
+		To:         rno.To,
+		ToCommit:   tagTargetHash(to),
+		Date:       tagTargetDate(to),
+	}
+	if fromTag != nil {
+		notes.FromCommit = tagTargetHash(fromTag)
+	}
+
+	var commits []*Commit
+	urlStr := r.c.commitRangeUrl(rno.Owner, rno.RepoSlug, notes.ToCommit, notes.FromCommit, nil)
+	for commit, err := range iteratePages(r.c, rno.ctx, urlStr, decodeCommit) {
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+
+	pullRequests, err := r.mergedPullRequests(rno, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*ReleaseNote
+	byPullRequest := map[int]*ReleaseNote{}
+	for i, commit := range commits {
+		if pr := pullRequests[i]; pr != nil {
+			if note, ok := byPullRequest[pr.ID]; ok {
+				note.Commits = append(note.Commits, commit.Hash)
+				continue
+			}
+			note := newReleaseNote(pr.Title)
+			note.PullRequest = pr
+			note.Author = pr.Author
+			note.Commits = []string{commit.Hash}
+			byPullRequest[pr.ID] = note
+			changes = append(changes, note)
+			continue
+		}
+
+		// Merge commits of pull requests that were not found add nothing.
+		if len(commit.Parents) > 1 {
+			continue
+		}
+		note := newReleaseNote(commit.Message)
+		note.Commits = []string{commit.Hash}
+		note.Author = commitAuthorName(commit)
+		if strings.Contains(commit.Message, "\nBREAKING CHANGE:") || strings.Contains(commit.Message, "\nBREAKING-CHANGE:") {
+			note.Breaking = true
+		}
+		changes = append(changes, note)
+	}
+
+	if rno.Classify != nil {
+		for _, note := range changes {
+			note.Type = rno.Classify(note)
+		}
+	}
+	notes.Sections = releaseNotesSections(rno.Types, changes)
+	return notes, nil
+}
+
+func (r *Repository) findTag(rno *ReleaseNotesOptions, name string) (*RepositoryTag, error) {
+	if name == "" {
+		return nil, errors.New("a tag is required")
+	}
+	tags, err := r.ListTags((&RepositoryTagOptions{
+		Owner: rno.Owner, RepoSlug: rno.RepoSlug, Query: fmt.Sprintf("name = %q", name),
+	}).WithContext(rno.ctx))
+	if err != nil {
+		return nil, err
+	}
+	for i := range tags.Tags {
+		if tags.Tags[i].Name == name {
+			return &tags.Tags[i], nil
+		}
+	}
+	return nil, fmt.Errorf("tag %q not found", name)
+}
+
+// previousTag returns the most recent tag whose commit is older than the one
+// of tag, or nil if there is none. Only the first tag of the first page is
+// needed, so a single tag is requested rather than listing every older one.
+func (r *Repository) previousTag(rno *ReleaseNotesOptions, tag *RepositoryTag) (*RepositoryTag, error) {
+	date := tagTargetDate(tag)
+	if date == nil {
+		return nil, fmt.Errorf("tag %q has no date", tag.Name)
+	}
+	params := url.Values{}
+	params.Set("q", fmt.Sprintf("target.date < %s", date.UTC().Format(time.RFC3339)))
+	params.Set("sort", "-target.date")
+	params.Set("pagelen", "1")
+	urlStr := r.c.requestUrl("/repositories/%s/%s/refs/tags?%s", rno.Owner, rno.RepoSlug, params.Encode())
+	page := 1
+	response, err := r.c.executePaginatedWithContext("GET", urlStr, "", &page, rno.ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := decodeRepositoryTags(response)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tags.Tags {
+		if tags.Tags[i].Name != tag.Name && tagTargetHash(&tags.Tags[i]) != tagTargetHash(tag) {
+			return &tags.Tags[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// mergedPullRequests returns the merged pull request each commit belongs to,
+// or nil for commits that were not part of one. A 404 means the commit links
+// of the repository are not indexed, so no lookup can find a pull request and
+// the remaining ones are skipped.
+func (r *Repository) mergedPullRequests(rno *ReleaseNotesOptions, commits []*Commit) ([]*ReleaseNotePullRequest, error) {
+	ctx := rno.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pullRequests := &PullRequests{c: r.c}
+	results := make([]*ReleaseNotePullRequest, len(commits))
+	var (
+		mu         sync.Mutex
+		firstErr   error
+		notIndexed atomic.Bool
+		wg         sync.WaitGroup
+	)
+	indexes := make(chan int)
+	for range min(releaseNotesConcurrency, len(commits)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if notIndexed.Load() {
+					continue
+				}
+				response, err := pullRequests.GetByCommit((&PullRequestsOptions{
+					Owner: rno.Owner, RepoSlug: rno.RepoSlug, Commit: commits[i].Hash,
+				}).WithContext(ctx))
+				if err == nil {
+					results[i], err = decodeMergedPullRequest(response)
+				}
+				if isNotFound(err) {
+					notIndexed.Store(true)
+					continue
+				}
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+send:
+	for i := range commits {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// decodeMergedPullRequest returns the first merged pull request of a commit.
+func decodeMergedPullRequest(response interface{}) (*ReleaseNotePullRequest, error) {
+	responseMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+	var page struct {
+		Values []struct {
+			ID     int
+			Title  string
+			State  string
+			Author struct {
+				DisplayName string `mapstructure:"display_name"`
+			}
+			Links struct {
+				Html struct {
+					Href string
+				}
+			}
+		}
+	}
+	if err := mapstructure.Decode(responseMap, &page); err != nil {
+		return nil, err
+	}
+
+	var merged *ReleaseNotePullRequest
+	for _, pr := range page.Values {
+		if pr.State != "MERGED" || merged != nil && merged.ID < pr.ID {
+			continue
+		}
+		merged = &ReleaseNotePullRequest{ID: pr.ID, Title: pr.Title, Author: pr.Author.DisplayName, URL: pr.Links.Html.Href}
+	}
+	return merged, nil
+}
+
+func newReleaseNote(message string) *ReleaseNote {
+	summary, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	summary = strings.TrimSpace(summary)
+	note := &ReleaseNote{Type: otherReleaseNoteType, Summary: summary}
+	if m := conventionalCommitRe.FindStringSubmatch(summary); m != nil {
+		note.Type = strings.ToLower(m[1])
+		note.Scope = m[2]
+		note.Breaking = m[3] == "!"
+		note.Summary = m[4]
+	}
+	return note
+}
+
+func commitAuthorName(commit *Commit) string {
+	if commit.Author.User != nil && commit.Author.User.DisplayName != "" {
+		return commit.Author.User.DisplayName
+	}
+	name, _, _ := strings.Cut(commit.Author.Raw, " <")
+	return name
+}
+
+func releaseNotesSections(types []ReleaseNoteType, notes []*ReleaseNote) []ReleaseNotesSection {
+	if types == nil {
+		types = DefaultReleaseNoteTypes
+	}
+	index := map[string]int{}
+	sections := make([]ReleaseNotesSection, 0, len(types)+1)
+	for _, t := range types {
+		index[t.Type] = len(sections)
+		sections = append(sections, ReleaseNotesSection{Type: t.Type, Title: t.Title})
+	}
+	if _, ok := index[otherReleaseNoteType]; !ok {
+		index[otherReleaseNoteType] = len(sections)
+		sections = append(sections, ReleaseNotesSection{Type: otherReleaseNoteType, Title: "Other Changes"})
+	}
+
+	for _, note := range notes {
+		i, ok := index[note.Type]
+		if !ok {
+			i = index[otherReleaseNoteType]
+		}
+		sections[i].Notes = append(sections[i].Notes, note)
+	}
+
+	// Breaking changes come first in their section.
+	result := sections[:0]
+	for _, section := range sections {
+		if len(section.Notes) == 0 {
+			continue
+		}
+		sort.SliceStable(section.Notes, func(i, j int) bool {
+			return section.Notes[i].Breaking && !section.Notes[j].Breaking
+		})
+		result = append(result, section)
+	}
+	return result
+}
+
+// DefaultReleaseNotesTemplate renders release notes as Markdown.
+var DefaultReleaseNotesTemplate = template.Must(template.New("release-notes").Parse(
+	`## {{.To}}{{with .Date}} ({{.Format "2006-01-02"}}){{end}}
+{{range .Sections}}
+### {{.Title}}
+
+{{range .Notes}}- {{if .Breaking}}**BREAKING:** {{end}}{{with .Scope}}**{{.}}:** {{end}}{{.Summary}}
+{{- with .PullRequest}} ([#{{.ID}}]({{.URL}})){{else}} ({{printf "%.7s" (index .Commits 0)}}){{end}}
+{{end}}{{end}}`))
+
+// Render writes the release notes with a text template, or with
+// DefaultReleaseNotesTemplate when tmpl is nil.
+func (n *ReleaseNotes) Render(w io.Writer, tmpl *template.Template) error {
+	if tmpl == nil {
+		tmpl = DefaultReleaseNotesTemplate
+	}
+	return tmpl.Execute(w, n)
+}
+
+// Markdown returns the release notes rendered with
+// DefaultReleaseNotesTemplate.
+func (n *ReleaseNotes) Markdown() (string, error) {
+	var b strings.Builder
+	err := n.Render(&b, nil)
+	return b.String(), err
+}
+
+func tagTargetHash(tag *RepositoryTag) string {
+	if tag.Target == nil {
+		return ""
+	}
+	return tag.Target.Hash
+}
+
+func tagTargetDate(tag *RepositoryTag) *time.Time {
+	if tag.Target == nil || tag.Target.Date.IsZero() {
+		return nil
+	}
+	date := tag.Target.Date
+	return &date
+}
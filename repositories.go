@@ -20,7 +20,6 @@ type Repositories struct {
 	Webhooks           *Webhooks
 	Downloads          *Downloads
 	DeployKeys         *DeployKeys
-	repositories
 }
 
 type RepositoriesRes struct {
@@ -41,6 +40,7 @@ func (r *Repositories) ListForAccount(ro *RepositoriesOptions) (*RepositoriesRes
 		return nil, err
 	}
 	q := urlAsUrl.Query()
+	r.c.addFieldsParam(&q, ro.Fields, true)
 	if ro.Role != "" {
 		q.Set("role", ro.Role)
 	}
@@ -67,6 +67,10 @@ func (r *Repositories) ListForTeam(ro *RepositoriesOptions) (*RepositoriesRes, e
 func (r *Repositories) ListProject(ro *RepositoriesOptions) (*RepositoriesRes, error) {
 	urlPath := r.c.requestUrl("/repositories")
 	urlPath += fmt.Sprintf("/%s/?q=project.key=\"%s\"", ro.Owner, ro.Project)
+	urlPath, err := r.c.withFieldsParam(urlPath, ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 	repos, err := r.c.executePaginated("GET", urlPath, "", nil)
 	if err != nil {
 		return nil, err
@@ -89,7 +93,7 @@ func decodeRepositories(reposResponse interface{}) (*RepositoriesRes, error) {
 		return nil, errors.New("Not a valid format")
 	}
 
-	repoArray := reposResponseMap["values"].([]interface{})
+	repoArray, _ := reposResponseMap["values"].([]interface{})
 	var repos []Repository
 	for _, repoEntry := range repoArray {
 		repo, err := decodeRepository(repoEntry)
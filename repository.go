@@ -61,7 +61,23 @@ type RepositoryRefs struct {
 	MaxDepth int
 	Size     int
 	Next     string
-	Refs     []map[string]interface{}
+	Refs     []Ref
+}
+
+// RefKind tells whether a Ref is a branch or a tag.
+type RefKind string
+
+const (
+	RefKindBranch RefKind = "branch"
+	RefKindTag    RefKind = "tag"
+)
+
+// Ref is a branch or a tag listed by ListRefs.
+type Ref struct {
+	Kind   RefKind `mapstructure:"type"`
+	Name   string
+	Target *Commit
+	Links  map[string]interface{}
 }
 
 type RepositoryBranches struct {
@@ -79,7 +95,7 @@ type RepositoryBranch struct {
 	Default_Merge_Strategy string
 	Merge_Strategies       []string
 	Links                  map[string]interface{}
-	Target                 map[string]interface{}
+	Target                 *Commit
 	Heads                  []map[string]interface{}
 }
 
@@ -96,8 +112,12 @@ type RepositoryTag struct {
 	Type   string
 	Name   string
 	Links  map[string]interface{}
-	Target map[string]interface{}
+	Target *Commit
 	Heads  []map[string]interface{}
+	// Message, Date and Tagger are only set for annotated tags.
+	Message string
+	Date    time.Time
+	Tagger  CommitAuthor
 }
 
 type Pipeline struct {
@@ -299,8 +319,11 @@ func (r *Repository) Fork(fo *RepositoryForkOptions) (*Repository, error) {
 }
 
 func (r *Repository) Get(ro *RepositoryOptions) (*Repository, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s", ro.Owner, ro.RepoSlug)
-	response, err := r.c.execute("GET", urlStr, "")
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s", ro.Owner, ro.RepoSlug), ro.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.c.executeWithContext("GET", urlStr, "", ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -308,7 +331,7 @@ func (r *Repository) Get(ro *RepositoryOptions) (*Repository, error) {
 	return decodeRepository(response)
 }
 
-func (r *Repository) buildContentsURL(ro *RepositoryFilesOptions) (string, error) {
+func (r *Repository) buildContentsURL(ro *RepositoryFilesOptions, paginated bool) (string, error) {
 	urlPath := "/repositories/%s/%s/src/%s/%s"
 
 	urlStr := r.c.requestUrl(urlPath, ro.Owner, ro.RepoSlug, ro.Ref, ro.Path)
@@ -319,18 +342,19 @@ func (r *Repository) buildContentsURL(ro *RepositoryFilesOptions) (string, error
 
 	query := parsedUrl.Query()
 	r.c.addMaxDepthParam(&query, &ro.MaxDepth)
+	r.c.addFieldsParam(&query, ro.Fields, paginated)
 	parsedUrl.RawQuery = query.Encode()
 
 	return parsedUrl.String(), nil
 }
 
 func (r *Repository) GetFileContent(ro *RepositoryFilesOptions) ([]byte, error) {
-	urlStr, err := r.buildContentsURL(ro)
+	urlStr, err := r.buildContentsURL(ro, false)
 	if err != nil {
 		return nil, err
 	}
 
-	resBody, err := r.c.executeRaw("GET", urlStr, "")
+	resBody, err := r.c.executeRawWithContext("GET", urlStr, "", ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -340,12 +364,12 @@ func (r *Repository) GetFileContent(ro *RepositoryFilesOptions) ([]byte, error)
 }
 
 func (r *Repository) ListFiles(ro *RepositoryFilesOptions) ([]RepositoryFile, error) {
-	urlStr, err := r.buildContentsURL(ro)
+	urlStr, err := r.buildContentsURL(ro, true)
 	if err != nil {
 		return nil, err
 	}
 
-	response, err := r.c.executePaginated("GET", urlStr, "", nil)
+	response, err := r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -427,6 +451,8 @@ func (r *Repository) ListRefs(rbo *RepositoryRefOptions) (*RepositoryRefs, error
 		params.Add("max_depth", strconv.Itoa(rbo.MaxDepth))
 	}
 
+	r.c.addFieldsParam(&params, rbo.Fields, false)
+
 	urlStr := r.c.requestUrl("/repositories/%s/%s/refs?%s", rbo.Owner, rbo.RepoSlug, params.Encode())
 	response, err := r.c.executeRaw("GET", urlStr, "")
 	if err != nil {
@@ -469,6 +495,8 @@ func (r *Repository) ListBranches(rbo *RepositoryBranchOptions) (*RepositoryBran
 		r.c.addMaxDepthParam(&params, &rbo.MaxDepth)
 	}
 
+	r.c.addFieldsParam(&params, rbo.Fields, false)
+
 	urlStr := r.c.requestUrl("/repositories/%s/%s/refs/branches?%s", rbo.Owner, rbo.RepoSlug, params.Encode())
 	response, err := r.c.executeRaw("GET", urlStr, "")
 	if err != nil {
@@ -486,7 +514,10 @@ func (r *Repository) GetBranch(rbo *RepositoryBranchOptions) (*RepositoryBranch,
 	if rbo.BranchName == "" {
 		return nil, errors.New("Error: Branch Name is empty")
 	}
-	urlStr := r.c.requestUrl("/repositories/%s/%s/refs/branches/%s", rbo.Owner, rbo.RepoSlug, rbo.BranchName)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/refs/branches/%s", rbo.Owner, rbo.RepoSlug, rbo.BranchName), rbo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	response, err := r.c.executeRaw("GET", urlStr, "")
 	if err != nil {
 		return nil, err
@@ -510,7 +541,7 @@ func (r *Repository) DeleteBranch(rbo *RepositoryBranchDeleteOptions) error {
 		ref = rbo.RefUUID
 	}
 	urlStr := r.c.requestUrl("/repositories/%s/%s/refs/branches/%s", rbo.Owner, repo, ref)
-	_, err := r.c.execute("DELETE", urlStr, "")
+	_, err := r.c.executeWithContext("DELETE", urlStr, "", rbo.ctx)
 	return err
 }
 
@@ -558,8 +589,10 @@ func (r *Repository) ListTags(rbo *RepositoryTagOptions) (*RepositoryTags, error
 		params.Add("max_depth", strconv.Itoa(rbo.MaxDepth))
 	}
 
+	r.c.addFieldsParam(&params, rbo.Fields, true)
+
 	urlStr := r.c.requestUrl("/repositories/%s/%s/refs/tags?%s", rbo.Owner, rbo.RepoSlug, params.Encode())
-	response, err := r.c.executePaginated("GET", urlStr, "", nil)
+	response, err := r.c.executePaginatedWithContext("GET", urlStr, "", nil, rbo.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -587,6 +620,72 @@ func (r *Repository) CreateTag(rbo *RepositoryTagCreationOptions) (*RepositoryTa
 	return decodeRepositoryTagCreated(bodyString)
 }
 
+// GetTag https://developer.atlassian.com/cloud/bitbucket/rest/api-group-refs/#api-repositories-workspace-repo-slug-refs-tags-name-get
+func (r *Repository) GetTag(rbo *RepositoryTagOptions) (*RepositoryTag, error) {
+	if rbo.Name == "" {
+		return nil, errors.New("Error: Tag Name is empty")
+	}
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/refs/tags/%s", rbo.Owner, rbo.RepoSlug, url.PathEscape(rbo.Name)), rbo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.c.executeRawWithContext("GET", urlStr, "", rbo.ctx)
+	if err != nil {
+		return nil, err
+	}
+	bodyBytes, err := io.ReadAll(response)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRepositoryTag(string(bodyBytes))
+}
+
+// DeleteTag https://developer.atlassian.com/cloud/bitbucket/rest/api-group-refs/#api-repositories-workspace-repo-slug-refs-tags-name-delete
+func (r *Repository) DeleteTag(rbo *RepositoryTagOptions) error {
+	if rbo.Name == "" {
+		return errors.New("Error: Tag Name is empty")
+	}
+	urlStr := r.c.requestUrl("/repositories/%s/%s/refs/tags/%s", rbo.Owner, rbo.RepoSlug, url.PathEscape(rbo.Name))
+	_, err := r.c.executeWithContext("DELETE", urlStr, "", rbo.ctx)
+	return err
+}
+
+// FindTagsForCommit returns the tags pointing at a commit, whose hash may be
+// abbreviated to no less than 7 characters. The other options filter and sort
+// the tags like for ListTags, except for PageNum: every page of matching tags
+// is read, up to the LimitPages of the client.
+func (r *Repository) FindTagsForCommit(rbo *RepositoryTagOptions, commit string) ([]RepositoryTag, error) {
+	if commit == "" {
+		return nil, errors.New("Error: Commit is empty")
+	}
+	if len(commit) < 7 {
+		return nil, fmt.Errorf("commit %q is too short, at least 7 characters are required", commit)
+	}
+	// Full hashes are matched exactly, and abbreviated ones by prefix below.
+	query := fmt.Sprintf("target.hash = %q", commit)
+	if len(commit) < 40 {
+		query = fmt.Sprintf("target.hash ~ %q", commit)
+	}
+	if rbo.Query != "" {
+		query = fmt.Sprintf("(%s) AND %s", rbo.Query, query)
+	}
+	opts := *rbo
+	opts.Query = query
+	opts.PageNum = 0
+
+	tags, err := r.ListTags(&opts)
+	if err != nil {
+		return nil, err
+	}
+	var found []RepositoryTag
+	for _, tag := range tags.Tags {
+		if tag.Target != nil && strings.HasPrefix(tag.Target.Hash, commit) {
+			found = append(found, tag)
+		}
+	}
+	return found, nil
+}
+
 func (r *Repository) Update(ro *RepositoryOptions) (*Repository, error) {
 	data, err := r.buildRepositoryBody(ro)
 	if err != nil {
@@ -614,19 +713,28 @@ func (r *Repository) Delete(ro *RepositoryOptions) (interface{}, error) {
 }
 
 func (r *Repository) ListWatchers(ro *RepositoryOptions) (interface{}, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/watchers", ro.Owner, ro.RepoSlug)
-	return r.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/watchers", ro.Owner, ro.RepoSlug), ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 }
 
 func (r *Repository) ListForks(ro *RepositoryOptions) (interface{}, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/forks", ro.Owner, ro.RepoSlug)
-	return r.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/forks", ro.Owner, ro.RepoSlug), ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 }
 
 func (r *Repository) ListDefaultReviewers(ro *RepositoryOptions) (*DefaultReviewers, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/default-reviewers?pagelen=1", ro.Owner, ro.RepoSlug)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/default-reviewers?pagelen=1", ro.Owner, ro.RepoSlug), ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := r.c.executePaginated("GET", urlStr, "", nil)
+	res, err := r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -634,7 +742,10 @@ func (r *Repository) ListDefaultReviewers(ro *RepositoryOptions) (*DefaultReview
 }
 
 func (r *Repository) GetDefaultReviewer(rdro *RepositoryDefaultReviewerOptions) (*DefaultReviewer, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/default-reviewers/%s", rdro.Owner, rdro.RepoSlug, rdro.Username)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/default-reviewers/%s", rdro.Owner, rdro.RepoSlug, rdro.Username), rdro.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	res, err := r.c.execute("GET", urlStr, "")
 	if err != nil {
 		return nil, fmt.Errorf("unable to get default reviewer: %w", err)
@@ -657,9 +768,12 @@ func (r *Repository) DeleteDefaultReviewer(rdro *RepositoryDefaultReviewerOption
 }
 
 func (r *Repository) ListEffectiveDefaultReviewers(ro *RepositoryOptions) (*EffectiveDefaultReviewers, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/effective-default-reviewers", ro.Owner, ro.RepoSlug)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/effective-default-reviewers", ro.Owner, ro.RepoSlug), ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := r.c.executePaginated("GET", urlStr, "", nil)
+	res, err := r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -667,7 +781,10 @@ func (r *Repository) ListEffectiveDefaultReviewers(ro *RepositoryOptions) (*Effe
 }
 
 func (r *Repository) GetPipelineConfig(rpo *RepositoryPipelineOptions) (*Pipeline, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/pipelines_config", rpo.Owner, rpo.RepoSlug)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/pipelines_config", rpo.Owner, rpo.RepoSlug), rpo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	response, err := r.c.execute("GET", urlStr, "")
 	if err != nil {
 		return nil, fmt.Errorf("unable to get pipeline config: %w", err)
@@ -712,6 +829,8 @@ func (r *Repository) ListPipelineVariables(opt *RepositoryPipelineVariablesOptio
 		params.Add("max_depth", strconv.Itoa(opt.MaxDepth))
 	}
 
+	r.c.addFieldsParam(&params, opt.Fields, false)
+
 	urlStr := r.c.requestUrl("/repositories/%s/%s/pipelines_config/variables/?%s", opt.Owner, opt.RepoSlug, params.Encode())
 	response, err := r.c.executeRaw("GET", urlStr, "")
 	if err != nil {
@@ -746,8 +865,11 @@ func (r *Repository) DeletePipelineVariable(opt *RepositoryPipelineVariableDelet
 }
 
 func (r *Repository) GetPipelineVariable(opt *RepositoryPipelineVariableOptions) (*PipelineVariable, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/pipelines_config/variables/%s", opt.Owner, opt.RepoSlug, opt.Uuid)
-	response, err := r.c.execute("GET", urlStr, "")
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/pipelines_config/variables/%s", opt.Owner, opt.RepoSlug, opt.Uuid), opt.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.c.executeWithContext("GET", urlStr, "", opt.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -768,7 +890,10 @@ func (r *Repository) UpdatePipelineVariable(opt *RepositoryPipelineVariableOptio
 }
 
 func (r *Repository) GetPipelineKeyPair(rpkpo *RepositoryPipelineKeyPairOptions) (*PipelineKeyPair, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/pipelines_config/ssh/key_pair", rpkpo.Owner, rpkpo.RepoSlug)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/pipelines_config/ssh/key_pair", rpkpo.Owner, rpkpo.RepoSlug), rpkpo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 
 	response, err := r.c.execute("GET", urlStr, "")
 	if err != nil {
@@ -814,8 +939,11 @@ func (r *Repository) UpdatePipelineBuildNumber(rpbno *RepositoryPipelineBuildNum
 }
 
 func (r *Repository) BranchingModel(rbmo *RepositoryBranchingModelOptions) (*BranchingModel, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/branching-model", rbmo.Owner, rbmo.RepoSlug)
-	response, err := r.c.execute("GET", urlStr, "")
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/branching-model", rbmo.Owner, rbmo.RepoSlug), rbmo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.c.executeWithContext("GET", urlStr, "", rbmo.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -823,7 +951,10 @@ func (r *Repository) BranchingModel(rbmo *RepositoryBranchingModelOptions) (*Bra
 }
 
 func (r *Repository) ListEnvironments(opt *RepositoryEnvironmentsOptions) (*Environments, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/environments/", opt.Owner, opt.RepoSlug)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/environments/", opt.Owner, opt.RepoSlug), opt.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	res, err := r.c.executeRaw("GET", urlStr, "")
 	if err != nil {
 		return nil, err
@@ -858,8 +989,11 @@ func (r *Repository) DeleteEnvironment(opt *RepositoryEnvironmentDeleteOptions)
 }
 
 func (r *Repository) GetEnvironment(opt *RepositoryEnvironmentOptions) (*Environment, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/environments/%s", opt.Owner, opt.RepoSlug, opt.Uuid)
-	res, err := r.c.execute("GET", urlStr, "")
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/environments/%s", opt.Owner, opt.RepoSlug, opt.Uuid), opt.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.c.executeWithContext("GET", urlStr, "", opt.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -889,6 +1023,8 @@ func (r *Repository) ListDeploymentVariables(opt *RepositoryDeploymentVariablesO
 		params.Add("max_depth", strconv.Itoa(opt.MaxDepth))
 	}
 
+	r.c.addFieldsParam(&params, opt.Fields, false)
+
 	urlStr := r.c.requestUrl("/repositories/%s/%s/deployments_config/environments/%s/variables?%s", opt.Owner, opt.RepoSlug, opt.Environment.Uuid, params.Encode())
 	response, err := r.c.executeRaw("GET", urlStr, "")
 	if err != nil {
@@ -938,9 +1074,12 @@ func (r *Repository) UpdateDeploymentVariable(opt *RepositoryDeploymentVariableO
 }
 
 func (r *Repository) ListGroupPermissions(ro *RepositoryOptions) (*GroupPermissions, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/permissions-config/groups?pagelen=1", ro.Owner, ro.RepoSlug)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/permissions-config/groups?pagelen=1", ro.Owner, ro.RepoSlug), ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := r.c.executePaginated("GET", urlStr, "", nil)
+	res, err := r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -969,7 +1108,10 @@ func (r *Repository) DeleteGroupPermissions(rgo *RepositoryGroupPermissionsOptio
 }
 
 func (r *Repository) GetGroupPermissions(rgo *RepositoryGroupPermissionsOptions) (*GroupPermission, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/permissions-config/groups/%s", rgo.Owner, rgo.RepoSlug, rgo.Group)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/permissions-config/groups/%s", rgo.Owner, rgo.RepoSlug, rgo.Group), rgo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := r.c.executePaginated("GET", urlStr, "", nil)
 	if err != nil {
@@ -979,9 +1121,12 @@ func (r *Repository) GetGroupPermissions(rgo *RepositoryGroupPermissionsOptions)
 }
 
 func (r *Repository) ListUserPermissions(ro *RepositoryOptions) (*UserPermissions, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/permissions-config/users?pagelen=1", ro.Owner, ro.RepoSlug)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/permissions-config/users?pagelen=1", ro.Owner, ro.RepoSlug), ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 
-	res, err := r.c.executePaginated("GET", urlStr, "", nil)
+	res, err := r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1010,7 +1155,10 @@ func (r *Repository) DeleteUserPermissions(rgo *RepositoryUserPermissionsOptions
 }
 
 func (r *Repository) GetUserPermissions(rgo *RepositoryUserPermissionsOptions) (*UserPermission, error) {
-	urlStr := r.c.requestUrl("/repositories/%s/%s/permissions-config/users/%s", rgo.Owner, rgo.RepoSlug, rgo.User)
+	urlStr, err := r.c.withFieldsParam(r.c.requestUrl("/repositories/%s/%s/permissions-config/users/%s", rgo.Owner, rgo.RepoSlug, rgo.User), rgo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 
 	res, err := r.c.executePaginated("GET", urlStr, "", nil)
 	if err != nil {
@@ -1170,6 +1318,9 @@ func (r *Repository) buildTagBody(rbo *RepositoryTagCreationOptions) (string, er
 			"hash": rbo.Target.Hash,
 		},
 	}
+	if rbo.Message != "" {
+		body["message"] = rbo.Message
+	}
 
 	return r.buildJsonBody(body)
 }
@@ -1277,12 +1428,12 @@ func decodeRepositoryRefs(refResponseStr string) (*RepositoryRefs, error) {
 		return nil, err
 	}
 
-	refArray := refResponseMap["values"].([]interface{})
-	var refs []map[string]interface{}
+	refArray, _ := refResponseMap["values"].([]interface{})
+	var refs []Ref
 	for _, refEntry := range refArray {
-		var ref map[string]interface{}
-		err = mapstructure.Decode(refEntry, &ref)
-		if err == nil {
+		var ref Ref
+		// Entries that can't be decoded are skipped.
+		if err := decodeWithTimeHook(refEntry, &ref); err == nil {
 			refs = append(refs, ref)
 		}
 	}
@@ -1329,12 +1480,12 @@ func decodeRepositoryBranches(branchResponseStr string) (*RepositoryBranches, er
 		return nil, err
 	}
 
-	branchArray := branchResponseMap["values"].([]interface{})
+	branchArray, _ := branchResponseMap["values"].([]interface{})
 	var branches []RepositoryBranch
 	for _, branchEntry := range branchArray {
 		var branch RepositoryBranch
-		err = mapstructure.Decode(branchEntry, &branch)
-		if err == nil {
+		// Entries that can't be decoded are skipped.
+		if err := decodeWithTimeHook(branchEntry, &branch); err == nil {
 			branches = append(branches, branch)
 		}
 	}
@@ -1381,7 +1532,7 @@ func decodeRepositoryBranch(branchResponseStr string) (*RepositoryBranch, error)
 		return nil, err
 	}
 	var repositoryBranch RepositoryBranch
-	err = mapstructure.Decode(branchResponseMap, &repositoryBranch)
+	err = decodeWithTimeHook(branchResponseMap, &repositoryBranch)
 	if err != nil {
 		return nil, err
 	}
@@ -1389,31 +1540,35 @@ func decodeRepositoryBranch(branchResponseStr string) (*RepositoryBranch, error)
 }
 
 func decodeRepositoryBranchCreated(branchResponseStr string) (*RepositoryBranch, error) {
-	var responseBranchCreated RepositoryBranch
-	err := json.Unmarshal([]byte(branchResponseStr), &responseBranchCreated)
-	if err != nil {
-		return nil, err
-	}
-	return &responseBranchCreated, nil
+	return decodeRepositoryBranch(branchResponseStr)
 }
 
 func decodeRepositoryTagCreated(tagResponseStr string) (*RepositoryTag, error) {
-	var responseTagCreated RepositoryTag
-	err := json.Unmarshal([]byte(tagResponseStr), &responseTagCreated)
+	return decodeRepositoryTag(tagResponseStr)
+}
+
+func decodeRepositoryTag(tagResponseStr string) (*RepositoryTag, error) {
+	var tagResponseMap map[string]interface{}
+	err := json.Unmarshal([]byte(tagResponseStr), &tagResponseMap)
 	if err != nil {
 		return nil, err
 	}
-	return &responseTagCreated, nil
+	var tag RepositoryTag
+	err = decodeWithTimeHook(tagResponseMap, &tag)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
 }
 
 func decodeRepositoryTags(tagResponse interface{}) (*RepositoryTags, error) {
 	tagResponseMap := tagResponse.(map[string]interface{})
-	tagArray := tagResponseMap["values"].([]interface{})
+	tagArray, _ := tagResponseMap["values"].([]interface{})
 	var tags []RepositoryTag
 	for _, tagEntry := range tagArray {
 		var tag RepositoryTag
-		err := mapstructure.Decode(tagEntry, &tag)
-		if err == nil {
+		// Entries that can't be decoded are skipped.
+		if err := decodeWithTimeHook(tagEntry, &tag); err == nil {
 			tags = append(tags, tag)
 		}
 	}
@@ -1476,7 +1631,7 @@ func decodePipelineVariables(responseStr string) (*PipelineVariables, error) {
 		return nil, err
 	}
 
-	values := responseMap["values"].([]interface{})
+	values, _ := responseMap["values"].([]interface{})
 	var variables []PipelineVariable
 	for _, variable := range values {
 		var pipelineVariable PipelineVariable
@@ -1591,7 +1746,7 @@ func decodeEnvironments(response string) (*Environments, error) {
 		return nil, err
 	}
 
-	values := responseMap["values"].([]interface{})
+	values, _ := responseMap["values"].([]interface{})
 	var environmentsArray []Environment
 	var errs error = nil
 	for idx, value := range values {
@@ -1668,7 +1823,7 @@ func decodeDeploymentVariables(response string) (*DeploymentVariables, error) {
 		return nil, err
 	}
 
-	values := responseMap["values"].([]interface{})
+	values, _ := responseMap["values"].([]interface{})
 	var variablesArray []DeploymentVariable
 	var errs error = nil
 	for idx, value := range values {
@@ -1757,7 +1912,7 @@ func decodeDefaultReviewer(response interface{}) (*DefaultReviewer, error) {
 
 func decodeDefaultReviewers(response interface{}) (*DefaultReviewers, error) {
 	responseMap := response.(map[string]interface{})
-	values := responseMap["values"].([]interface{})
+	values, _ := responseMap["values"].([]interface{})
 	var variables []DefaultReviewer
 	for _, variable := range values {
 		var defaultReviewerVariable DefaultReviewer
@@ -1803,7 +1958,7 @@ func decodeDefaultReviewers(response interface{}) (*DefaultReviewers, error) {
 
 func decodeEffectiveDefaultReviewers(response interface{}) (*EffectiveDefaultReviewers, error) {
 	responseMap := response.(map[string]interface{})
-	values := responseMap["values"].([]interface{})
+	values, _ := responseMap["values"].([]interface{})
 	var variables []EffectiveDefaultReviewer
 	for _, variable := range values {
 		var defaultReviewerVariable EffectiveDefaultReviewer
@@ -1858,7 +2013,7 @@ func decodeGroupPermissions(response interface{}) (*GroupPermission, error) {
 
 func decodeGroupsPermissions(response interface{}) (*GroupPermissions, error) {
 	responseMap := response.(map[string]interface{})
-	values := responseMap["values"].([]interface{})
+	values, _ := responseMap["values"].([]interface{})
 
 	var variables []GroupPermission
 	for _, variable := range values {
@@ -1914,7 +2069,7 @@ func decodeUserPermissions(response interface{}) (*UserPermission, error) {
 
 func decodeUsersPermissions(response interface{}) (*UserPermissions, error) {
 	responseMap := response.(map[string]interface{})
-	values := responseMap["values"].([]interface{})
+	values, _ := responseMap["values"].([]interface{})
 
 	var variables []UserPermission
 	for _, variable := range values {
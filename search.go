@@ -0,0 +1,179 @@
+package bitbucket
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// CodeSearchQuery builds a code search query from search terms and qualifiers.
+// Reference: https://support.atlassian.com/bitbucket-cloud/docs/search-in-bitbucket-cloud/
+type CodeSearchQuery struct {
+	// Text holds the search terms, e.g. `"oldlib.Client"` for an exact phrase.
+	Text string
+	// Repository restricts the search to a repository slug.
+	Repository string
+	// Project restricts the search to a project key.
+	Project string
+	// Language restricts the search to a language, e.g. "go".
+	Language string
+	// Path restricts the search to files whose path contains the value.
+	Path string
+	// Extension restricts the search to a file extension, e.g. "yaml".
+	Extension string
+}
+
+// String returns the query in Bitbucket search syntax.
+func (q CodeSearchQuery) String() string {
+	var parts []string
+	if q.Text != "" {
+		parts = append(parts, q.Text)
+	}
+	for _, qualifier := range []struct{ name, value string }{
+		{"repo", q.Repository},
+		{"project", q.Project},
+		{"lang", q.Language},
+		{"path", q.Path},
+		{"ext", q.Extension},
+	} {
+		if qualifier.value == "" {
+			continue
+		}
+		value := qualifier.value
+		if strings.ContainsAny(value, " \t\"") {
+			value = strconv.Quote(value)
+		}
+		parts = append(parts, qualifier.name+":"+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// CodeSearchResult is a file matching a code search.
+type CodeSearchResult struct {
+	// Path is the path of the file in the repository.
+	Path string
+	// Repository is the full name of the repository, e.g. "workspace/repo".
+	Repository string
+	// CommitHash is the commit at which the file was indexed.
+	CommitHash string
+	// ContentMatchCount is the number of matches in the content of the file.
+	ContentMatchCount int
+	// ContentMatches are the matched regions of the file.
+	ContentMatches []CodeSearchContentMatch
+	// PathMatches are the segments of Path, highlighting the matched ones.
+	PathMatches []CodeSearchSegment
+	Links       map[string]interface{}
+}
+
+// CodeSearchContentMatch is a region of consecutive lines of a file.
+type CodeSearchContentMatch struct {
+	Lines []CodeSearchLine
+}
+
+// CodeSearchLine is a line of a file split into segments.
+type CodeSearchLine struct {
+	// Line is the 1-based line number.
+	Line     int
+	Segments []CodeSearchSegment
+}
+
+// Text returns the content of the line.
+func (l CodeSearchLine) Text() string {
+	var b strings.Builder
+	for _, s := range l.Segments {
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+// CodeSearchSegment is a piece of text; Match reports whether it matched the query.
+type CodeSearchSegment struct {
+	Text  string
+	Match bool
+}
+
+type codeSearchFile struct {
+	Path   string
+	Links  map[string]interface{}
+	Commit struct {
+		Hash       string
+		Repository struct {
+			FullName string `mapstructure:"full_name"`
+		}
+	}
+}
+
+func decodeCodeSearchResult(value interface{}) (CodeSearchResult, error) {
+	var raw struct {
+		ContentMatchCount int                      `mapstructure:"content_match_count"`
+		ContentMatches    []CodeSearchContentMatch `mapstructure:"content_matches"`
+		PathMatches       []CodeSearchSegment      `mapstructure:"path_matches"`
+		File              codeSearchFile
+	}
+	if err := mapstructure.Decode(value, &raw); err != nil {
+		return CodeSearchResult{}, err
+	}
+
+	result := CodeSearchResult{
+		Path:              raw.File.Path,
+		Repository:        raw.File.Commit.Repository.FullName,
+		CommitHash:        raw.File.Commit.Hash,
+		ContentMatchCount: raw.ContentMatchCount,
+		ContentMatches:    raw.ContentMatches,
+		PathMatches:       raw.PathMatches,
+		Links:             raw.File.Links,
+	}
+	if result.Repository == "" || result.CommitHash == "" {
+		repository, hash := parseSrcLink(raw.File.Links)
+		if result.Repository == "" {
+			result.Repository = repository
+		}
+		if result.CommitHash == "" {
+			result.CommitHash = hash
+		}
+	}
+	return result, nil
+}
+
+// parseSrcLink extracts the repository full name and commit hash from the
+// self link of a file, ".../repositories/{workspace}/{repo}/src/{hash}/{path}".
+func parseSrcLink(links map[string]interface{}) (string, string) {
+	self, _ := links["self"].(map[string]interface{})
+	href, _ := self["href"].(string)
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", ""
+	}
+	segments := strings.Split(u.Path, "/")
+	for i := 0; i+4 < len(segments); i++ {
+		if segments[i] == "repositories" && segments[i+3] == "src" {
+			return segments[i+1] + "/" + segments[i+2], segments[i+4]
+		}
+	}
+	return "", ""
+}
+
+// SearchCode searches the code of the repositories in a workspace. The query
+// uses Bitbucket search syntax, see CodeSearchQuery for building one with
+// qualifiers. Results are fetched page by page as the iteration advances:
+//
+//	query := bitbucket.CodeSearchQuery{Text: "oldlib", Language: "go"}
+//	for result, err := range c.Workspaces.SearchCode(ctx, "acme", query.String()) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(result.Repository, result.Path)
+//	}
+//
+// Code search must be enabled for the workspace.
+func (t *Workspace) SearchCode(ctx context.Context, workspace string, query string) iter.Seq2[CodeSearchResult, error] {
+	params := url.Values{}
+	params.Set("search_query", query)
+	params.Set("fields", "+values.file.commit.repository")
+	urlStr := t.c.requestUrl("/workspaces/%s/search/code", workspace) + "?" + params.Encode()
+	return iteratePages(t.c, ctx, urlStr, decodeCodeSearchResult)
+}
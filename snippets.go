@@ -0,0 +1,464 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"time"
+)
+
+// Snippets manages snippets, small sets of files shared outside repositories.
+// Reference: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-snippets/
+type Snippets struct {
+	c *Client
+}
+
+type Snippet struct {
+	Type      string
+	Id        int
+	Title     string
+	Scm       string
+	IsPrivate bool      `mapstructure:"is_private"`
+	CreatedOn time.Time `mapstructure:"created_on"`
+	UpdatedOn time.Time `mapstructure:"updated_on"`
+	Owner     *User
+	Creator   *User
+	// Files maps file names to the files of the snippet.
+	Files map[string]SnippetFile
+	Links map[string]interface{}
+}
+
+type SnippetFile struct {
+	Name  string `mapstructure:"-"`
+	Links map[string]interface{}
+}
+
+type SnippetsRes struct {
+	Page    int32
+	Pagelen int32
+	Size    int32
+	Items   []Snippet
+}
+
+// SnippetCommit is a revision of a snippet.
+type SnippetCommit struct {
+	Type    string
+	Hash    string
+	Date    time.Time
+	Message string
+	Author  SnippetCommitAuthor
+	Parents []SnippetCommit
+	Links   map[string]interface{}
+}
+
+type SnippetCommitAuthor struct {
+	Raw  string
+	User *User
+}
+
+type SnippetComment struct {
+	Type      string
+	Id        int
+	Content   SnippetCommentContent
+	User      *User
+	Deleted   bool
+	CreatedOn time.Time `mapstructure:"created_on"`
+	UpdatedOn time.Time `mapstructure:"updated_on"`
+	Parent    *SnippetComment
+	Links     map[string]interface{}
+}
+
+type SnippetCommentContent struct {
+	Raw    string
+	Markup string
+	Html   string
+}
+
+func decodeSnippet(response interface{}) (*Snippet, error) {
+	snippetMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+
+	if snippetMap["type"] == "error" {
+		return nil, DecodeError(snippetMap)
+	}
+
+	var snippet = new(Snippet)
+	if err := decodeWithTimeHook(snippetMap, snippet); err != nil {
+		return nil, err
+	}
+	for name, file := range snippet.Files {
+		file.Name = name
+		snippet.Files[name] = file
+	}
+
+	return snippet, nil
+}
+
+func decodeSnippets(response interface{}) (*SnippetsRes, error) {
+	snippetsMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+
+	values, _ := snippetsMap["values"].([]interface{})
+	var snippets []Snippet
+	for _, value := range values {
+		snippet, err := decodeSnippet(value)
+		if err != nil {
+			return nil, err
+		}
+		snippets = append(snippets, *snippet)
+	}
+
+	page, _ := snippetsMap["page"].(float64)
+	pagelen, _ := snippetsMap["pagelen"].(float64)
+	size, _ := snippetsMap["size"].(float64)
+
+	return &SnippetsRes{
+		Page:    int32(page),
+		Pagelen: int32(pagelen),
+		Size:    int32(size),
+		Items:   snippets,
+	}, nil
+}
+
+func decodeSnippetCommit(response interface{}) (*SnippetCommit, error) {
+	commitMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+
+	if commitMap["type"] == "error" {
+		return nil, DecodeError(commitMap)
+	}
+
+	var commit = new(SnippetCommit)
+	if err := decodeWithTimeHook(commitMap, commit); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+func decodeSnippetCommits(response interface{}) ([]SnippetCommit, error) {
+	commitsMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+
+	var commits []SnippetCommit
+	if err := decodeWithTimeHook(commitsMap["values"], &commits); err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+func decodeSnippetComment(response interface{}) (*SnippetComment, error) {
+	commentMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+
+	if commentMap["type"] == "error" {
+		return nil, DecodeError(commentMap)
+	}
+
+	var comment = new(SnippetComment)
+	if err := decodeWithTimeHook(commentMap, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func decodeSnippetComments(response interface{}) ([]SnippetComment, error) {
+	commentsMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+
+	var comments []SnippetComment
+	if err := decodeWithTimeHook(commentsMap["values"], &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// snippetUrl returns the URL of the snippet, followed by the path elements.
+func (s *Snippets) snippetUrl(workspace, id string, elem ...string) string {
+	urlStr := s.c.requestUrl("/snippets/%s/%s", workspace, id)
+	for _, e := range elem {
+		urlStr += "/" + e
+	}
+	return urlStr
+}
+
+func (s *Snippets) buildSnippetForm(so *SnippetOptions) uploadForm {
+	params := make(map[string]string)
+	if so.Title != "" {
+		params["title"] = so.Title
+	}
+	if so.IsPrivate != "" {
+		params["is_private"] = so.IsPrivate
+	}
+	return uploadForm{
+		files:         so.Files,
+		fileField:     "file",
+		deleteField:   "file",
+		filesToDelete: so.FilesToDelete,
+		params:        params,
+	}
+}
+
+// List returns the snippets of a workspace, or of the authenticated user if
+// no workspace is given.
+func (s *Snippets) List(so *SnippetOptions) (*SnippetsRes, error) {
+	urlStr := s.c.requestUrl("/snippets")
+	if so.Workspace != "" {
+		urlStr = s.c.requestUrl("/snippets/%s", so.Workspace)
+	}
+	params := url.Values{}
+	if so.Role != "" {
+		params.Set("role", so.Role)
+	}
+	s.c.addFieldsParam(&params, so.Fields, true)
+	if len(params) > 0 {
+		urlStr += "?" + params.Encode()
+	}
+
+	response, err := s.c.executePaginatedWithContext("GET", urlStr, "", nil, so.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippets(response)
+}
+
+// Create creates a snippet with the given files. The files are sent as a
+// multipart form, so Create supports binary files.
+func (s *Snippets) Create(so *SnippetOptions) (*Snippet, error) {
+	if len(so.Files) == 0 {
+		return nil, errors.New("a snippet needs at least one file")
+	}
+	urlStr := s.c.requestUrl("/snippets")
+	if so.Workspace != "" {
+		urlStr = s.c.requestUrl("/snippets/%s", so.Workspace)
+	}
+	response, err := s.c.executeUpload("POST", urlStr, s.buildSnippetForm(so), false, so.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippet(response)
+}
+
+// Get returns a snippet, at Revision if one is given.
+func (s *Snippets) Get(so *SnippetOptions) (*Snippet, error) {
+	urlStr := s.snippetUrl(so.Workspace, so.Id)
+	if so.Revision != "" {
+		urlStr = s.snippetUrl(so.Workspace, so.Id, so.Revision)
+	}
+	urlStr, err := s.c.withFieldsParam(urlStr, so.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.c.executeWithContext("GET", urlStr, "", so.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippet(response)
+}
+
+// Update changes the title or privacy of a snippet, adds or replaces Files and
+// removes FilesToDelete, creating a new revision.
+func (s *Snippets) Update(so *SnippetOptions) (*Snippet, error) {
+	urlStr := s.snippetUrl(so.Workspace, so.Id)
+	response, err := s.c.executeUpload("PUT", urlStr, s.buildSnippetForm(so), false, so.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippet(response)
+}
+
+func (s *Snippets) Delete(so *SnippetOptions) error {
+	urlStr := s.snippetUrl(so.Workspace, so.Id)
+	_, err := s.c.executeWithContext("DELETE", urlStr, "", so.ctx)
+	return err
+}
+
+// GetFile returns the content of the file at Path, at Revision if one is given.
+func (s *Snippets) GetFile(so *SnippetOptions) ([]byte, error) {
+	if so.Path == "" {
+		return nil, errors.New("a file path is required")
+	}
+	urlStr := s.snippetUrl(so.Workspace, so.Id, "files", so.Path)
+	if so.Revision != "" {
+		urlStr = s.snippetUrl(so.Workspace, so.Id, so.Revision, "files", so.Path)
+	}
+	resBody, err := s.c.executeRawWithContext("GET", urlStr, "", so.ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer resBody.Close()
+
+	return io.ReadAll(resBody)
+}
+
+// ListRevisions returns the revision history of a snippet, newest first.
+func (s *Snippets) ListRevisions(so *SnippetOptions) ([]SnippetCommit, error) {
+	urlStr, err := s.c.withFieldsParam(s.snippetUrl(so.Workspace, so.Id, "commits"), so.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.c.executePaginatedWithContext("GET", urlStr, "", nil, so.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippetCommits(response)
+}
+
+// GetRevision returns the revision Revision of a snippet.
+func (s *Snippets) GetRevision(so *SnippetOptions) (*SnippetCommit, error) {
+	if so.Revision == "" {
+		return nil, errors.New("a revision is required")
+	}
+	urlStr, err := s.c.withFieldsParam(s.snippetUrl(so.Workspace, so.Id, "commits", so.Revision), so.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.c.executeWithContext("GET", urlStr, "", so.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippetCommit(response)
+}
+
+// Diff returns the unified diff of Revision, e.g. a single revision or a range
+// "from..to", limited to Path if one is given.
+func (s *Snippets) Diff(so *SnippetOptions) (string, error) {
+	return s.getRevisionText(so, "diff")
+}
+
+// Patch returns Revision as a patch series, e.g. for git am.
+func (s *Snippets) Patch(so *SnippetOptions) (string, error) {
+	return s.getRevisionText(so, "patch")
+}
+
+func (s *Snippets) getRevisionText(so *SnippetOptions, kind string) (string, error) {
+	if so.Revision == "" {
+		return "", errors.New("a revision is required")
+	}
+	urlStr := s.snippetUrl(so.Workspace, so.Id, so.Revision, kind)
+	if so.Path != "" {
+		urlStr += "?" + url.Values{"path": {so.Path}}.Encode()
+	}
+	resBody, err := s.c.executeRawWithContext("GET", urlStr, "", so.ctx)
+	if err != nil {
+		return "", err
+	}
+	defer resBody.Close()
+
+	data, err := io.ReadAll(resBody)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (s *Snippets) ListComments(sco *SnippetCommentOptions) ([]SnippetComment, error) {
+	urlStr := s.snippetUrl(sco.Workspace, sco.SnippetId, "comments")
+	response, err := s.c.executePaginatedWithContext("GET", urlStr, "", nil, sco.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippetComments(response)
+}
+
+func (s *Snippets) GetComment(sco *SnippetCommentOptions) (*SnippetComment, error) {
+	urlStr := s.snippetUrl(sco.Workspace, sco.SnippetId, "comments", sco.CommentId)
+	response, err := s.c.executeWithContext("GET", urlStr, "", sco.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippetComment(response)
+}
+
+func (s *Snippets) AddComment(sco *SnippetCommentOptions) (*SnippetComment, error) {
+	data, err := buildSnippetCommentBody(sco)
+	if err != nil {
+		return nil, err
+	}
+	urlStr := s.snippetUrl(sco.Workspace, sco.SnippetId, "comments")
+	response, err := s.c.executeWithContext("POST", urlStr, data, sco.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippetComment(response)
+}
+
+func (s *Snippets) UpdateComment(sco *SnippetCommentOptions) (*SnippetComment, error) {
+	data, err := buildSnippetCommentBody(sco)
+	if err != nil {
+		return nil, err
+	}
+	urlStr := s.snippetUrl(sco.Workspace, sco.SnippetId, "comments", sco.CommentId)
+	response, err := s.c.executeWithContext("PUT", urlStr, data, sco.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnippetComment(response)
+}
+
+func (s *Snippets) DeleteComment(sco *SnippetCommentOptions) error {
+	urlStr := s.snippetUrl(sco.Workspace, sco.SnippetId, "comments", sco.CommentId)
+	_, err := s.c.executeWithContext("DELETE", urlStr, "", sco.ctx)
+	return err
+}
+
+func buildSnippetCommentBody(sco *SnippetCommentOptions) (string, error) {
+	body := map[string]interface{}{}
+	body["content"] = map[string]interface{}{
+		"raw": sco.Content,
+	}
+
+	if sco.Parent != nil {
+		body["parent"] = map[string]interface{}{
+			"id": sco.Parent,
+		}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// IsWatching reports whether the authenticated user watches the snippet.
+func (s *Snippets) IsWatching(so *SnippetOptions) (bool, error) {
+	urlStr := s.snippetUrl(so.Workspace, so.Id, "watch")
+	resBody, err := s.c.executeRawWithContext("GET", urlStr, "", so.ctx)
+	if resBody != nil {
+		resBody.Close()
+	}
+	// A 404 indicates that the user doesn't watch the snippet.
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *Snippets) Watch(so *SnippetOptions) error {
+	urlStr := s.snippetUrl(so.Workspace, so.Id, "watch")
+	_, err := s.c.executeWithContext("PUT", urlStr, "", so.ctx)
+	return err
+}
+
+func (s *Snippets) Unwatch(so *SnippetOptions) error {
+	urlStr := s.snippetUrl(so.Workspace, so.Id, "watch")
+	_, err := s.c.executeWithContext("DELETE", urlStr, "", so.ctx)
+	return err
+}
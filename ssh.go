@@ -62,7 +62,7 @@ func decodeSSHKeys(keysResponse interface{}) (*SSHKeyRes, error) {
 		return nil, errors.New("Not a valid format")
 	}
 
-	keyArray := keysResponseMap["values"].([]interface{})
+	keyArray, _ := keysResponseMap["values"].([]interface{})
 	var keys []SSHKey
 	for _, keyEntry := range keyArray {
 		var key SSHKey
@@ -116,6 +116,10 @@ func (sk *SSHKeys) Create(ro *SSHKeyOptions) (*SSHKey, error) {
 
 func (sk *SSHKeys) Get(ro *SSHKeyOptions) (*SSHKey, error) {
 	urlStr := sk.c.requestUrl("/users/%s/ssh-keys/%s", ro.Owner, ro.Uuid)
+	urlStr, err := sk.c.withFieldsParam(urlStr, ro.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	response, err := sk.c.execute("GET", urlStr, "")
 	if err != nil {
 		return nil, err
@@ -0,0 +1,188 @@
+package tests
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func buildTarGz(t *testing.T, headers []*tar.Header, contents []string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := tar.NewWriter(gz)
+	for i, header := range headers {
+		header.Size = int64(len(contents[i]))
+		if err := w.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(contents[i]))
+	}
+	w.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestDownloadArchiveFollowsRedirectWithoutLeakingCredentials(t *testing.T) {
+	archive := buildZip(t, map[string]string{
+		"acme-api-abc123/README.md":   "# api",
+		"acme-api-abc123/src/main.go": "package main",
+	})
+
+	var cdnAuthorization string
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cdnAuthorization = r.Header.Get("Authorization")
+		w.Write(archive)
+	}))
+	defer cdn.Close()
+	// The loopback address under another name is a different host for redirects.
+	cdnURL := strings.Replace(cdn.URL, "127.0.0.1", "localhost", 1)
+
+	var webAuthorization, webPath string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		webAuthorization = r.Header.Get("Authorization")
+		webPath = r.URL.Path
+		http.Redirect(w, r, cdnURL+"/signed/archive.zip", http.StatusFound)
+	})
+
+	body, err := c.Repositories.Repository.DownloadArchive(&bitbucket.RepositoryArchiveOptions{
+		Owner: "acme", RepoSlug: "api", Ref: "main",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+
+	if webPath != "/acme/api/get/main.zip" {
+		t.Errorf("unexpected archive path %q", webPath)
+	}
+	if webAuthorization == "" {
+		t.Error("expected the archive request to be authenticated")
+	}
+	files := map[string]string{}
+	err = bitbucket.WalkArchive(body, bitbucket.ArchiveZip, func(f *bitbucket.ArchiveFile) error {
+		data, err := io.ReadAll(f.Body)
+		files[f.Path] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cdnAuthorization != "" {
+		t.Errorf("expected the credentials not to be sent to the download host, got %q", cdnAuthorization)
+	}
+	if files["README.md"] != "# api" || files["src/main.go"] != "package main" {
+		t.Errorf("unexpected files %v", files)
+	}
+}
+
+func TestExtractArchiveTarGz(t *testing.T) {
+	archive := buildTarGz(t, []*tar.Header{
+		{Name: "acme-api-abc123/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "acme-api-abc123/bin/run.sh", Typeflag: tar.TypeReg, Mode: 0o755},
+		{Name: "acme-api-abc123/docs/index.md", Typeflag: tar.TypeReg, Mode: 0o644},
+		{Name: "acme-api-abc123/docs/README.md", Typeflag: tar.TypeSymlink, Linkname: "index.md"},
+	}, []string{"", "#!/bin/sh", "# docs", ""})
+
+	dir := t.TempDir()
+	if err := bitbucket.ExtractArchive(bytes.NewReader(archive), bitbucket.ArchiveTarGz, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "bin", "run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&0o100 == 0 {
+		t.Errorf("expected run.sh to be executable, got %v", info.Mode())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "docs", "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "# docs" {
+		t.Errorf("unexpected content through the symlink %q", data)
+	}
+}
+
+func TestExtractArchiveRejectsEscapingEntries(t *testing.T) {
+	for name, archive := range map[string][]byte{
+		"zip path": buildZip(t, map[string]string{"acme-api-abc123/../../evil": "x"}),
+		"tar symlink": buildTarGz(t, []*tar.Header{
+			{Name: "acme-api-abc123/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc"},
+		}, []string{""}),
+	} {
+		format := bitbucket.ArchiveZip
+		if strings.HasPrefix(name, "tar") {
+			format = bitbucket.ArchiveTarGz
+		}
+		dir := t.TempDir()
+		if err := bitbucket.ExtractArchive(bytes.NewReader(archive), format, filepath.Join(dir, "out")); err == nil {
+			t.Errorf("%s: expected the entry to be rejected", name)
+		}
+		if _, err := os.Lstat(filepath.Join(dir, "evil")); err == nil {
+			t.Errorf("%s: expected nothing to be written outside of the directory", name)
+		}
+	}
+}
+
+func TestExtractArchiveRejectsChainedSymlinks(t *testing.T) {
+	for name, headers := range map[string][]*tar.Header{
+		"link then file": {
+			{Name: "acme-api-abc123/x", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "acme-api-abc123/x/x/y", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+			{Name: "acme-api-abc123/x/x/y/evil", Typeflag: tar.TypeReg, Mode: 0o644},
+		},
+		"links only": {
+			{Name: "acme-api-abc123/x", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "acme-api-abc123/x/x/y", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+		},
+		"target through link": {
+			{Name: "acme-api-abc123/x", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "acme-api-abc123/y", Typeflag: tar.TypeSymlink, Linkname: "x/x/.."},
+		},
+	} {
+		archive := buildTarGz(t, headers, make([]string, len(headers)))
+		dir := t.TempDir()
+		out := filepath.Join(dir, "out")
+		if err := bitbucket.ExtractArchive(bytes.NewReader(archive), bitbucket.ArchiveTarGz, out); err == nil {
+			t.Errorf("%s: expected the archive to be rejected", name)
+		}
+		if _, err := os.Lstat(filepath.Join(dir, "evil")); err == nil {
+			t.Errorf("%s: expected nothing to be written outside of the directory", name)
+		}
+		entries, _ := os.ReadDir(out)
+		for _, entry := range entries {
+			if target, err := filepath.EvalSymlinks(filepath.Join(out, entry.Name())); err == nil && !strings.HasPrefix(target, out) {
+				t.Errorf("%s: %s resolves outside of the directory to %s", name, entry.Name(), target)
+			}
+		}
+	}
+}
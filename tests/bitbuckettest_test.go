@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+	"github.com/ktrysmt/go-bitbucket/bitbuckettest"
+)
+
+func newFakeServer(t *testing.T, opts ...bitbucket.Option) (*bitbuckettest.Server, *bitbucket.Client) {
+	t.Helper()
+	srv := bitbuckettest.NewServer()
+	t.Cleanup(srv.Close)
+	c, err := srv.NewClient(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, c
+}
+
+func TestFakeServerRepositoriesArePaginated(t *testing.T) {
+	srv, c := newFakeServer(t, bitbucket.WithPageLength(2))
+	for i := 0; i < 5; i++ {
+		srv.AddRepository("acme", fmt.Sprintf("repo-%d", i))
+	}
+
+	res, err := c.Repositories.ListForAccount(&bitbucket.RepositoriesOptions{Owner: "acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 5 {
+		t.Fatalf("expected 5 repositories across pages, got %d", len(res.Items))
+	}
+	if res.Items[4].Full_name != "acme/repo-4" {
+		t.Errorf("unexpected last repository %q", res.Items[4].Full_name)
+	}
+
+	pages := 0
+	for _, r := range srv.Requests() {
+		if strings.HasPrefix(r, "GET /2.0/repositories/acme") {
+			pages++
+		}
+	}
+	if pages != 3 {
+		t.Errorf("expected 3 page requests, got %d: %v", pages, srv.Requests())
+	}
+
+	if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "acme", RepoSlug: "missing"}); err == nil {
+		t.Error("expected an unknown repository to fail")
+	}
+}
+
+func TestFakeServerFiltersRepositories(t *testing.T) {
+	srv, c := newFakeServer(t)
+	for _, slug := range []string{"api", "web-API", "docs"} {
+		srv.AddRepository("acme", slug)
+	}
+
+	keyword := "api"
+	res, err := c.Repositories.ListForAccount(&bitbucket.RepositoriesOptions{Owner: "acme", Role: "member", Keyword: &keyword})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, r := range res.Items {
+		names = append(names, r.Slug)
+	}
+	if strings.Join(names, ",") != "api,web-API" {
+		t.Errorf("expected the keyword to match api and web-API, got %v", names)
+	}
+
+	_, err = c.Repositories.ListForAccount(&bitbucket.RepositoriesOptions{Owner: "acme", Role: "guest"})
+	var unexpected *bitbucket.UnexpectedResponseStatusError
+	if !errors.As(err, &unexpected) || unexpected.Status != "400 Bad Request" {
+		t.Errorf("expected an unknown role to be rejected, got %v", err)
+	}
+}
+
+func TestFakeServerPullRequestLifecycle(t *testing.T) {
+	srv, c := newFakeServer(t)
+	srv.AddRepository("acme", "api")
+
+	branch, err := c.Repositories.Repository.CreateBranch(&bitbucket.RepositoryBranchCreationOptions{
+		Owner: "acme", RepoSlug: "api", Name: "feature", Target: bitbucket.RepositoryBranchTarget{Hash: "main"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch.Name != "feature" {
+		t.Errorf("unexpected branch %q", branch.Name)
+	}
+
+	opt := &bitbucket.PullRequestsOptions{
+		Owner: "acme", RepoSlug: "api", Title: "Add feature", SourceBranch: "feature", CloseSourceBranch: true,
+	}
+	if _, err := c.Repositories.PullRequests.Create(opt); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Repositories.PullRequests.AddComment(&bitbucket.PullRequestCommentOptions{
+		Owner: "acme", RepoSlug: "api", PullRequestID: "1", Content: "Looks good",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	opt.ID = "1"
+	if _, err := c.Repositories.PullRequests.Approve(opt); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Repositories.PullRequests.Merge(opt); err != nil {
+		t.Fatal(err)
+	}
+
+	pr, ok := srv.PullRequest("acme", "api", 1)
+	if !ok {
+		t.Fatal("expected pull request 1 to exist")
+	}
+	if pr["state"] != "MERGED" {
+		t.Errorf("expected the pull request to be merged, got %v", pr["state"])
+	}
+	if comments := srv.Comments("acme", "api", 1); len(comments) != 1 {
+		t.Errorf("expected 1 comment, got %d", len(comments))
+	}
+	if branches := srv.Branches("acme", "api"); len(branches) != 1 || branches[0] != "main" {
+		t.Errorf("expected the source branch to be closed, got %v", branches)
+	}
+	if _, err := c.Repositories.PullRequests.Merge(opt); err == nil {
+		t.Error("expected merging a merged pull request to fail")
+	}
+}
+
+func TestFakeServerWebhooksAndVariables(t *testing.T) {
+	srv, c := newFakeServer(t)
+	srv.AddRepository("acme", "api")
+
+	hook, err := c.Repositories.Webhooks.Create(&bitbucket.WebhooksOptions{
+		Owner: "acme", RepoSlug: "api", Url: "https://example.com/hook", Active: true, Events: []string{"repo:push"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hooks, err := c.Repositories.Webhooks.List(&bitbucket.WebhooksOptions{Owner: "acme", RepoSlug: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 1 || hooks[0].Uuid != hook.Uuid || hooks[0].Url != "https://example.com/hook" {
+		t.Errorf("unexpected webhooks %+v", hooks)
+	}
+
+	if _, err := c.Repositories.Repository.AddPipelineVariable(&bitbucket.RepositoryPipelineVariableOptions{
+		Owner: "acme", RepoSlug: "api", Key: "TOKEN", Value: "secret", Secured: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	vars, err := c.Repositories.Repository.ListPipelineVariables(&bitbucket.RepositoryPipelineVariablesOptions{Owner: "acme", RepoSlug: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vars.Variables) != 1 || vars.Variables[0].Key != "TOKEN" || vars.Variables[0].Value != "" {
+		t.Errorf("expected the secured value to be hidden, got %+v", vars.Variables)
+	}
+	if stored := srv.Variables("acme", "api"); len(stored) != 1 || stored[0]["value"] != "secret" {
+		t.Errorf("unexpected stored variables %v", stored)
+	}
+}
+
+func TestFakeServerInjectsFaults(t *testing.T) {
+	srv, c := newFakeServer(t)
+	srv.AddRepository("acme", "api")
+	srv.InjectFault(bitbuckettest.Fault{Method: http.MethodGet, Path: "/repositories/acme/*", Status: http.StatusServiceUnavailable, Times: 1})
+
+	opt := &bitbucket.RepositoryOptions{Owner: "acme", RepoSlug: "api"}
+	_, err := c.Repositories.Repository.Get(opt)
+	var unexpected *bitbucket.UnexpectedResponseStatusError
+	if !errors.As(err, &unexpected) || unexpected.Status != "503 Service Unavailable" {
+		t.Fatalf("expected an injected 503, got %v", err)
+	}
+	if _, err := c.Repositories.Repository.Get(opt); err != nil {
+		t.Fatalf("expected the fault to be used up, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func newBranchCleanupServer(t *testing.T, deleted *[]string) *bitbucket.Client {
+	old := time.Now().AddDate(0, 0, -90).UTC().Format(time.RFC3339)
+	recent := time.Now().AddDate(0, 0, -2).UTC().Format(time.RFC3339)
+	branch := func(name, hash, date string) string {
+		return fmt.Sprintf(`{"type": "branch", "name": %q, "target": {"type": "commit", "hash": %q, "date": %q}}`, name, hash, date)
+	}
+	var mu sync.Mutex
+	return newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.EscapedPath()
+		switch {
+		case path == "/2.0/repositories/acme/api":
+			fmt.Fprint(w, `{"type": "repository", "full_name": "acme/api", "mainbranch": {"type": "branch", "name": "main"}}`)
+		case path == "/2.0/repositories/acme/api/branch-restrictions":
+			// The restrictions and pull requests are split across pages too,
+			// which must be read whatever the page limit of the client.
+			if r.URL.Query().Get("page") != "2" {
+				fmt.Fprintf(w, `{"values": [
+					{"kind": "delete", "branch_match_kind": "branching_model", "branch_type": "release"},
+					{"kind": "push", "branch_match_kind": "branching_model", "branch_type": "production", "pattern": ""}],
+					"next": "http://%s%s?page=2"}`, r.Host, path)
+				return
+			}
+			fmt.Fprint(w, `{"values": [{"kind": "delete", "branch_match_kind": "glob", "pattern": "support/*"}]}`)
+		case path == "/2.0/repositories/acme/api/branching-model":
+			fmt.Fprint(w, `{"type": "branching_model",
+				"development": {"name": "develop", "use_mainbranch": false},
+				"production": {"name": "main", "use_mainbranch": true},
+				"branch_types": [{"kind": "feature", "prefix": "feature/"}, {"kind": "release", "prefix": "release/"}]}`)
+		case path == "/2.0/repositories/acme/api/pullrequests/":
+			if got := r.URL.Query().Get("state"); got != "OPEN" {
+				t.Errorf("expected open pull requests, got %q", got)
+			}
+			if r.URL.Query().Get("page") != "2" {
+				fmt.Fprintf(w, `{"values": [
+					{"source": {"branch": {"name": "feature/wip"}, "repository": {"full_name": "acme/api"}}, "destination": {"repository": {"full_name": "acme/api"}}},
+					{"source": {"branch": {"name": "feature/done"}, "repository": {"full_name": "someone/api"}}, "destination": {"repository": {"full_name": "acme/api"}}}],
+					"next": "http://%s%s?state=OPEN&page=2"}`, r.Host, path)
+				return
+			}
+			fmt.Fprint(w, `{"values": [
+				{"source": {"branch": {"name": "feature/review"}, "repository": {"full_name": "acme/api"}}, "destination": {"repository": {"full_name": "acme/api"}}}]}`)
+		case path == "/2.0/repositories/acme/api/refs/branches" && r.Method == http.MethodGet:
+			// The branches are split across pages, which must all be read.
+			if r.URL.Query().Get("page") != "2" {
+				fmt.Fprintf(w, `{"values": [%s, %s, %s, %s, %s, %s, %s], "next": "http://%s%s?page=2"}`,
+					branch("main", "m1", old),
+					branch("release/1.2", "r1", old),
+					branch("support/1.x", "s1", old),
+					branch("experiment/x", "x1", old),
+					branch("feature/recent", "n1", recent),
+					branch("develop", "v1", old),
+					branch("feature/review", "e1", old),
+					r.Host, path)
+				return
+			}
+			fmt.Fprintf(w, `{"values": [%s, %s, %s, %s]}`,
+				branch("feature/wip", "w1", old),
+				branch("feature/done", "d1", old),
+				branch("feature/fixed", "f1", old),
+				branch("feature/unmerged", "u1", old))
+		case strings.HasPrefix(path, "/2.0/repositories/acme/api/merge-base/"):
+			head := path[strings.LastIndex(path, ".")+1:]
+			if head == "u1" {
+				fmt.Fprint(w, `{"type": "commit", "hash": "m0"}`)
+				return
+			}
+			fmt.Fprintf(w, `{"type": "commit", "hash": %q}`, head)
+		case strings.HasPrefix(path, "/2.0/repositories/acme/api/refs/branches/") && r.Method == http.MethodDelete:
+			name := strings.TrimPrefix(r.URL.Path, "/2.0/repositories/acme/api/refs/branches/")
+			if name == "feature/fixed" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"type": "error", "error": {"message": "forbidden"}}`)
+				return
+			}
+			mu.Lock()
+			*deleted = append(*deleted, name)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func branchCleanupNames(entries []bitbucket.BranchCleanupEntry) map[string]bitbucket.BranchKeptReason {
+	names := make(map[string]bitbucket.BranchKeptReason)
+	for _, entry := range entries {
+		names[entry.Name] = entry.Reason
+	}
+	return names
+}
+
+func TestCleanupBranches(t *testing.T) {
+	var deleted []string
+	c := newBranchCleanupServer(t, &deleted)
+
+	report, err := c.Repositories.Repository.CleanupBranches(&bitbucket.BranchCleanupOptions{
+		Owner: "acme", RepoSlug: "api", OlderThan: 30 * 24 * time.Hour,
+		Exclude: []string{"experiment/*"}, Concurrency: 2,
+	})
+	if err == nil || !strings.Contains(err.Error(), "feature/fixed") {
+		t.Errorf("expected the failed deletion to be returned, got %v", err)
+	}
+	if report.MainBranch != "main" || report.DevelopmentBranch != "develop" || report.DryRun {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	kept := branchCleanupNames(report.Kept)
+	want := map[string]bitbucket.BranchKeptReason{
+		"main":             bitbucket.BranchKeptMain,
+		"release/1.2":      bitbucket.BranchKeptProtected,
+		"support/1.x":      bitbucket.BranchKeptProtected,
+		"experiment/x":     bitbucket.BranchKeptExcluded,
+		"feature/recent":   bitbucket.BranchKeptRecent,
+		"develop":          bitbucket.BranchKeptDevelopment,
+		"feature/review":   bitbucket.BranchKeptOpenPullRequest,
+		"feature/wip":      bitbucket.BranchKeptOpenPullRequest,
+		"feature/unmerged": bitbucket.BranchKeptUnmerged,
+	}
+	if fmt.Sprint(kept) != fmt.Sprint(want) {
+		t.Errorf("expected kept branches %v, got %v", want, kept)
+	}
+	if report.Kept[1].Pattern != "release/*" || report.Kept[2].Pattern != "support/*" {
+		t.Errorf("expected the protected branch patterns to be reported, got %+v", report.Kept[1:3])
+	}
+
+	sort.Strings(deleted)
+	if fmt.Sprint(deleted) != "[feature/done]" || len(report.Deleted) != 1 || report.Deleted[0].Name != "feature/done" {
+		t.Errorf("expected only feature/done to be deleted, got %v and %+v", deleted, report.Deleted)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].Name != "feature/fixed" || report.Failed[0].Err == nil {
+		t.Errorf("unexpected failures %+v", report.Failed)
+	}
+}
+
+func TestCleanupBranchesDryRun(t *testing.T) {
+	var deleted []string
+	c := newBranchCleanupServer(t, &deleted)
+
+	report, err := c.Repositories.Repository.CleanupBranches(&bitbucket.BranchCleanupOptions{
+		Owner: "acme", RepoSlug: "api", MainBranch: "main", OlderThan: 30 * 24 * time.Hour, DryRun: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected no deletion on a dry run, got %v", deleted)
+	}
+	stale := branchCleanupNames(report.Deleted)
+	if _, ok := stale["feature/done"]; !ok || len(stale) != 3 {
+		t.Errorf("expected experiment/x, feature/done and feature/fixed to be stale, got %v", stale)
+	}
+}
+
+func TestCleanupBranchesReadsEveryRestrictionAndPullRequest(t *testing.T) {
+	var deleted []string
+	c := newBranchCleanupServer(t, &deleted)
+	c.LimitPages = 1
+
+	report, err := c.Repositories.Repository.CleanupBranches(&bitbucket.BranchCleanupOptions{
+		Owner: "acme", RepoSlug: "api", MainBranch: "main", OlderThan: 30 * 24 * time.Hour, DryRun: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := branchCleanupNames(report.Kept)
+	if kept["support/1.x"] != bitbucket.BranchKeptProtected || kept["feature/review"] != bitbucket.BranchKeptOpenPullRequest {
+		t.Errorf("expected the restriction and pull request on the second page to keep their branches, got %v", kept)
+	}
+	if stale := branchCleanupNames(report.Deleted); len(stale) != 1 {
+		t.Errorf("expected only experiment/x to be stale on the first page of branches, got %v", stale)
+	}
+}
+
+func TestCleanupBranchesCanceled(t *testing.T) {
+	var deleted []string
+	c := newBranchCleanupServer(t, &deleted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.Repositories.Repository.CleanupBranches((&bitbucket.BranchCleanupOptions{
+		Owner: "acme", RepoSlug: "api", MainBranch: "main",
+	}).WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cleanup to be canceled, got %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected no deletion, got %v", deleted)
+	}
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func newETagHandler(fullResponses, notModified *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(fullResponses, 1)
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"type": "repository", "slug": "cached"}`)
+	}
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	var fullResponses, notModified int32
+	c := newLocalTestClient(t, newETagHandler(&fullResponses, &notModified))
+	c.EnableCache(bitbucket.NewMemoryCache(10))
+
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	for i := 0; i < 3; i++ {
+		repo, err := c.Repositories.Repository.Get(opt)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		if repo.Slug != "cached" {
+			t.Fatalf("request %d: unexpected slug %q", i, repo.Slug)
+		}
+	}
+
+	if fullResponses != 1 || notModified != 2 {
+		t.Errorf("expected 1 full response and 2 revalidations, got %d and %d", fullResponses, notModified)
+	}
+}
+
+func TestCacheBypass(t *testing.T) {
+	var fullResponses, notModified int32
+	c := newLocalTestClient(t, newETagHandler(&fullResponses, &notModified))
+	c.EnableCache(bitbucket.NewMemoryCache(10))
+
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	if _, err := c.Repositories.Repository.Get(opt); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Repositories.Repository.Get(opt.WithContext(bitbucket.BypassCache(context.Background()))); err != nil {
+		t.Fatal(err)
+	}
+
+	if fullResponses != 2 || notModified != 0 {
+		t.Errorf("expected the bypassed call to skip revalidation, got %d full responses and %d revalidations", fullResponses, notModified)
+	}
+}
+
+func TestCacheIsKeyedByIdentity(t *testing.T) {
+	var fullResponses, notModified int32
+	c := newLocalTestClient(t, newETagHandler(&fullResponses, &notModified))
+	cache := bitbucket.NewMemoryCache(10)
+	c.EnableCache(cache)
+
+	other, err := bitbucket.NewBasicAuth("someone-else", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseURL, err := url.Parse(c.GetApiBaseURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.SetApiBaseURL(*baseURL)
+	other.HttpClient = c.HttpClient
+
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	if _, err := c.Repositories.Repository.Get(opt); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Repositories.Repository.Get(opt); err != nil {
+		t.Fatal(err)
+	}
+
+	if fullResponses != 2 || cache.Len() != 2 {
+		t.Errorf("expected separate cache entries per identity, got %d full responses and %d entries", fullResponses, cache.Len())
+	}
+}
+
+func TestCacheSurvivesRotatingCredentials(t *testing.T) {
+	var fullResponses, notModified int32
+	server := httptest.NewServer(newETagHandler(&fullResponses, &notModified))
+	defer server.Close()
+
+	// Every request gets a new token, which only the namespace ties to an
+	// account.
+	ts := &countingTokenSource{}
+	c, err := bitbucket.NewOAuthWithTokenSource(ts, bitbucket.WithBaseURL(server.URL+"/2.0"),
+		bitbucket.WithCache(bitbucket.NewMemoryCache(10)), bitbucket.WithCacheNamespace("acme"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Repositories.Repository.Get(opt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ts.calls != 2 {
+		t.Fatalf("expected a token per request, got %d", ts.calls)
+	}
+	if fullResponses != 1 || notModified != 1 {
+		t.Errorf("expected the second request to revalidate the cached response, got %d full responses and %d revalidations", fullResponses, notModified)
+	}
+}
+
+func TestCacheSkipsTokenSourcesWithoutNamespace(t *testing.T) {
+	var fullResponses, notModified int32
+	server := httptest.NewServer(newETagHandler(&fullResponses, &notModified))
+	defer server.Close()
+
+	cache := bitbucket.NewMemoryCache(10)
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	for i := 0; i < 2; i++ {
+		// A new client per request, as a pool would rebuild after an eviction.
+		c, err := bitbucket.NewOAuthWithTokenSource(&countingTokenSource{},
+			bitbucket.WithBaseURL(server.URL+"/2.0"), bitbucket.WithCache(cache))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Repositories.Repository.Get(opt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fullResponses != 2 || cache.Len() != 0 {
+		t.Errorf("expected token source responses not to be cached, got %d full responses and %d entries", fullResponses, cache.Len())
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := bitbucket.NewMemoryCache(2)
+	cache.Set("a", []byte("a"))
+	cache.Set("b", []byte("b"))
+	cache.Get("a")
+	cache.Set("c", []byte("c"))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("expected a to be kept")
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	var fullResponses, notModified int32
+	c := newLocalTestClient(t, newETagHandler(&fullResponses, &notModified))
+	dir := t.TempDir()
+
+	// Each iteration starts from a fresh transport, as a new process would.
+	for i := 0; i < 2; i++ {
+		cache, err := bitbucket.NewDiskCache(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.HttpClient = new(http.Client)
+		c.EnableCache(cache)
+		if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if fullResponses != 1 || notModified != 1 {
+		t.Errorf("expected the second transport to revalidate the entry on disk, got %d full responses and %d revalidations", fullResponses, notModified)
+	}
+}
@@ -31,6 +31,7 @@ func TestClientNewBasicAuth(t *testing.T) {
 }
 
 func TestClientNewBasicAuthWithCaCert(t *testing.T) {
+	skipWhenRecording(t)
 
 	caCerts, err := FetchCACerts("api.bitbucket.org", "443")
 	if err != nil {
@@ -64,6 +65,7 @@ func TestClientWithBearerToken(t *testing.T) {
 }
 
 func TestClientWithBearerTokenWithCaCert(t *testing.T) {
+	skipWhenRecording(t)
 
 	caCerts, err := FetchCACerts("api.bitbucket.org", "443")
 	if err != nil {
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+var dispositionName = regexp.MustCompile(`; name="([^"]*)"`)
+
+type committedPart struct {
+	disposition string
+	content     string
+}
+
+func TestCommitBuilderStreamsChanges(t *testing.T) {
+	fields := map[string][]string{}
+	parts := map[string]committedPart{}
+	var chunked bool
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/2.0/repositories/acme/api/src/abc123/bin/old #1?.sh":
+			if r.URL.Query().Get("format") == "meta" {
+				fmt.Fprint(w, `{"type": "commit_file", "path": "bin/old #1?.sh", "attributes": ["executable"]}`)
+				return
+			}
+			fmt.Fprint(w, "#!/bin/sh\necho old")
+		case r.Method == http.MethodPost && r.URL.Path == "/2.0/repositories/acme/api/src":
+			chunked = r.ContentLength == -1
+			reader, err := r.MultipartReader()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for {
+				// Bitbucket's x-attributes parameter is not valid MIME syntax,
+				// so the disposition is parsed by hand.
+				part, err := reader.NextRawPart()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				data, _ := io.ReadAll(part)
+				disposition := part.Header.Get("Content-Disposition")
+				name := dispositionName.FindStringSubmatch(disposition)[1]
+				if !strings.Contains(disposition, "filename=") {
+					fields[name] = append(fields[name], string(data))
+					continue
+				}
+				parts[name] = committedPart{disposition: disposition, content: string(data)}
+			}
+			w.Header().Set("Location", "https://api.bitbucket.org/2.0/repositories/acme/api/commit/def456")
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	hash, err := c.Repositories.Repository.NewCommit("acme", "api").
+		Message("Regenerate clients").
+		Author("Bot <bot@example.com>").
+		Branch("main").
+		Parents("abc123").
+		Put("gen/client.go", strings.NewReader("package gen")).
+		PutBytes("README.md", []byte("# api")).
+		PutMode("bin/build.sh", strings.NewReader("#!/bin/sh"), bitbucket.CommitFileExecutable).
+		Symlink("docs/README.md", "../README.md").
+		Delete("gen/legacy.go").
+		Rename("bin/old #1?.sh", "bin/new.sh").
+		Commit(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash != "def456" {
+		t.Errorf("unexpected commit hash %q", hash)
+	}
+	if !chunked {
+		t.Error("expected the body to be streamed")
+	}
+	for name, want := range map[string]string{"message": "Regenerate clients", "author": "Bot <bot@example.com>", "branch": "main", "parents": "abc123"} {
+		if got := fields[name]; len(got) != 1 || got[0] != want {
+			t.Errorf("unexpected %s %v", name, got)
+		}
+	}
+	if deleted := strings.Join(fields["files"], ","); deleted != "gen/legacy.go,bin/old #1?.sh" {
+		t.Errorf("unexpected deleted files %q", deleted)
+	}
+	if parts["gen/client.go"].content != "package gen" || parts["README.md"].content != "# api" {
+		t.Errorf("unexpected file contents %+v", parts)
+	}
+	if d := parts["bin/build.sh"].disposition; !strings.Contains(d, `x-attributes:"executable"`) {
+		t.Errorf("expected build.sh to be executable, got %q", d)
+	}
+	if p := parts["docs/README.md"]; p.content != "../README.md" || !strings.Contains(p.disposition, `x-attributes:"link"`) {
+		t.Errorf("unexpected symlink %+v", p)
+	}
+	if p := parts["bin/new.sh"]; p.content != "#!/bin/sh\necho old" || !strings.Contains(p.disposition, `x-attributes:"executable"`) {
+		t.Errorf("expected the renamed file to keep its content and mode, got %+v", p)
+	}
+}
+
+func TestCommitBuilderReportsConflicts(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"type": "error", "error": {"message": "Parent commit is not the tip of the branch"}}`)
+	})
+
+	_, err := c.Repositories.Repository.NewCommit("acme", "api").
+		Branch("main").Parents("stale").PutBytes("a.txt", []byte("a")).
+		Commit(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "409") {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+
+	if _, err := c.Repositories.Repository.NewCommit("acme", "api").Commit(context.Background()); err == nil {
+		t.Error("expected an empty commit to be rejected")
+	}
+	if _, err := c.Repositories.Repository.NewCommit("acme", "api").Rename("a", "b").Commit(context.Background()); err == nil {
+		t.Error("expected a rename without a source revision to be rejected")
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func TestCompare(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/2.0/repositories/acme/api/merge-base/main..feature":
+			fmt.Fprint(w, `{"type": "commit", "hash": "base1", "date": "2024-01-01T10:00:00+00:00", "message": "Release 1.0",
+				"author": {"raw": "Jane <jane@example.com>", "user": {"display_name": "Jane Doe"}}}`)
+		case r.URL.Path == "/2.0/repositories/acme/api/commits" && q.Get("include") == "feature" && q.Get("exclude") == "main":
+			if q.Get("page") == "" {
+				fmt.Fprintf(w, `{"next": "http://%s%s?include=feature&exclude=main&page=2", "values": [
+					{"hash": "f2", "message": "Add flags", "date": "2024-01-03T10:00:00+00:00"}
+				]}`, r.Host, r.URL.Path)
+				return
+			}
+			fmt.Fprint(w, `{"values": [{"hash": "f1", "message": "Add server", "date": "2024-01-02T10:00:00+00:00", "parents": [{"hash": "base1"}]}]}`)
+		case r.URL.Path == "/2.0/repositories/acme/api/commits" && q.Get("include") == "main" && q.Get("exclude") == "feature":
+			if q.Get("fields") != "values.hash,next" || q.Get("pagelen") != "100" {
+				t.Errorf("expected only hashes to be requested to count commits, got %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"values": [{"hash": "m3"}, {"hash": "m2"}, {"hash": "m1"}]}`)
+		case r.URL.Path == "/2.0/repositories/acme/api/diffstat/feature..main":
+			if q.Get("topic") != "true" {
+				t.Error("expected a three-dot diff stat")
+			}
+			fmt.Fprint(w, `{"values": [
+				{"type": "diffstat", "status": "modified", "lines_added": 10, "lines_removed": 2, "new": {"path": "server.go"}},
+				{"type": "diffstat", "status": "added", "lines_added": 5, "new": {"path": "flags.go"}}
+			]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	comparison, err := c.Repositories.Repository.Compare((&bitbucket.CompareOptions{
+		Owner: "acme", RepoSlug: "api", Base: "main", Head: "feature",
+	}).WithContext(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mb := comparison.MergeBase; mb.Hash != "base1" || mb.Author.User == nil || mb.Author.User.DisplayName != "Jane Doe" || mb.Date.Day() != 1 {
+		t.Errorf("unexpected merge base %+v", mb)
+	}
+	if comparison.Ahead != 2 || comparison.Behind != 3 {
+		t.Errorf("expected 2 ahead and 3 behind, got %d and %d", comparison.Ahead, comparison.Behind)
+	}
+	if comparison.Commits[0].Hash != "f2" || comparison.Commits[1].Parents[0].Hash != "base1" {
+		t.Errorf("unexpected commits %+v", comparison.Commits)
+	}
+	if comparison.FilesChanged != 2 || comparison.LinesAdded != 15 || comparison.LinesRemoved != 2 {
+		t.Errorf("unexpected totals %+v", comparison)
+	}
+	if comparison.DiffStats[1].Status != "added" {
+		t.Errorf("unexpected diff stats %+v", comparison.DiffStats[1])
+	}
+}
+
+func TestMergeBaseError(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"type": "error", "error": {"message": "No merge base"}}`)
+	})
+
+	if _, err := c.Repositories.Repository.MergeBase(&bitbucket.CompareOptions{
+		Owner: "acme", RepoSlug: "api", Base: "main", Head: "orphan",
+	}); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func TestConnectCanonicalRequest(t *testing.T) {
+	u, err := url.Parse("https://example.com/app/path/to/service/?zee_last=param&repeated=parameter%202&first=param&repeated=parameter%201&empty=&repeated=parameter%203&jwt=ignored&a+b=c%2Bd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := bitbucket.ConnectCanonicalRequest("get", u, "/app")
+	want := "GET&/path/to/service&a%20b=c%2Bd&empty=&first=param&repeated=parameter%201,parameter%202,parameter%203&zee_last=param"
+	if got != want {
+		t.Errorf("unexpected canonical request\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestConnectJWTSignsRequests(t *testing.T) {
+	secrets := map[string]string{"app-key": "shared-secret"}
+	lookup := func(claims *bitbucket.ConnectClaims) (string, error) {
+		return secrets[claims.Issuer], nil
+	}
+
+	var verified []*bitbucket.ConnectClaims
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := bitbucket.VerifyConnectRequest(r, "", lookup)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		verified = append(verified, claims)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"slug": "second"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"values": [{"slug": "first"}], "next": "http://%s/2.0/repositories/owner?page=2&role=member"}`, r.Host)
+	}))
+	defer server.Close()
+
+	c, err := bitbucket.NewConnectJWT("app-key", "client-key", "shared-secret", bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := c.Repositories.ListForAccount(&bitbucket.RepositoriesOptions{Owner: "owner", Role: "member"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Items) != 2 || len(verified) != 2 {
+		t.Fatalf("expected both pages to be fetched with verified tokens, got %d items and %d tokens", len(res.Items), len(verified))
+	}
+	if verified[0].Subject != "client-key" || verified[0].QSH == "" {
+		t.Errorf("unexpected claims %+v", verified[0])
+	}
+}
+
+func TestVerifyConnectRequestRejectsBadTokens(t *testing.T) {
+	lookup := func(claims *bitbucket.ConnectClaims) (string, error) {
+		if claims.Issuer != "client-key" {
+			return "", errors.New("unknown installation")
+		}
+		return "shared-secret", nil
+	}
+	now := time.Now()
+	sign := func(claims *bitbucket.ConnectClaims, secret string) string {
+		token, err := bitbucket.SignConnectJWT(claims, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	request := func(target, token string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, target, strings.NewReader("{}"))
+		r.Header.Set("Authorization", "JWT "+token)
+		return r
+	}
+
+	target := "https://app.example.com/addon/webhook?event=repo:push"
+	u, _ := url.Parse(target)
+	valid := &bitbucket.ConnectClaims{
+		Issuer:    "client-key",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+		QSH:       bitbucket.ConnectQSH(http.MethodPost, u, "/addon"),
+	}
+	if _, err := bitbucket.VerifyConnectRequest(request(target, sign(valid, "shared-secret")), "/addon", lookup); err != nil {
+		t.Fatalf("expected a valid token to be accepted: %v", err)
+	}
+
+	expired := *valid
+	expired.ExpiresAt = now.Add(-time.Hour).Unix()
+	unknown := *valid
+	unknown.Issuer = "someone-else"
+
+	for name, r := range map[string]*http.Request{
+		"wrong secret":    request(target, sign(valid, "other-secret")),
+		"expired":         request(target, sign(&expired, "shared-secret")),
+		"unknown issuer":  request(target, sign(&unknown, "shared-secret")),
+		"different query": request(target+"&extra=1", sign(valid, "shared-secret")),
+		"no token":        httptest.NewRequest(http.MethodPost, target, nil),
+	} {
+		if _, err := bitbucket.VerifyConnectRequest(r, "/addon", lookup); err == nil {
+			t.Errorf("%s: expected the token to be rejected", name)
+		}
+	}
+}
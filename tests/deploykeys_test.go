@@ -29,7 +29,7 @@ func TestDeployKey(t *testing.T) {
 		t.Error("BITBUCKET_TEST_REPOSLUG is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -124,7 +124,7 @@ func TestDeployKeyWithComment(t *testing.T) {
 		t.Error("BITBUCKET_TEST_REPOSLUG is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -226,7 +226,7 @@ func TestListDeployKeys(t *testing.T) {
 		t.Error("BITBUCKET_TEST_REPOSLUG is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -23,7 +23,7 @@ func TestDiff(t *testing.T) {
 		t.Error("BITBUCKET_TEST_PASSWORD is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -62,7 +62,7 @@ func TestGetDiffStat(t *testing.T) {
 		t.Error("BITBUCKET_TEST_PASSWORD is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -101,7 +101,7 @@ func TestGetDiffStatWithFields(t *testing.T) {
 		t.Error("BITBUCKET_TEST_PASSWORD is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
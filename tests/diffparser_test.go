@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+const samplePatch = `From 1a2b3c Mon Sep 17 00:00:00 2001
+From: Jane Doe <jane@example.com>
+Subject: [PATCH] Rework the server
+
+---
+diff --git a/server.go b/server.go
+index 1111111..2222222 100644
+--- a/server.go
++++ b/server.go
+@@ -1,4 +1,5 @@ package main
+ package main
+-import "log"
++import "fmt"
++import "os"
+
+ func main() {
+@@ -10,2 +11,2 @@ func main() {
+-	log.Print("bye")
++	fmt.Println("bye")
+ }
+\ No newline at end of file
+diff --git a/old name.txt b/new name.txt
+similarity index 90%
+rename from old name.txt
+rename to new name.txt
+index 3333333..4444444 100644
+--- a/old name.txt
++++ b/new name.txt
+@@ -1 +1 @@
+-hello
++hello world
+diff --git a/added.go b/added.go
+new file mode 100644
+index 0000000..5555555
+--- /dev/null
++++ b/added.go
+@@ -0,0 +1,2 @@
++package main
++
+diff --git a/removed.go b/removed.go
+deleted file mode 100644
+index 6666666..0000000
+--- a/removed.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package main
+diff --git a/logo.png b/logo.png
+index 7777777..8888888 100644
+Binary files a/logo.png and b/logo.png differ
+diff --git a/build.sh b/build.sh
+old mode 100644
+new mode 100755
+diff --git "a/caf\303\251.md" "b/caf\303\251.md"
+index 9999999..aaaaaaa 100644
+--- "a/caf\303\251.md"
++++ "b/caf\303\251.md"
+@@ -1 +1 @@
+-old
++new
+--
+2.43.0
+`
+
+func TestParseDiff(t *testing.T) {
+	files, err := bitbucket.ParseDiff(strings.NewReader(samplePatch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 7 {
+		t.Fatalf("expected 7 files, got %d", len(files))
+	}
+
+	server := files[0]
+	if server.OldPath != "server.go" || server.NewPath != "server.go" || len(server.Hunks) != 2 {
+		t.Fatalf("unexpected file %+v", server)
+	}
+	if h := server.Hunks[0]; h.OldStart != 1 || h.OldLines != 4 || h.NewStart != 1 || h.NewLines != 5 || h.Section != "package main" {
+		t.Errorf("unexpected hunk %+v", h)
+	}
+	line, ok := server.LineAtPosition(3)
+	if !ok || line.Kind != bitbucket.DiffLineAdded || line.Content != `import "fmt"` || line.NewLine != 2 || line.OldLine != 0 {
+		t.Errorf("unexpected line at position 3 %+v", line)
+	}
+	// The second hunk header takes position 7.
+	line, ok = server.LineAtPosition(8)
+	if !ok || line.Kind != bitbucket.DiffLineDeleted || line.OldLine != 10 {
+		t.Errorf("unexpected line at position 8 %+v", line)
+	}
+	if line, ok := server.NewLine(12); !ok || line.Content != "}" || line.OldLine != 11 || !line.NoNewline {
+		t.Errorf("unexpected new line 12 %+v", line)
+	}
+	if _, ok := server.NewLine(7); ok {
+		t.Error("expected lines outside of the hunks not to be found")
+	}
+
+	if f := files[1]; !f.IsRename || f.OldPath != "old name.txt" || f.NewPath != "new name.txt" || f.Similarity != 90 {
+		t.Errorf("unexpected rename %+v", f)
+	}
+	if f := files[2]; !f.IsNew || f.OldPath != "" || f.Path() != "added.go" || f.NewMode != "100644" {
+		t.Errorf("unexpected added file %+v", f)
+	}
+	if f := files[3]; !f.IsDeleted || f.NewPath != "" || f.Path() != "removed.go" || f.Hunks[0].Lines[0].OldLine != 1 {
+		t.Errorf("unexpected deleted file %+v", f)
+	}
+	if f := files[4]; !f.IsBinary || f.Path() != "logo.png" || len(f.Hunks) != 0 {
+		t.Errorf("unexpected binary file %+v", f)
+	}
+	if f := files[5]; !f.IsModeChange() || f.OldMode != "100644" || f.NewMode != "100755" || f.Path() != "build.sh" {
+		t.Errorf("unexpected mode change %+v", f)
+	}
+	if f := files[6]; f.Path() != "café.md" || len(f.Hunks[0].Lines) != 2 {
+		t.Errorf("expected the quoted path to be unquoted and the signature ignored, got %+v", f)
+	}
+}
+
+func TestParseDiffRejectsTruncatedHunks(t *testing.T) {
+	diff := "diff --git a/a b/a\n--- a/a\n+++ b/a\n@@ -1,3 +1,3 @@\n a\n"
+	if _, err := bitbucket.ParseDiff(strings.NewReader(diff)); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestInlineCommentFromDiffPosition(t *testing.T) {
+	var comment map[string]interface{}
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/repositories/acme/api/pullrequests/7/diff":
+			fmt.Fprint(w, samplePatch)
+		case "/2.0/repositories/acme/api/pullrequests/7/comments":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &comment)
+			fmt.Fprint(w, `{"id": 1}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	files, err := bitbucket.ParseDiffResponse(c.Repositories.PullRequests.Diff(&bitbucket.PullRequestsOptions{
+		Owner: "acme", RepoSlug: "api", ID: "7",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	inline, err := files[0].InlineAt(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := files[0].InlineAt(100); err == nil {
+		t.Error("expected a position outside of the diff to be rejected")
+	}
+
+	_, err = c.Repositories.PullRequests.AddComment(&bitbucket.PullRequestCommentOptions{
+		Owner: "acme", RepoSlug: "api", PullRequestID: "7", Content: "Keep log?", Inline: inline,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"path": "server.go", "from": float64(2)}
+	if got := comment["inline"]; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("expected inline %v, got %v", want, got)
+	}
+}
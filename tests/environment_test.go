@@ -28,7 +28,7 @@ func TestListEnvironments(t *testing.T) {
 		t.Error("BITBUCKET_TEST_REPOSLUG is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -68,7 +68,7 @@ func TestEndToEndEnvironments(t *testing.T) {
 		t.Error("BITBUCKET_TEST_REPOSLUG is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
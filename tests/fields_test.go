@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func TestFieldsParamOnGet(t *testing.T) {
+	var gotFields string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotFields = r.URL.Query().Get("fields")
+		fmt.Fprint(w, `{"type": "repository", "slug": "repo"}`)
+	})
+
+	repo, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{
+		Owner:    "owner",
+		RepoSlug: "repo",
+		Fields:   []string{"slug", "-links", "+owner.display_name"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotFields != "slug,-links,+owner.display_name" {
+		t.Errorf("unexpected fields param: %q", gotFields)
+	}
+	if repo.Slug != "repo" {
+		t.Errorf("expected slug to be decoded from a partial response, got %q", repo.Slug)
+	}
+}
+
+func TestFieldsParamKeepsAutoPaging(t *testing.T) {
+	var requests []string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Query().Get("fields"))
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"slug": "second"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"values": [{"slug": "first"}], "next": "http://%s/2.0/repositories/owner?page=2"}`, r.Host)
+	})
+
+	res, err := c.Repositories.ListForAccount(&bitbucket.RepositoriesOptions{
+		Owner:  "owner",
+		Fields: []string{"values.slug"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+	if requests[0] != "values.slug,next" {
+		t.Errorf("expected next to be requested alongside the selected fields, got %q", requests[0])
+	}
+	if len(res.Items) != 2 || res.Items[1].Slug != "second" {
+		t.Errorf("unexpected items: %+v", res.Items)
+	}
+}
+
+func TestFieldsParamPartialListResponse(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"size": 3}`)
+	})
+
+	branches, err := c.Repositories.Repository.ListBranches(&bitbucket.RepositoryBranchOptions{
+		Owner:    "owner",
+		RepoSlug: "repo",
+		Fields:   []string{"size"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branches.Size != 3 || len(branches.Branches) != 0 {
+		t.Errorf("unexpected branches: %+v", branches)
+	}
+
+	workspaces, err := c.Workspaces.ListWithOptions(&bitbucket.WorkspaceOptions{Fields: []string{"size"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workspaces.Size != 3 {
+		t.Errorf("unexpected workspaces: %+v", workspaces)
+	}
+}
+
+func TestFieldsParamOnUserAndWorkspaceOptions(t *testing.T) {
+	requests := map[string]string{}
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path] = r.URL.Query().Get("fields")
+		if r.URL.Path == "/2.0/workspaces/acme/members" {
+			fmt.Fprint(w, `{"values": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"type": "user", "display_name": "Jane"}`)
+	})
+
+	user, err := c.Users.GetWithOptions(&bitbucket.UserOptions{Username: "jane", Fields: []string{"display_name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.DisplayName != "Jane" {
+		t.Errorf("unexpected user %+v", user)
+	}
+	if _, err := c.Workspaces.MembersWithOptions(&bitbucket.WorkspaceOptions{Workspace: "acme", Fields: []string{"values.user.uuid"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := requests["/2.0/users/jane/"]; got != "display_name" {
+		t.Errorf("expected a single object to be read with the fields as given, got %q", got)
+	}
+	if got := requests["/2.0/workspaces/acme/members"]; got != "values.user.uuid,next" {
+		t.Errorf("expected a paginated list to keep next, got %q", got)
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func TestFileHistoryFollowsRenames(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/repositories/acme/api/filehistory/main/cmd/server.go" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("renames") != "" {
+			t.Error("expected renames to be followed by default")
+		}
+		if !strings.Contains(r.URL.Query().Get("fields"), "+values.commit.message") {
+			t.Errorf("expected the commit details to be requested, got %q", r.URL.Query().Get("fields"))
+		}
+		fmt.Fprint(w, `{"values": [
+			{"type": "commit_file", "path": "cmd/server.go", "size": 120, "commit": {"hash": "c3", "message": "Move the server"}},
+			{"type": "commit_file", "path": "server.go", "size": 100, "commit": {"hash": "c1", "message": "Add the server"}}
+		]}`)
+	})
+
+	var paths, hashes []string
+	for file, err := range c.Repositories.Repository.FileHistory((&bitbucket.FileHistoryOptions{
+		Owner: "acme", RepoSlug: "api", Commit: "main", Path: "cmd/server.go",
+	}).WithContext(context.Background())) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, file.Path)
+		hashes = append(hashes, file.Commit["hash"].(string))
+	}
+	if strings.Join(paths, ",") != "cmd/server.go,server.go" || strings.Join(hashes, ",") != "c3,c1" {
+		t.Errorf("unexpected history %v %v", paths, hashes)
+	}
+}
+
+func TestLastCommits(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/repositories/acme/api/src/main/cmd":
+			fmt.Fprint(w, `{"values": [
+				{"type": "commit_file", "path": "cmd/main.go", "commit": {"hash": "abc123"}},
+				{"type": "commit_directory", "path": "cmd/internal", "commit": {"hash": "abc123"}}
+			]}`)
+		case "/2.0/repositories/acme/api/filehistory/abc123/cmd/main.go":
+			if r.URL.Query().Get("pagelen") != "1" {
+				t.Errorf("expected only the last commit to be requested, got %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"values": [{"type": "commit_file", "path": "cmd/main.go", "commit": {
+				"hash": "c2", "message": "Add flags", "date": "2024-03-01T10:00:00+00:00",
+				"author": {"raw": "Jane <jane@example.com>", "user": {"display_name": "Jane Doe"}}
+			}}]}`)
+		case "/2.0/repositories/acme/api/filehistory/abc123/cmd/internal":
+			fmt.Fprint(w, `{"values": [{"type": "commit_directory", "path": "cmd/internal", "commit": {
+				"hash": "c1", "message": "Initial commit", "date": "2024-01-01T10:00:00+00:00",
+				"author": {"raw": "Bot <bot@example.com>"}
+			}}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	commits, err := c.Repositories.Repository.LastCommits(&bitbucket.RepositoryFilesOptions{
+		Owner: "acme", RepoSlug: "api", Ref: "main", Path: "cmd",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(commits))
+	}
+	if got := commits[0]; got.File.Path != "cmd/main.go" || got.Hash != "c2" || got.Author != "Jane Doe" || got.Date == nil || got.Date.Month() != 3 {
+		t.Errorf("unexpected last commit %+v", got)
+	}
+	if got := commits[1]; got.File.Path != "cmd/internal" || got.Hash != "c1" || got.Author != "Bot <bot@example.com>" {
+		t.Errorf("unexpected last commit %+v", got)
+	}
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func TestRepositoryFS(t *testing.T) {
+	var contentRequests int32
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch path := strings.TrimPrefix(r.URL.Path, "/2.0/repositories/acme/api/src/"); path {
+		case "main/":
+			fmt.Fprint(w, `{"type": "commit_directory", "path": "", "commit": {"hash": "abc123"}}`)
+		case "abc123/":
+			fmt.Fprint(w, `{"values": [
+				{"type": "commit_file", "path": "README.md", "size": 5},
+				{"type": "commit_directory", "path": "docs"},
+				{"type": "commit_file", "path": "build.sh", "size": 9, "attributes": ["executable"]}
+			]}`)
+		case "abc123/docs":
+			fmt.Fprint(w, `{"values": [
+				{"type": "commit_file", "path": "docs/guide.md", "size": 7},
+				{"type": "commit_file", "path": "docs/notes.txt", "size": 5}
+			]}`)
+		case "abc123/README.md", "abc123/build.sh", "abc123/docs/guide.md", "abc123/docs/notes.txt":
+			atomic.AddInt32(&contentRequests, 1)
+			fmt.Fprint(w, map[string]string{
+				"abc123/README.md":      "# api",
+				"abc123/build.sh":       "#!/bin/sh",
+				"abc123/docs/guide.md":  "# guide",
+				"abc123/docs/notes.txt": "notes",
+			}[path])
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	fsys, err := c.Repositories.Repository.FS((&bitbucket.RepositoryFSOptions{
+		Owner: "acme", RepoSlug: "api", Ref: "main",
+	}).WithContext(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fsys.Commit() != "abc123" {
+		t.Errorf("expected the ref to be pinned, got %q", fsys.Commit())
+	}
+
+	if err := fstest.TestFS(fsys, "README.md", "build.sh", "docs/guide.md", "docs/notes.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := fs.Glob(fsys, "docs/*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(matches, []string{"docs/guide.md"}) {
+		t.Errorf("unexpected matches %v", matches)
+	}
+
+	atomic.StoreInt32(&contentRequests, 0)
+	for i := 0; i < 3; i++ {
+		data, err := fs.ReadFile(fsys, "docs/guide.md")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "# guide" {
+			t.Errorf("unexpected content %q", data)
+		}
+		data[0] = 'x'
+	}
+	if contentRequests != 0 {
+		t.Errorf("expected contents to be cached, got %d requests", contentRequests)
+	}
+
+	info, err := fs.Stat(fsys, "build.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode() != 0o555 || info.Size() != 9 {
+		t.Errorf("unexpected file info %v %d", info.Mode(), info.Size())
+	}
+	if _, err := fs.Stat(fsys, "docs/missing.md"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a missing file not to exist, got %v", err)
+	}
+	if _, err := fs.Stat(fsys, "README.md/child"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a path below a file not to exist, got %v", err)
+	}
+}
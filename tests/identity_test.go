@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func newIdentityServer(t *testing.T, headers map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/user" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") == "Bearer revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		for name, value := range headers {
+			w.Header().Set(name, value)
+		}
+		fmt.Fprint(w, `{"type": "app_user", "uuid": "{bot}", "display_name": "Repo token", "account_id": "123"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWhoAmI(t *testing.T) {
+	server := newIdentityServer(t, map[string]string{
+		"X-OAuth-Scopes":    "repository:write, pullrequest",
+		"X-Credential-Type": "repo_access_token",
+	})
+	c, err := bitbucket.New(bitbucket.BearerTokenCredentials("token"), bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := c.WhoAmI(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Type != "app_user" || identity.DisplayName != "Repo token" || identity.AccountId != "123" {
+		t.Errorf("unexpected principal %+v", identity)
+	}
+	if identity.CredentialType != bitbucket.CredentialTypeRepoAccessToken {
+		t.Errorf("unexpected credential type %q", identity.CredentialType)
+	}
+	if !identity.ScopesKnown || !reflect.DeepEqual(identity.Scopes, []string{"pullrequest", "repository:write"}) {
+		t.Errorf("unexpected scopes %v", identity.Scopes)
+	}
+	if !identity.HasScope("repository") {
+		t.Error("expected repository:write to imply repository")
+	}
+}
+
+func TestWhoAmIFallsBackToConfiguredCredentialType(t *testing.T) {
+	server := newIdentityServer(t, nil)
+	c, err := bitbucket.New(bitbucket.BasicCredentials("user", "app-password"), bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := c.RequireScopes(context.Background(), "repository:admin")
+	if err != nil {
+		t.Fatalf("expected unknown scopes not to fail the preflight: %v", err)
+	}
+	if identity.CredentialType != bitbucket.CredentialTypeBasic || identity.ScopesKnown {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+}
+
+func TestWhoAmIMapsCredentialTypeHeader(t *testing.T) {
+	for header, want := range map[string]string{
+		"Workspace_Access_Token": bitbucket.CredentialTypeWorkspaceAccessToken,
+		"app_password":           bitbucket.CredentialTypeBasic,
+		"something_new":          bitbucket.CredentialTypeBearer,
+	} {
+		server := newIdentityServer(t, map[string]string{"X-Credential-Type": header})
+		c, err := bitbucket.New(bitbucket.BearerTokenCredentials("token"), bitbucket.WithBaseURL(server.URL+"/2.0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		identity, err := c.WhoAmI(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if identity.CredentialType != want {
+			t.Errorf("expected %q to be reported as %q, got %q", header, want, identity.CredentialType)
+		}
+	}
+}
+
+func TestRequireScopes(t *testing.T) {
+	server := newIdentityServer(t, map[string]string{"X-OAuth-Scopes": "repository, pullrequest:write"})
+	c, err := bitbucket.New(bitbucket.BearerTokenCredentials("token"), bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.RequireScopes(context.Background(), "repository:write", "pullrequest"); err != nil {
+		t.Errorf("expected implied scopes to satisfy the preflight: %v", err)
+	}
+
+	_, err = c.RequireScopes(context.Background(), "pipeline", "repository")
+	var missing *bitbucket.MissingScopesError
+	if !errors.As(err, &missing) || !reflect.DeepEqual(missing.Missing, []string{"pipeline"}) {
+		t.Errorf("expected pipeline to be reported missing, got %v", err)
+	}
+
+	revoked, err := bitbucket.New(bitbucket.BearerTokenCredentials("revoked"), bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var status *bitbucket.UnexpectedResponseStatusError
+	if _, err := revoked.RequireScopes(context.Background()); !errors.As(err, &status) {
+		t.Errorf("expected a rejected credential to fail with the response status, got %v", err)
+	}
+}
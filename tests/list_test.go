@@ -14,7 +14,7 @@ func TestList(t *testing.T) {
 	pass := os.Getenv("BITBUCKET_TEST_PASSWORD")
 	owner := os.Getenv("BITBUCKET_TEST_OWNER")
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
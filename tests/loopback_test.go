@@ -0,0 +1,152 @@
+package tests
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+	"golang.org/x/oauth2"
+)
+
+// newPKCETokenServer serves a token endpoint that only accepts the code "the-code"
+// together with a verifier matching the challenge sent to the consent page.
+func newPKCETokenServer(t *testing.T, challenge *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "the-code" || base64.RawURLEncoding.EncodeToString(sum[:]) != *challenge {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "loopback-token", "token_type": "bearer", "refresh_token": "r", "expires_in": 3600}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// redirectTo simulates the browser following the redirect from the consent page.
+func redirectTo(authURL string, challenge *string, state func(string) string) error {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	*challenge = q.Get("code_challenge")
+	if q.Get("code_challenge_method") != "S256" {
+		return fmt.Errorf("unexpected challenge method %q", q.Get("code_challenge_method"))
+	}
+	callback := q.Get("redirect_uri") + "?" + url.Values{"code": {"the-code"}, "state": {state(q.Get("state"))}}.Encode()
+	go func() {
+		if resp, err := http.Get(callback); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	return nil
+}
+
+func TestNewOAuthWithLoopback(t *testing.T) {
+	var challenge string
+	tokenServer := newPKCETokenServer(t, &challenge)
+	api, seen := newAuthorizationRecorder(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := bitbucket.NewOAuthWithLoopback((&bitbucket.LoopbackOAuthOptions{
+		ClientID:      "id",
+		ClientSecret:  "secret",
+		Endpoint:      oauth2.Endpoint{AuthURL: tokenServer.URL + "/authorize", TokenURL: tokenServer.URL + "/token"},
+		ClientOptions: []bitbucket.Option{bitbucket.WithBaseURL(api.URL + "/2.0")},
+		OpenURL: func(authURL string) error {
+			return redirectTo(authURL, &challenge, func(state string) string { return state })
+		},
+	}).WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*seen) != 1 || (*seen)[0] != "Bearer loopback-token" {
+		t.Errorf("expected the exchanged token to be used, got %v", *seen)
+	}
+}
+
+func TestNewOAuthWithLoopbackIgnoresWrongState(t *testing.T) {
+	var challenge string
+	tokenServer := newPKCETokenServer(t, &challenge)
+
+	statuses := make(chan int, 2)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := bitbucket.NewOAuthWithLoopback((&bitbucket.LoopbackOAuthOptions{
+		ClientID: "id",
+		Endpoint: oauth2.Endpoint{AuthURL: tokenServer.URL + "/authorize", TokenURL: tokenServer.URL + "/token"},
+		OpenURL: func(authURL string) error {
+			u, err := url.Parse(authURL)
+			if err != nil {
+				return err
+			}
+			redirect := u.Query().Get("redirect_uri")
+			// A prefetch or a stale redirect from another login must not end this one.
+			for _, q := range []url.Values{
+				{"code": {"the-code"}, "state": {"forged"}},
+				{"error": {"access_denied"}, "state": {"forged"}},
+			} {
+				resp, err := http.Get(redirect + "?" + q.Encode())
+				if err != nil {
+					return err
+				}
+				resp.Body.Close()
+				statuses <- resp.StatusCode
+			}
+			return redirectTo(authURL, &challenge, func(state string) string { return state })
+		},
+	}).WithContext(ctx))
+	if err != nil {
+		t.Fatalf("expected the callback with the right state to complete the flow, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if status := <-statuses; status != http.StatusBadRequest {
+			t.Errorf("expected a callback with a forged state to get 400, got %d", status)
+		}
+	}
+}
+
+func TestNewOAuthWithLoopbackReportsAuthorizationError(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := bitbucket.NewOAuthWithLoopback((&bitbucket.LoopbackOAuthOptions{
+		ClientID: "id",
+		Endpoint: oauth2.Endpoint{AuthURL: "http://127.0.0.1/authorize", TokenURL: "http://127.0.0.1/token"},
+		OpenURL: func(authURL string) error {
+			u, err := url.Parse(authURL)
+			if err != nil {
+				return err
+			}
+			q := url.Values{"error": {"access_denied"}, "state": {u.Query().Get("state")}}
+			go func() {
+				if resp, err := http.Get(u.Query().Get("redirect_uri") + "?" + q.Encode()); err == nil {
+					resp.Body.Close()
+				}
+			}()
+			return nil
+		},
+	}).WithContext(ctx))
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Fatalf("expected the denied authorization to end the flow, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func newRepositoryServer(t *testing.T, slug string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"type": "repository", "slug": %q}`, slug)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewClientsUseTheirOwnBaseURL(t *testing.T) {
+	first := newRepositoryServer(t, "first")
+	second := newRepositoryServer(t, "second")
+
+	c1, err := bitbucket.New(bitbucket.BasicCredentials("user", "password"), bitbucket.WithBaseURL(first.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := bitbucket.New(bitbucket.BasicCredentials("user", "password"), bitbucket.WithBaseURL(second.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	for c, want := range map[*bitbucket.Client]string{c1: "first", c2: "second"} {
+		repo, err := c.Repositories.Repository.Get(opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repo.Slug != want {
+			t.Errorf("expected %q, got %q", want, repo.Slug)
+		}
+	}
+}
+
+func TestNewWithCACertsLeavesDefaultTransportAlone(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"type": "repository", "slug": "tls"}`)
+	}))
+	defer server.Close()
+
+	caCerts := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	defaultTransport := http.DefaultTransport
+
+	c, err := bitbucket.New(bitbucket.BasicCredentials("user", "password"),
+		bitbucket.WithBaseURL(server.URL+"/2.0"), bitbucket.WithCACerts(caCerts))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if http.DefaultTransport != defaultTransport {
+		t.Fatal("expected http.DefaultTransport not to be replaced")
+	}
+
+	repo, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.Slug != "tls" {
+		t.Errorf("unexpected slug %q", repo.Slug)
+	}
+
+	other, err := bitbucket.New(bitbucket.BasicCredentials("user", "password"), bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err == nil {
+		t.Error("expected a client without the CA to reject the server certificate")
+	}
+}
+
+func TestNewWithUserAgentMiddlewareAndPageLength(t *testing.T) {
+	var userAgent, pagelen string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		pagelen = r.URL.Query().Get("pagelen")
+		fmt.Fprint(w, `{"values": []}`)
+	}))
+	defer server.Close()
+
+	var seen []string
+	mw := func(next http.RoundTripper) http.RoundTripper {
+		return bitbucket.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			seen = append(seen, req.Method+" "+req.URL.Path)
+			return next.RoundTrip(req)
+		})
+	}
+
+	c, err := bitbucket.New(bitbucket.BasicCredentials("user", "password"),
+		bitbucket.WithBaseURL(server.URL+"/2.0"),
+		bitbucket.WithUserAgent("my-tool/1.0"),
+		bitbucket.WithPageLength(50),
+		bitbucket.WithMiddleware(mw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Repositories.ListForAccount(&bitbucket.RepositoriesOptions{Owner: "owner"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if userAgent != "my-tool/1.0" {
+		t.Errorf("unexpected user agent %q", userAgent)
+	}
+	if pagelen != "50" {
+		t.Errorf("unexpected pagelen %q", pagelen)
+	}
+	if len(seen) != 1 || seen[0] != "GET /2.0/repositories/owner" {
+		t.Errorf("unexpected requests seen by the middleware: %v", seen)
+	}
+}
+
+func TestNewWithRetry(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"type": "repository", "slug": "repo"}`)
+	}))
+	defer server.Close()
+
+	c, err := bitbucket.New(bitbucket.BasicCredentials("user", "password"),
+		bitbucket.WithBaseURL(server.URL+"/2.0"),
+		bitbucket.WithRetry(3, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestNewRejectsInvalidOptions(t *testing.T) {
+	if _, err := bitbucket.New(nil, bitbucket.WithBaseURL("not a url")); err == nil {
+		t.Error("expected a relative base URL to be rejected")
+	}
+	if _, err := bitbucket.New(nil, bitbucket.WithPageLength(0)); err == nil {
+		t.Error("expected a zero page length to be rejected")
+	}
+}
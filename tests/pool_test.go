@@ -0,0 +1,224 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func TestClientPoolBuildsOneClientPerTenant(t *testing.T) {
+	server, seen := newAuthorizationRecorder(t)
+
+	var providerCalls int32
+	provider := bitbucket.CredentialProviderFunc(func(ctx context.Context, tenant string) (*bitbucket.Auth, error) {
+		atomic.AddInt32(&providerCalls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return bitbucket.BearerTokenCredentials("token-" + tenant), nil
+	})
+
+	var transportCalls int32
+	shared := bitbucket.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&transportCalls, 1)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	pool := bitbucket.NewClientPool(provider, &bitbucket.ClientPoolOptions{
+		Transport:     shared,
+		ClientOptions: []bitbucket.Option{bitbucket.WithBaseURL(server.URL + "/2.0")},
+	})
+
+	var wg sync.WaitGroup
+	clients := make([]*bitbucket.Client, 4)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := pool.Get(context.Background(), "acme")
+			if err != nil {
+				t.Error(err)
+			}
+			clients[i] = c
+		}(i)
+	}
+	wg.Wait()
+
+	for _, c := range clients[1:] {
+		if c != clients[0] {
+			t.Fatal("expected concurrent calls for one tenant to share a client")
+		}
+	}
+
+	other, err := pool.Get(context.Background(), "globex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	for _, c := range []*bitbucket.Client{clients[0], other} {
+		if _, err := c.Repositories.Repository.Get(opt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if providerCalls != 2 || pool.Len() != 2 {
+		t.Errorf("expected one provider call and client per tenant, got %d calls and %d clients", providerCalls, pool.Len())
+	}
+	if transportCalls != 2 {
+		t.Errorf("expected both clients to use the shared transport, got %d calls", transportCalls)
+	}
+	if len(*seen) != 2 || (*seen)[0] != "Bearer token-acme" || (*seen)[1] != "Bearer token-globex" {
+		t.Errorf("expected each tenant to use its own credentials, got %v", *seen)
+	}
+}
+
+func TestClientPoolEvictsIdleClients(t *testing.T) {
+	provider := bitbucket.CredentialProviderFunc(func(ctx context.Context, tenant string) (*bitbucket.Auth, error) {
+		return bitbucket.BearerTokenCredentials("token"), nil
+	})
+	pool := bitbucket.NewClientPool(provider, &bitbucket.ClientPoolOptions{IdleTimeout: 20 * time.Millisecond})
+
+	first, err := pool.Get(context.Background(), "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	if n := pool.EvictIdle(); n != 1 || pool.Len() != 0 {
+		t.Fatalf("expected the idle client to be evicted, evicted %d and %d remain", n, pool.Len())
+	}
+	second, err := pool.Get(context.Background(), "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Error("expected a new client after eviction")
+	}
+}
+
+func TestClientPoolRetriesFailedProviders(t *testing.T) {
+	var calls int32
+	provider := bitbucket.CredentialProviderFunc(func(ctx context.Context, tenant string) (*bitbucket.Auth, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return nil, errors.New("vault unavailable")
+		}
+		return bitbucket.BearerTokenCredentials("token"), nil
+	})
+	pool := bitbucket.NewClientPool(provider, nil)
+
+	if _, err := pool.Get(context.Background(), "acme"); err == nil {
+		t.Fatal("expected the provider error to be returned")
+	}
+	if _, err := pool.Get(context.Background(), "acme"); err != nil {
+		t.Fatalf("expected the second call to rebuild the client: %v", err)
+	}
+}
+
+func TestClientPoolAppliesPerTenantRateLimits(t *testing.T) {
+	server, _ := newAuthorizationRecorder(t)
+	limiters := map[string]*bitbucket.RateLimiter{
+		"acme":   bitbucket.NewRateLimiter(1, 1, 1),
+		"globex": bitbucket.NewRateLimiter(1, 1, 1),
+	}
+	provider := bitbucket.CredentialProviderFunc(func(ctx context.Context, tenant string) (*bitbucket.Auth, error) {
+		return bitbucket.BearerTokenCredentials(tenant), nil
+	})
+	pool := bitbucket.NewClientPool(provider, &bitbucket.ClientPoolOptions{
+		RateLimit:     func(tenant string) *bitbucket.RateLimiter { return limiters[tenant] },
+		ClientOptions: []bitbucket.Option{bitbucket.WithBaseURL(server.URL + "/2.0")},
+	})
+
+	c, err := pool.Get(context.Background(), "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if tokens := limiters["acme"].Stats().Tokens; tokens >= 1 {
+		t.Errorf("expected the acme request to use the acme limiter, %v tokens left", tokens)
+	}
+	if tokens := limiters["globex"].Stats().Tokens; tokens != 1 {
+		t.Errorf("expected the globex limiter to be untouched, %v tokens left", tokens)
+	}
+}
+
+func TestClientPoolIgnoresCanceledCallers(t *testing.T) {
+	release := make(chan struct{})
+	var calls int32
+	provider := bitbucket.CredentialProviderFunc(func(ctx context.Context, tenant string) (*bitbucket.Auth, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return bitbucket.BearerTokenCredentials("token"), nil
+	})
+	pool := bitbucket.NewClientPool(provider, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := pool.Get(ctx, "acme")
+		canceled <- err
+	}()
+	for atomic.LoadInt32(&calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waiting := make(chan error)
+	go func() {
+		_, err := pool.Get(context.Background(), "acme")
+		waiting <- err
+	}()
+	cancel()
+	if err := <-canceled; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the canceled caller to give up, got %v", err)
+	}
+	close(release)
+
+	if err := <-waiting; err != nil {
+		t.Errorf("expected the other caller to get the client, got %v", err)
+	}
+	if calls != 1 || pool.Len() != 1 {
+		t.Errorf("expected the client to be built once and kept, got %d calls and %d clients", calls, pool.Len())
+	}
+}
+
+func TestClientPoolSeparatesCachedResponsesByTenant(t *testing.T) {
+	var fullResponses, notModified int32
+	server := httptest.NewServer(newETagHandler(&fullResponses, &notModified))
+	defer server.Close()
+
+	provider := bitbucket.CredentialProviderFunc(func(ctx context.Context, tenant string) (*bitbucket.Auth, error) {
+		return bitbucket.OAuthTokenSourceCredentials(&countingTokenSource{}), nil
+	})
+	cache := bitbucket.NewMemoryCache(10)
+	pool := bitbucket.NewClientPool(provider, &bitbucket.ClientPoolOptions{
+		ClientOptions: []bitbucket.Option{bitbucket.WithBaseURL(server.URL + "/2.0"), bitbucket.WithCache(cache)},
+	})
+
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	for _, tenant := range []string{"acme", "globex", "acme"} {
+		c, err := pool.Get(context.Background(), tenant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Repositories.Repository.Get(opt); err != nil {
+			t.Fatal(err)
+		}
+		// The client is rebuilt, like after an idle eviction.
+		pool.Evict(tenant)
+	}
+
+	if fullResponses != 2 || notModified != 1 || cache.Len() != 2 {
+		t.Errorf("expected an entry per tenant, revalidated by the rebuilt client, got %d full responses, %d revalidations and %d entries",
+			fullResponses, notModified, cache.Len())
+	}
+}
@@ -18,7 +18,7 @@ func getClient(t *testing.T) *bitbucket.Client {
 		t.Error("BITBUCKET_TEST_PASSWORD is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func TestRateLimiterCapsInFlightRequests(t *testing.T) {
+	var current, peak int32
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		fmt.Fprint(w, `{"type": "repository", "slug": "repo"}`)
+	})
+	limiter := bitbucket.NewRateLimiter(0, 1, 2)
+	c.SetRateLimiter(limiter)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", peak)
+	}
+	if stats := limiter.Stats(); stats.InFlight != 0 || stats.Waiting != 0 {
+		t.Errorf("expected an idle limiter, got %+v", stats)
+	}
+}
+
+func TestRateLimiterThrottles(t *testing.T) {
+	limiter := bitbucket.NewRateLimiter(50, 1, 0)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		limiter.Done()
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected 5 requests at 50/s with a burst of 1 to take at least 80ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterWaitHonoursContext(t *testing.T) {
+	limiter := bitbucket.NewRateLimiter(0.001, 1, 0)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	limiter.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); err == nil {
+		t.Fatal("expected Wait to give up when the context expires")
+	}
+}
+
+func TestRateLimiterAdaptsToResponses(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	limiter := bitbucket.NewRateLimiter(10, 5, 0)
+	c.SetRateLimiter(limiter)
+
+	if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err == nil {
+		t.Fatal("expected the 429 response to be reported")
+	}
+
+	stats := limiter.Stats()
+	if stats.Limit != 1000 {
+		t.Errorf("expected the limit header to be recorded, got %d", stats.Limit)
+	}
+	if stats.Rate >= 10 {
+		t.Errorf("expected the rate to be reduced after a 429, got %v", stats.Rate)
+	}
+	if time.Until(stats.PausedUntil) < 25*time.Second {
+		t.Errorf("expected requests to be paused for Retry-After, paused until %s", stats.PausedUntil)
+	}
+}
+
+func TestRateLimiterSpreadsRemainingBudget(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "10")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(time.Now().Add(100*time.Second).Unix()))
+		fmt.Fprint(w, `{"type": "repository", "slug": "repo"}`)
+	})
+	limiter := bitbucket.NewRateLimiter(10, 5, 0)
+	c.SetRateLimiter(limiter)
+
+	if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 10 requests over about 100s, below the 1000/h the limit would allow.
+	if rate := limiter.Stats().Rate; rate > 0.11 {
+		t.Errorf("expected the rate to be capped by the remaining budget, got %v", rate)
+	}
+}
+
+func TestRateLimiterResumesAfterReset(t *testing.T) {
+	reset := time.Now().Add(time.Second).Truncate(time.Second).Add(time.Second)
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "1000")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+		fmt.Fprint(w, `{"type": "repository", "slug": "repo"}`)
+	})
+	limiter := bitbucket.NewRateLimiter(10, 5, 0)
+	c.SetRateLimiter(limiter)
+
+	if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+		t.Fatal(err)
+	}
+	if stats := limiter.Stats(); !stats.PausedUntil.Equal(reset) {
+		t.Errorf("expected requests to be paused until the reset, paused until %s", stats.PausedUntil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("expected requests to resume after the reset: %v", err)
+	}
+	limiter.Done()
+	if time.Now().Before(reset) {
+		t.Error("expected no request before the reset")
+	}
+	if rate := limiter.Stats().Rate; rate != 10 {
+		t.Errorf("expected the rate to be restored after the reset, got %v", rate)
+	}
+}
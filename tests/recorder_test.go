@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+	"github.com/ktrysmt/go-bitbucket/recorder"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	const secretToken = "s3cr3t-token"
+	server := newRepositoryServer(t, "recorded")
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	secrets := map[string]string{"ACCESS_TOKEN": secretToken}
+
+	rec, err := recorder.New(cassette, recorder.Options{Mode: recorder.ModeRecord, Secrets: secrets})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := bitbucket.New(bitbucket.BearerTokenCredentials(secretToken),
+		bitbucket.WithBaseURL(server.URL+"/2.0"), bitbucket.WithTransport(rec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	if _, err := c.Repositories.Repository.Get(opt); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), secretToken) {
+		t.Fatalf("expected the token to be scrubbed from the cassette:\n%s", data)
+	}
+
+	offline := bitbucket.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("network access while replaying")
+	})
+	player, err := recorder.New(cassette, recorder.Options{Mode: recorder.ModeReplay, Secrets: secrets, Transport: offline})
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := bitbucket.New(bitbucket.BearerTokenCredentials(secretToken),
+		bitbucket.WithBaseURL(server.URL+"/2.0"), bitbucket.WithTransport(player))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := replayed.Repositories.Repository.Get(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Slug != "recorded" {
+		t.Errorf("unexpected replayed slug %q", res.Slug)
+	}
+	if _, err := replayed.Repositories.Repository.Get(opt); err == nil {
+		t.Error("expected replaying an interaction twice to fail")
+	}
+}
+
+func TestRecorderScrubsSecrets(t *testing.T) {
+	server := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		fmt.Fprint(w, `{"access_token": "abc", "refresh_token": "def", "owner": "alice"}`)
+	})
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := recorder.New(cassette, recorder.Options{
+		Mode:    recorder.ModeRecord,
+		Secrets: map[string]string{"USERNAME": "alice"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.GetApiBaseURL()+"/site/oauth2/access_token?client_secret=xyz",
+		strings.NewReader(`{"password": "hunter2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.SetBasicAuth("alice", "hunter2")
+	resp, err := rec.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"alice", "hunter2", "xyz", `"abc"`, `"def"`, "session=", "Authorization"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("expected %s to be scrubbed from the cassette:\n%s", leaked, data)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+const refTarget = `{"type": "commit", "hash": "abc123", "date": "2024-05-06T07:08:09+00:00", "message": "Fix build\n",
+	"author": {"raw": "Jane <jane@example.com>", "user": {"display_name": "Jane Doe"}},
+	"parents": [{"type": "commit", "hash": "def456"}]}`
+
+func TestTypedRefTargets(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/repositories/acme/api/refs":
+			fmt.Fprintf(w, `{"values": [{"type": "branch", "name": "main", "target": %s}, {"type": "tag", "name": "v1.0", "target": %s}]}`, refTarget, refTarget)
+		case "/2.0/repositories/acme/api/refs/branches":
+			fmt.Fprintf(w, `{"values": [{"type": "branch", "name": "main", "target": %s}]}`, refTarget)
+		case "/2.0/repositories/acme/api/refs/branches/main":
+			fmt.Fprintf(w, `{"type": "branch", "name": "main", "target": %s}`, refTarget)
+		case "/2.0/repositories/acme/api/refs/tags":
+			fmt.Fprintf(w, `{"values": [{"type": "tag", "name": "v1.0", "target": %s}]}`, refTarget)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	checkTarget := func(what string, target *bitbucket.Commit) {
+		t.Helper()
+		want := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+		if target == nil {
+			t.Fatalf("%s: expected a target", what)
+		}
+		if target.Hash != "abc123" || !target.Date.Equal(want) || target.Message != "Fix build\n" {
+			t.Errorf("%s: unexpected target %+v", what, target)
+		}
+		if target.Author.User == nil || target.Author.User.DisplayName != "Jane Doe" || len(target.Parents) != 1 || target.Parents[0].Hash != "def456" {
+			t.Errorf("%s: unexpected author or parents %+v", what, target)
+		}
+	}
+
+	refs, err := c.Repositories.Repository.ListRefs(&bitbucket.RepositoryRefOptions{Owner: "acme", RepoSlug: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs.Refs) != 2 || refs.Refs[0].Kind != bitbucket.RefKindBranch || refs.Refs[1].Kind != bitbucket.RefKindTag {
+		t.Errorf("unexpected refs %+v", refs.Refs)
+	}
+	checkTarget("ref", refs.Refs[1].Target)
+
+	branches, err := c.Repositories.Repository.ListBranches(&bitbucket.RepositoryBranchOptions{Owner: "acme", RepoSlug: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTarget("branches", branches.Branches[0].Target)
+
+	branch, err := c.Repositories.Repository.GetBranch(&bitbucket.RepositoryBranchOptions{Owner: "acme", RepoSlug: "api", BranchName: "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTarget("branch", branch.Target)
+
+	tags, err := c.Repositories.Repository.ListTags(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTarget("tags", tags.Tags[0].Target)
+}
@@ -0,0 +1,212 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func releaseNotesServer(t *testing.T) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/2.0/repositories/acme/api/refs/tags" && q.Get("q") == `name = "v1.1.0"`:
+			fmt.Fprint(w, `{"values": [{"name": "v1.1.0", "target": {"hash": "c5", "date": "2024-02-01T10:00:00+00:00"}}]}`)
+		case r.URL.Path == "/2.0/repositories/acme/api/refs/tags" && q.Get("q") == `name = "v1.0.0"`:
+			fmt.Fprint(w, `{"values": [{"name": "v1.0.0", "target": {"hash": "c0", "date": "2024-01-01T10:00:00+00:00"}}]}`)
+		case r.URL.Path == "/2.0/repositories/acme/api/refs/tags" && strings.HasPrefix(q.Get("q"), "target.date < 2024-02-01T10:00:00Z"):
+			if q.Get("sort") != "-target.date" {
+				t.Errorf("expected the tags to be sorted by date, got %q", q.Get("sort"))
+			}
+			if q.Get("pagelen") != "1" || q.Get("page") != "1" {
+				t.Errorf("expected a single tag to be requested, got %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"values": [{"name": "v1.0.0", "target": {"hash": "c0", "date": "2024-01-01T10:00:00+00:00"}}]}`)
+		case r.URL.Path == "/2.0/repositories/acme/api/commits":
+			if q.Get("include") != "c5" || q.Get("exclude") != "c0" {
+				t.Errorf("unexpected range %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"values": [
+				{"hash": "c5aaaaaaaa", "message": "Merged in feature/tokens (pull request #12)", "parents": [{"hash": "c4"}, {"hash": "c3"}]},
+				{"hash": "c4aaaaaaaa", "message": "fix(db): Close idle connections\n", "author": {"raw": "Bob <bob@example.com>"}},
+				{"hash": "c3aaaaaaaa", "message": "Add token endpoint"},
+				{"hash": "c2aaaaaaaa", "message": "Add token model"},
+				{"hash": "c1aaaaaaaa", "message": "Drop v1 routes\n\nBREAKING CHANGE: the v1 API is gone", "author": {"raw": "Jane <jane@example.com>"}}
+			]}`)
+		case strings.HasPrefix(r.URL.Path, "/2.0/repositories/acme/api/commit/"):
+			switch strings.Split(r.URL.Path, "/")[6] {
+			case "c5aaaaaaaa", "c3aaaaaaaa", "c2aaaaaaaa":
+				fmt.Fprint(w, `{"values": [
+					{"id": 9, "state": "DECLINED", "title": "feat: Tokens, take one"},
+					{"id": 12, "state": "MERGED", "title": "feat(auth)!: Add API tokens", "author": {"display_name": "Jane Doe"},
+					 "links": {"html": {"href": "https://bitbucket.org/acme/api/pull-requests/12"}}}
+				]}`)
+			default:
+				fmt.Fprint(w, `{"values": []}`)
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestReleaseNotes(t *testing.T) {
+	c := newLocalTestClient(t, releaseNotesServer(t))
+
+	notes, err := c.Repositories.Repository.ReleaseNotes((&bitbucket.ReleaseNotesOptions{
+		Owner: "acme", RepoSlug: "api", To: "v1.1.0",
+	}).WithContext(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if notes.From != "v1.0.0" || notes.FromCommit != "c0" || notes.ToCommit != "c5" {
+		t.Errorf("expected the previous tag to be found, got %+v", notes)
+	}
+	markdown, err := notes.Markdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `## v1.1.0 (2024-02-01)
+
+### Features
+
+- **BREAKING:** **auth:** Add API tokens ([#12](https://bitbucket.org/acme/api/pull-requests/12))
+
+### Bug Fixes
+
+- **db:** Close idle connections (c4aaaaa)
+
+### Other Changes
+
+- **BREAKING:** Drop v1 routes (c1aaaaa)
+`
+	if markdown != want {
+		t.Errorf("unexpected release notes:\n%s\nwant:\n%s", markdown, want)
+	}
+
+	pr := notes.Sections[0].Notes[0]
+	if strings.Join(pr.Commits, ",") != "c5aaaaaaaa,c3aaaaaaaa,c2aaaaaaaa" || pr.Author != "Jane Doe" {
+		t.Errorf("expected the commits to be grouped under the pull request, got %+v", pr)
+	}
+	if notes.Sections[1].Notes[0].Author != "Bob" {
+		t.Errorf("unexpected commit author %q", notes.Sections[1].Notes[0].Author)
+	}
+
+	data, err := json.Marshal(notes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"pull_request":{"id":12`) {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
+
+func TestReleaseNotesClassify(t *testing.T) {
+	c := newLocalTestClient(t, releaseNotesServer(t))
+
+	notes, err := c.Repositories.Repository.ReleaseNotes(&bitbucket.ReleaseNotesOptions{
+		Owner: "acme", RepoSlug: "api", From: "v1.0.0", To: "v1.1.0",
+		Types: []bitbucket.ReleaseNoteType{{Type: "security", Title: "Security"}},
+		Classify: func(note *bitbucket.ReleaseNote) string {
+			if note.Scope == "auth" {
+				return "security"
+			}
+			return note.Type
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(notes.Sections) != 2 || notes.Sections[0].Title != "Security" || notes.Sections[1].Title != "Other Changes" {
+		t.Fatalf("unexpected sections %+v", notes.Sections)
+	}
+	if notes.Sections[0].Notes[0].PullRequest.ID != 12 || len(notes.Sections[1].Notes) != 2 {
+		t.Errorf("unexpected grouping %+v", notes.Sections)
+	}
+}
+
+func TestReleaseNotesWithoutIndexedCommitLinks(t *testing.T) {
+	var lookups atomic.Int32
+	server := releaseNotesServer(t)
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/2.0/repositories/acme/api/commit/") {
+			lookups.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "Pull request commit links are not indexed"}}`)
+			return
+		}
+		server(w, r)
+	})
+
+	notes, err := c.Repositories.Repository.ReleaseNotes(&bitbucket.ReleaseNotesOptions{
+		Owner: "acme", RepoSlug: "api", From: "v1.0.0", To: "v1.1.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var summaries []string
+	for _, section := range notes.Sections {
+		for _, note := range section.Notes {
+			if note.PullRequest != nil {
+				t.Errorf("expected no pull request, got %+v", note.PullRequest)
+			}
+			summaries = append(summaries, note.Summary)
+		}
+	}
+	if want := "Close idle connections,Drop v1 routes,Add token endpoint,Add token model"; strings.Join(summaries, ",") != want {
+		t.Errorf("expected the commits to be listed on their own, got %q", summaries)
+	}
+	if n := lookups.Load(); n > 4 {
+		t.Errorf("expected the lookups to stop after a 404, got %d", n)
+	}
+}
+
+func TestReleaseNotesCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := releaseNotesServer(t)
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/2.0/repositories/acme/api/commit/") {
+			cancel()
+		}
+		server(w, r)
+	})
+
+	_, err := c.Repositories.Repository.ReleaseNotes((&bitbucket.ReleaseNotesOptions{
+		Owner: "acme", RepoSlug: "api", From: "v1.0.0", To: "v1.1.0",
+	}).WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the notes to stop on cancellation, got %v", err)
+	}
+}
+
+func TestReleaseNotesCanceledWhileFindingTags(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := releaseNotesServer(t)
+	var requests int32
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		cancel()
+		server(w, r)
+	})
+
+	_, err := c.Repositories.Repository.ReleaseNotes((&bitbucket.ReleaseNotesOptions{
+		Owner: "acme", RepoSlug: "api", To: "v1.1.0",
+	}).WithContext(ctx))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the tag lookup to stop on cancellation, got %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected no request after the cancellation, got %d requests", n)
+	}
+}
@@ -29,7 +29,7 @@ func TestAddGetandDeletePipelineVariableAccess(t *testing.T) {
 		t.Errorf("BITBUCKET_TEST_REPOSLUG is unset")
 	}
 
-	c, err := bitbucket.NewOAuthbearerToken(accessToken)
+	c, err := newBearerToken(t, accessToken)
 	if err != nil {
 		t.Error(err)
 	}
@@ -46,6 +46,7 @@ func TestAddGetandDeletePipelineVariableAccess(t *testing.T) {
 }
 
 func TestAddGetandDeletePipelineVariableAccessTokenCaCert(t *testing.T) {
+	skipWhenRecording(t)
 
 	accessToken := os.Getenv("BITBUCKET_TEST_ACCESS_TOKEN")
 	workspace := os.Getenv("BITBUCKET_TEST_OWNER")
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func TestCodeSearchQueryString(t *testing.T) {
+	query := bitbucket.CodeSearchQuery{
+		Text:       `"oldlib.Client"`,
+		Repository: "api",
+		Project:    "CORE",
+		Language:   "go",
+		Path:       "internal dir",
+	}
+	want := `"oldlib.Client" repo:api project:CORE lang:go path:"internal dir"`
+	if got := query.String(); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestSearchCodeIteratesPages(t *testing.T) {
+	var requests int32
+	var serverURL string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/2.0/workspaces/acme/search/code" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query().Get("search_query"); q != "oldlib lang:go" {
+			t.Errorf("unexpected query %q", q)
+		}
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"page": 1, "next": "%s/2.0/workspaces/acme/search/code?search_query=oldlib+lang%%3Ago&page=2", "values": [{
+				"type": "code_search_result",
+				"content_match_count": 1,
+				"content_matches": [{"lines": [{"line": 3, "segments": [{"text": "import \""}, {"text": "oldlib", "match": true}, {"text": "\""}]}]}],
+				"path_matches": [{"text": "main.go"}],
+				"file": {"path": "main.go", "commit": {"hash": "abc", "repository": {"full_name": "acme/api"}}}
+			}]}`, serverURL)
+		case "2":
+			fmt.Fprint(w, `{"page": 2, "values": [{
+				"type": "code_search_result",
+				"content_match_count": 2,
+				"path_matches": [{"text": "lib/"}, {"text": "oldlib", "match": true}, {"text": ".go"}],
+				"file": {"path": "lib/oldlib.go", "links": {"self": {"href": "https://api.bitbucket.org/2.0/repositories/acme/worker/src/def/lib/oldlib.go"}}}
+			}]}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+	serverURL = c.GetApiHostnameURL()
+
+	var results []bitbucket.CodeSearchResult
+	for result, err := range c.Workspaces.SearchCode(context.Background(), "acme", "oldlib lang:go") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	first := results[0]
+	if first.Repository != "acme/api" || first.Path != "main.go" || first.CommitHash != "abc" {
+		t.Errorf("unexpected first result %+v", first)
+	}
+	if line := first.ContentMatches[0].Lines[0]; line.Line != 3 || line.Text() != `import "oldlib"` || !line.Segments[1].Match {
+		t.Errorf("unexpected matched line %+v", line)
+	}
+	if second := results[1]; second.Repository != "acme/worker" || second.CommitHash != "def" || !second.PathMatches[1].Match {
+		t.Errorf("expected the repository to be read from the file link, got %+v", second)
+	}
+
+	atomic.StoreInt32(&requests, 0)
+	for range c.Workspaces.SearchCode(context.Background(), "acme", "oldlib lang:go") {
+		break
+	}
+	if requests != 1 {
+		t.Errorf("expected breaking out of the loop to stop paging, got %d requests", requests)
+	}
+}
+
+func TestSearchCodeYieldsErrors(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"type": "error", "error": {"message": "Code search is not enabled"}}`)
+	})
+
+	count := 0
+	for _, err := range c.Workspaces.SearchCode(context.Background(), "acme", "oldlib") {
+		count++
+		if err == nil {
+			t.Error("expected an error")
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected a single error, got %d values", count)
+	}
+}
@@ -0,0 +1,208 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+const snippetJSON = `{
+	"type": "snippet",
+	"id": 42,
+	"title": "runbook",
+	"scm": "git",
+	"is_private": true,
+	"created_on": "2024-03-01T10:00:00.123456+00:00",
+	"updated_on": "2024-03-02T10:00:00.123456+00:00",
+	"owner": {"type": "user", "display_name": "Alice", "account_id": "123"},
+	"files": {
+		"deploy.md": {"links": {"self": {"href": "https://api.bitbucket.org/2.0/snippets/acme/42/files/deploy.md"}}},
+		"config.yaml": {"links": {"self": {"href": "https://api.bitbucket.org/2.0/snippets/acme/42/files/config.yaml"}}}
+	}
+}`
+
+func TestSnippetsCreateSendsFilesAsMultipart(t *testing.T) {
+	var files = map[string]string{}
+	var title, method, path string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		reader, err := r.MultipartReader()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			data, _ := io.ReadAll(part)
+			switch part.FormName() {
+			case "file":
+				files[part.FileName()] = string(data)
+			case "title":
+				title = string(data)
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, snippetJSON)
+	})
+
+	snippet, err := c.Snippets.Create(&bitbucket.SnippetOptions{
+		Workspace: "acme",
+		Title:     "runbook",
+		IsPrivate: "true",
+		Files: []bitbucket.File{
+			{Name: "deploy.md", Content: []byte("# Deploy")},
+			{Name: "config.yaml", Content: []byte("replicas: 2")},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if method != http.MethodPost || path != "/2.0/snippets/acme" {
+		t.Errorf("unexpected request %s %s", method, path)
+	}
+	if title != "runbook" || files["deploy.md"] != "# Deploy" || files["config.yaml"] != "replicas: 2" {
+		t.Errorf("unexpected form: title %q, files %v", title, files)
+	}
+	if snippet.Id != 42 || !snippet.IsPrivate || snippet.Owner.DisplayName != "Alice" {
+		t.Errorf("unexpected snippet %+v", snippet)
+	}
+	if file := snippet.Files["config.yaml"]; file.Name != "config.yaml" {
+		t.Errorf("expected the file name to be set, got %+v", file)
+	}
+	if snippet.CreatedOn.Year() != 2024 {
+		t.Errorf("unexpected creation time %v", snippet.CreatedOn)
+	}
+}
+
+func TestSnippetsUpdateDeletesFiles(t *testing.T) {
+	var deleted []string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/2.0/snippets/acme/42" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+			return
+		}
+		deleted = r.MultipartForm.Value["file"]
+		fmt.Fprint(w, snippetJSON)
+	})
+
+	if _, err := c.Snippets.Update(&bitbucket.SnippetOptions{
+		Workspace: "acme", Id: "42", FilesToDelete: []string{"old.txt"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 1 || deleted[0] != "old.txt" {
+		t.Errorf("unexpected deleted files %v", deleted)
+	}
+}
+
+func TestSnippetsRevisionsDiffAndFiles(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/snippets/acme/42/commits":
+			fmt.Fprint(w, `{"values": [
+				{"type": "snippet_commit", "hash": "bbb", "date": "2024-03-02T10:00:00+00:00", "message": "Update",
+				 "author": {"raw": "Alice <alice@example.com>"}, "parents": [{"hash": "aaa"}]},
+				{"type": "snippet_commit", "hash": "aaa", "date": "2024-03-01T10:00:00+00:00", "message": "Create"}
+			]}`)
+		case "/2.0/snippets/acme/42/aaa..bbb/diff":
+			if r.URL.Query().Get("path") != "deploy.md" {
+				t.Errorf("unexpected diff path %q", r.URL.Query().Get("path"))
+			}
+			fmt.Fprint(w, "diff --git a/deploy.md b/deploy.md\n")
+		case "/2.0/snippets/acme/42/bbb/files/deploy.md":
+			fmt.Fprint(w, "# Deploy")
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	revisions, err := c.Snippets.ListRevisions(&bitbucket.SnippetOptions{Workspace: "acme", Id: "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 2 || revisions[0].Parents[0].Hash != "aaa" || revisions[0].Author.Raw != "Alice <alice@example.com>" {
+		t.Errorf("unexpected revisions %+v", revisions)
+	}
+	if want := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC); !revisions[0].Date.Equal(want) {
+		t.Errorf("unexpected revision date %v", revisions[0].Date)
+	}
+
+	diff, err := c.Snippets.Diff(&bitbucket.SnippetOptions{Workspace: "acme", Id: "42", Revision: "aaa..bbb", Path: "deploy.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "diff --git a/deploy.md b/deploy.md\n" {
+		t.Errorf("unexpected diff %q", diff)
+	}
+
+	content, err := c.Snippets.GetFile(&bitbucket.SnippetOptions{Workspace: "acme", Id: "42", Revision: "bbb", Path: "deploy.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "# Deploy" {
+		t.Errorf("unexpected content %q", content)
+	}
+}
+
+func TestSnippetsCommentsAndWatch(t *testing.T) {
+	watching := false
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /2.0/snippets/acme/42/comments":
+			var body struct {
+				Content struct{ Raw string }
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"type": "snippet_comment", "id": 7, "content": {"raw": %q}, "created_on": "2024-03-01T10:00:00.5+00:00"}`, body.Content.Raw)
+		case "GET /2.0/snippets/acme/42/watch":
+			if !watching {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"type": "error", "error": {"message": "not watching"}}`)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "PUT /2.0/snippets/acme/42/watch":
+			watching = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	comment, err := c.Snippets.AddComment(&bitbucket.SnippetCommentOptions{Workspace: "acme", SnippetId: "42", Content: "Ship it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.Id != 7 || comment.Content.Raw != "Ship it" {
+		t.Errorf("unexpected comment %+v", comment)
+	}
+
+	opt := &bitbucket.SnippetOptions{Workspace: "acme", Id: "42"}
+	if ok, err := c.Snippets.IsWatching(opt); err != nil || ok {
+		t.Fatalf("expected not to watch the snippet, got %v, %v", ok, err)
+	}
+	if err := c.Snippets.Watch(opt); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := c.Snippets.IsWatching(opt); err != nil || !ok {
+		t.Fatalf("expected to watch the snippet, got %v, %v", ok, err)
+	}
+}
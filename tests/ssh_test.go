@@ -22,7 +22,7 @@ func TestUserSSHKey(t *testing.T) {
 		t.Error("BITBUCKET_TEST_OWNER is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+const annotatedTag = `{"type": "tag", "name": "v1.0", "message": "First release\n", "date": "2024-05-06T07:08:09+00:00",
+	"tagger": {"raw": "Jane <jane@example.com>", "user": {"display_name": "Jane Doe"}},
+	"target": {"type": "commit", "hash": "abc1230000000000000000000000000000000000"}}`
+
+func TestAnnotatedTags(t *testing.T) {
+	var created map[string]interface{}
+	deleted := false
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.EscapedPath() {
+		case "POST /2.0/repositories/acme/api/refs/tags":
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &created)
+			fmt.Fprint(w, annotatedTag)
+		case "GET /2.0/repositories/acme/api/refs/tags/v1.0":
+			fmt.Fprint(w, annotatedTag)
+		case "GET /2.0/repositories/acme/api/refs/tags/release%2F2":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "release/2 not found"}}`)
+		case "DELETE /2.0/repositories/acme/api/refs/tags/v1.0":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	repo := c.Repositories.Repository
+
+	tag, err := repo.CreateTag(&bitbucket.RepositoryTagCreationOptions{
+		Owner: "acme", RepoSlug: "api", Name: "v1.0", Message: "First release\n",
+		Target: bitbucket.RepositoryTagTarget{Hash: "abc123"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created["message"] != "First release\n" {
+		t.Errorf("expected the message to be sent, got %v", created)
+	}
+	if tag.Message != "First release\n" || tag.Date.IsZero() || tag.Tagger.User == nil || tag.Tagger.User.DisplayName != "Jane Doe" {
+		t.Errorf("unexpected tag %+v", tag)
+	}
+
+	tag, err = repo.GetTag(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api", Name: "v1.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != "v1.0" || tag.Target == nil || tag.Target.Hash != "abc1230000000000000000000000000000000000" {
+		t.Errorf("unexpected tag %+v", tag)
+	}
+	if _, err := repo.GetTag(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api", Name: "release/2"}); err == nil {
+		t.Error("expected a missing tag to be an error")
+	}
+	if _, err := repo.GetTag(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api"}); err == nil {
+		t.Error("expected an empty name to be rejected")
+	}
+
+	if err := repo.DeleteTag(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api", Name: "v1.0"}); err != nil {
+		t.Fatal(err)
+	}
+	if !deleted {
+		t.Error("expected the tag to be deleted")
+	}
+}
+
+func TestFindTagsForCommit(t *testing.T) {
+	var queries []string
+	var serverURL string
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2.0/repositories/acme/api/refs/tags" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		queries = append(queries, r.URL.Query().Get("q"))
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values": [{"type": "tag", "name": "v1.0-rc1", "target": {"hash": "abc1230000000000000000000000000000000000"}}]}`)
+			return
+		}
+		// The "~" operator matches anywhere in the hash.
+		fmt.Fprintf(w, `{"values": [
+			{"type": "tag", "name": "v1.0", "target": {"hash": "abc1230000000000000000000000000000000000"}},
+			{"type": "tag", "name": "v0.9", "target": {"hash": "ffabc12300000000000000000000000000000000"}}],
+			"next": "%s/2.0/repositories/acme/api/refs/tags?page=2"}`, serverURL)
+	})
+	serverURL = strings.TrimSuffix(c.GetApiBaseURL(), "/2.0")
+	repo := c.Repositories.Repository
+
+	tags, err := repo.FindTagsForCommit(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api", Query: `name ~ "v"`, PageNum: 2}, "abc1230")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[0].Name != "v1.0" || tags[1].Name != "v1.0-rc1" {
+		t.Errorf("expected the tags starting with the hash on every page, got %+v", tags)
+	}
+	if want := `(name ~ "v") AND target.hash ~ "abc1230"`; queries[0] != want {
+		t.Errorf("expected query %q, got %q", want, queries[0])
+	}
+
+	queries = nil
+	if _, err := repo.FindTagsForCommit(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api"}, "abc1230000000000000000000000000000000000"); err != nil {
+		t.Fatal(err)
+	}
+	if want := `target.hash = "abc1230000000000000000000000000000000000"`; queries[0] != want {
+		t.Errorf("expected query %q, got %q", want, queries[0])
+	}
+
+	if _, err := repo.FindTagsForCommit(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api"}, "abc12"); err == nil {
+		t.Error("expected a hash shorter than 7 characters to be rejected")
+	}
+}
+
+func TestListTagsSkipsUndecodableEntries(t *testing.T) {
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"values": [
+			{"type": "tag", "name": "v1.0", "date": "2024-05-06T07:08:09+00:00"},
+			{"type": "tag", "name": "v0.9", "date": "yesterday"}]}`)
+	})
+
+	tags, err := c.Repositories.Repository.ListTags(&bitbucket.RepositoryTagOptions{Owner: "acme", RepoSlug: "api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags.Tags) != 1 || tags.Tags[0].Name != "v1.0" || tags.Tags[0].Date.IsZero() {
+		t.Errorf("expected only the decodable tag, got %+v", tags.Tags)
+	}
+}
@@ -2,9 +2,11 @@ package tests
 
 import (
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/ktrysmt/go-bitbucket"
+	"github.com/ktrysmt/go-bitbucket/recorder"
 )
 
 var (
@@ -14,6 +16,17 @@ var (
 	repo  = os.Getenv("BITBUCKET_TEST_REPOSLUG")
 )
 
+// recordedTestsKey is the cassette metadata listing the e2e tests it covers.
+// Other e2e tests fail when replaying.
+const recordedTestsKey = "recorded_tests"
+
+var (
+	activeRecorder *recorder.Recorder
+
+	recordedTestsMu sync.Mutex
+	recordedTests   = make(map[string]bool)
+)
+
 func setup(t *testing.T) *bitbucket.Client {
 
 	if user == "" {
@@ -29,9 +42,51 @@ func setup(t *testing.T) *bitbucket.Client {
 		t.Error("BITBUCKET_TEST_REPOSLUG is empty.")
 	}
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
 	return c
 }
+
+// newBasicAuth returns an e2e client using basic auth, see newE2EClient.
+func newBasicAuth(t *testing.T, u, p string) (*bitbucket.Client, error) {
+	t.Helper()
+	return newE2EClient(t, bitbucket.BasicCredentials(u, p))
+}
+
+// newBearerToken returns an e2e client using an access token, see
+// newE2EClient.
+func newBearerToken(t *testing.T, token string) (*bitbucket.Client, error) {
+	t.Helper()
+	return newE2EClient(t, bitbucket.BearerTokenCredentials(token))
+}
+
+// newE2EClient returns a client for the tests against the live API, sending
+// requests through the recorder, if any. When replaying, tests missing from
+// the cassette fail. When recording, passing tests are added to it.
+func newE2EClient(t *testing.T, a *bitbucket.Auth) (*bitbucket.Client, error) {
+	t.Helper()
+	if activeRecorder == nil {
+		return bitbucket.New(a)
+	}
+
+	recordedTestsMu.Lock()
+	recorded := recordedTests[t.Name()]
+	recordedTestsMu.Unlock()
+	switch activeRecorder.Mode() {
+	case recorder.ModeReplay:
+		if !recorded {
+			t.Fatalf("%s is not in the cassette, record it with make test/record", t.Name())
+		}
+	case recorder.ModeRecord:
+		t.Cleanup(func() {
+			if !t.Failed() && !t.Skipped() {
+				recordedTestsMu.Lock()
+				recordedTests[t.Name()] = true
+				recordedTestsMu.Unlock()
+			}
+		})
+	}
+	return bitbucket.New(a, bitbucket.WithTransport(activeRecorder))
+}
@@ -5,9 +5,35 @@ import (
 	"encoding/pem"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
 	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
 )
 
+// newLocalTestClient starts an httptest server with the given handler and returns a
+// client whose API base URL points at it, so tests can run without network access.
+func newLocalTestClient(t *testing.T, handler http.HandlerFunc) *bitbucket.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := bitbucket.NewBasicAuth("example", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(server.URL + "/2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetApiBaseURL(*u)
+	return c
+}
+
 // fetchCACerts connects to the given host:port and returns the CA certs in PEM format
 func FetchCACerts(host string, port string) ([]byte, error) {
 	// Prepare TLS configuration (skip verification so we can inspect all certs)
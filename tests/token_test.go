@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+	"golang.org/x/oauth2"
+)
+
+// countingTokenSource hands out a new short-lived token on every call.
+type countingTokenSource struct {
+	mu     sync.Mutex
+	calls  int
+	expiry time.Duration
+	err    error
+}
+
+func (s *countingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.calls++
+	return &oauth2.Token{
+		AccessToken:  fmt.Sprintf("access-%d", s.calls),
+		RefreshToken: fmt.Sprintf("refresh-%d", s.calls),
+		TokenType:    "Bearer",
+		Expiry:       time.Now().Add(s.expiry),
+	}, nil
+}
+
+func newAuthorizationRecorder(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	var mu sync.Mutex
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Authorization"))
+		mu.Unlock()
+		fmt.Fprint(w, `{"type": "repository", "slug": "repo"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &seen
+}
+
+func TestTokenSourceRefreshesExpiredTokens(t *testing.T) {
+	server, seen := newAuthorizationRecorder(t)
+	// Tokens expiring within oauth2's expiry delta are refreshed on every request.
+	ts := &countingTokenSource{expiry: time.Second}
+
+	var persisted []string
+	c, err := bitbucket.NewOAuthWithTokenSource(ts,
+		bitbucket.WithBaseURL(server.URL+"/2.0"),
+		bitbucket.WithTokenRefreshHandler(func(tok *oauth2.Token) error {
+			persisted = append(persisted, tok.RefreshToken)
+			return nil
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Repositories.Repository.Get(opt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(*seen) != 2 || (*seen)[0] != "Bearer access-1" || (*seen)[1] != "Bearer access-2" {
+		t.Errorf("expected each request to use a refreshed token, got %v", *seen)
+	}
+	if len(persisted) != 2 || persisted[1] != "refresh-2" {
+		t.Errorf("expected every rotated refresh token to be persisted, got %v", persisted)
+	}
+	if tok := c.GetOAuthToken(); tok.AccessToken != "access-2" {
+		t.Errorf("expected GetOAuthToken to return the latest token, got %q", tok.AccessToken)
+	}
+}
+
+func TestTokenSourceReusesValidTokens(t *testing.T) {
+	server, seen := newAuthorizationRecorder(t)
+	ts := &countingTokenSource{expiry: time.Hour}
+
+	c, err := bitbucket.NewOAuthWithTokenSource(ts, bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ts.calls != 1 {
+		t.Errorf("expected the token to be fetched once, got %d calls", ts.calls)
+	}
+	for _, header := range *seen {
+		if header != "Bearer access-1" {
+			t.Errorf("unexpected Authorization header %q", header)
+		}
+	}
+}
+
+func TestTokenSourceErrorsFailRequests(t *testing.T) {
+	server, seen := newAuthorizationRecorder(t)
+	opt := &bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}
+
+	failing, err := bitbucket.NewOAuthWithTokenSource(&countingTokenSource{err: errors.New("refresh token revoked")},
+		bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := failing.Repositories.Repository.Get(opt); err == nil {
+		t.Error("expected a failing token source to fail the request")
+	}
+
+	expired, err := bitbucket.New(bitbucket.OAuthTokenCredentials(oauth2.Token{AccessToken: "old", Expiry: time.Now().Add(-time.Hour)}),
+		bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := expired.Repositories.Repository.Get(opt); err == nil {
+		t.Error("expected an expired token to fail the request")
+	}
+
+	persistFails, err := bitbucket.NewOAuthWithTokenSource(&countingTokenSource{expiry: time.Hour},
+		bitbucket.WithBaseURL(server.URL+"/2.0"),
+		bitbucket.WithTokenRefreshHandler(func(*oauth2.Token) error { return errors.New("disk full") }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := persistFails.Repositories.Repository.Get(opt); err == nil {
+		t.Error("expected a failing refresh handler to fail the request")
+	}
+
+	if len(*seen) != 0 {
+		t.Errorf("expected no unauthenticated requests to be sent, got %d", len(*seen))
+	}
+}
+
+func TestTokenRefreshHandlerStaysWithItsClient(t *testing.T) {
+	server, _ := newAuthorizationRecorder(t)
+	auth := bitbucket.OAuthTokenSourceCredentials(&countingTokenSource{expiry: time.Second})
+
+	var persisted int
+	if _, err := bitbucket.New(auth,
+		bitbucket.WithBaseURL(server.URL+"/2.0"),
+		bitbucket.WithTokenRefreshHandler(func(tok *oauth2.Token) error {
+			persisted++
+			return nil
+		})); err != nil {
+		t.Fatal(err)
+	}
+	other, err := bitbucket.New(auth, bitbucket.WithBaseURL(server.URL+"/2.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := other.Repositories.Repository.Get(&bitbucket.RepositoryOptions{Owner: "owner", RepoSlug: "repo"}); err != nil {
+		t.Fatal(err)
+	}
+	if persisted != 0 {
+		t.Errorf("expected the handler of one client not to be called for another, got %d calls", persisted)
+	}
+}
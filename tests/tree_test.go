@@ -0,0 +1,177 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ktrysmt/go-bitbucket"
+)
+
+func treeServer(t *testing.T, requested *[]string) func(w http.ResponseWriter, r *http.Request) {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*requested = append(*requested, r.URL.Path)
+		mu.Unlock()
+
+		switch path := strings.TrimPrefix(r.URL.Path, "/2.0/repositories/acme/api/src/"); {
+		case path == "main/" && r.URL.Query().Get("format") == "meta":
+			fmt.Fprint(w, `{"type": "commit_directory", "path": "", "commit": {"hash": "abc123"}}`)
+		case path == "abc123/" && r.URL.Query().Get("page") == "":
+			fmt.Fprintf(w, `{"next": "%s?page=2", "values": [
+				{"type": "commit_file", "path": "main.go", "size": 12},
+				{"type": "commit_directory", "path": "vendor"},
+				{"type": "commit_directory", "path": "src"}
+			]}`, "http://"+r.Host+r.URL.Path)
+		case path == "abc123/":
+			fmt.Fprint(w, `{"values": [
+				{"type": "commit_file", "path": "LICENSE", "size": 3},
+				{"type": "commit_file", "path": "README.md", "size": 5},
+				{"type": "commit_directory", "path": "docs"}
+			]}`)
+		case path == "abc123/src/":
+			fmt.Fprint(w, `{"values": [
+				{"type": "commit_file", "path": "src/app.go", "attributes": ["executable"]},
+				{"type": "commit_file", "path": "src/notes.txt"}
+			]}`)
+		case path == "abc123/vendor/":
+			fmt.Fprint(w, `{"values": []}`)
+		case path == "abc123/docs/":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"type": "error", "error": {"message": "Forbidden"}}`)
+		case strings.HasPrefix(path, "abc123/") && !strings.HasSuffix(path, "/"):
+			fmt.Fprintf(w, "content of %s", strings.TrimPrefix(path, "abc123/"))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestWalkTreeVisitsMatchingFilesInOrder(t *testing.T) {
+	var requested []string
+	c := newLocalTestClient(t, treeServer(t, &requested))
+
+	var visited []string
+	contents := map[string]string{}
+	err := c.Repositories.Repository.WalkTree(context.Background(), &bitbucket.WalkTreeOptions{
+		Owner: "acme", RepoSlug: "api", Ref: "main",
+		Include:       []string{"*.go", "LICENSE"},
+		Exclude:       []string{"vendor", "docs"},
+		FetchContents: true,
+		Concurrency:   2,
+	}, func(path string, entry *bitbucket.TreeEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		visited = append(visited, path)
+		if !entry.IsDir() {
+			contents[path] = string(entry.Content)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"", "LICENSE", "main.go", "src", "src/app.go"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("expected %v, got %v", want, visited)
+	}
+	if contents["src/app.go"] != "content of src/app.go" || contents["LICENSE"] != "content of LICENSE" {
+		t.Errorf("unexpected contents %v", contents)
+	}
+	for _, path := range requested {
+		if strings.Contains(path, "vendor") || strings.Contains(path, "README") {
+			t.Errorf("expected %s not to be requested", path)
+		}
+	}
+}
+
+func TestWalkTreeReportsErrorsAndSkips(t *testing.T) {
+	var requested []string
+	c := newLocalTestClient(t, treeServer(t, &requested))
+
+	var visited []string
+	var listErr error
+	err := c.Repositories.Repository.WalkTree(context.Background(), &bitbucket.WalkTreeOptions{
+		Owner: "acme", RepoSlug: "api", Ref: "main",
+	}, func(path string, entry *bitbucket.TreeEntry, err error) error {
+		if err != nil {
+			listErr = err
+			return nil
+		}
+		visited = append(visited, path)
+		if path == "src" {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"", "LICENSE", "README.md", "docs", "main.go", "src", "vendor"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("expected %v, got %v", want, visited)
+	}
+	if listErr == nil || !strings.Contains(listErr.Error(), "403") {
+		t.Errorf("expected the docs listing error to be reported, got %v", listErr)
+	}
+}
+
+func TestWalkTreeBoundsFetchesAcrossDirectories(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	c := newLocalTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch path := strings.TrimPrefix(r.URL.Path, "/2.0/repositories/acme/api/src/"); {
+		case path == "main/":
+			fmt.Fprint(w, `{"type": "commit_directory", "path": "", "commit": {"hash": "abc123"}}`)
+		case path == "abc123/":
+			fmt.Fprint(w, `{"values": [
+				{"type": "commit_directory", "path": "a"},
+				{"type": "commit_file", "path": "b1"},
+				{"type": "commit_file", "path": "b2"}
+			]}`)
+		case path == "abc123/a/":
+			fmt.Fprint(w, `{"values": [{"type": "commit_file", "path": "a/1"}, {"type": "commit_file", "path": "a/2"}]}`)
+		default:
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			fmt.Fprint(w, "content")
+		}
+	})
+
+	var visited []string
+	err := c.Repositories.Repository.WalkTree(context.Background(), &bitbucket.WalkTreeOptions{
+		Owner: "acme", RepoSlug: "api", Ref: "main", FetchContents: true, Concurrency: 1,
+	}, func(path string, entry *bitbucket.TreeEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			visited = append(visited, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(visited) != 4 {
+		t.Errorf("expected 4 files, got %v", visited)
+	}
+	if maxInFlight != 1 {
+		t.Errorf("expected at most 1 content request in flight, got %d", maxInFlight)
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"os"
 	"testing"
-
-	"github.com/ktrysmt/go-bitbucket"
 )
 
 func TestProfile(t *testing.T) {
@@ -13,7 +11,7 @@ func TestProfile(t *testing.T) {
 	user := getUsername()
 	pass := getPassword()
 
-	c, err := bitbucket.NewBasicAuth(user, pass)
+	c, err := newBasicAuth(t, user, pass)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,75 @@
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenRefreshFunc is called with every new token obtained from an
+// oauth2.TokenSource, e.g. to persist a rotated refresh token. If it returns an
+// error, the request that triggered the refresh fails with that error.
+type TokenRefreshFunc func(token *oauth2.Token) error
+
+// OAuthTokenSourceCredentials returns an Auth taking its tokens from ts.
+// Tokens are cached until they expire, so ts is only called to refresh them,
+// and calls to it are serialized.
+func OAuthTokenSourceCredentials(ts oauth2.TokenSource) *Auth {
+	return &Auth{tokenSource: oauth2.ReuseTokenSource(nil, ts)}
+}
+
+// NewOAuthWithTokenSource returns a Client authenticating with tokens from ts,
+// e.g. one returned by oauth2.Config.TokenSource.
+func NewOAuthWithTokenSource(ts oauth2.TokenSource, opts ...Option) (*Client, error) {
+	return New(OAuthTokenSourceCredentials(ts), opts...)
+}
+
+// WithTokenRefreshHandler calls fn with every token the client obtains from
+// its token source. It has no effect for basic or bearer token authentication.
+func WithTokenRefreshHandler(fn TokenRefreshFunc) Option {
+	return func(cfg *clientConfig) error {
+		cfg.onTokenRefresh = fn
+		return nil
+	}
+}
+
+// OnTokenRefresh calls fn with every token the client obtains from its token
+// source, replacing any handler set before.
+func (c *Client) OnTokenRefresh(fn TokenRefreshFunc) {
+	c.Auth.mu.Lock()
+	defer c.Auth.mu.Unlock()
+	c.Auth.onTokenRefresh = fn
+}
+
+// oauthToken returns a valid OAuth token, refreshing it through the token
+// source if needed. It returns nil when a uses no OAuth token at all.
+func (a *Auth) oauthToken() (*oauth2.Token, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.tokenSource == nil {
+		if a.token.AccessToken == "" {
+			return nil, nil
+		}
+		if !a.token.Valid() {
+			return nil, errors.New("oauth token has expired and cannot be refreshed")
+		}
+		tok := a.token
+		return &tok, nil
+	}
+
+	tok, err := a.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain token: %w", err)
+	}
+	if tok.AccessToken != a.token.AccessToken {
+		if a.onTokenRefresh != nil {
+			if err := a.onTokenRefresh(tok); err != nil {
+				return nil, fmt.Errorf("failed to handle refreshed token: %w", err)
+			}
+		}
+		a.token = *tok
+	}
+	return tok, nil
+}
@@ -0,0 +1,328 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+const DEFAULT_WALK_TREE_CONCURRENCY = 4
+
+// TreeEntry is a file or directory visited by WalkTree.
+type TreeEntry struct {
+	RepositoryFile
+	// Content is the content of a file when WalkTreeOptions.FetchContents is
+	// set. The content of a symbolic link is its target.
+	Content []byte
+}
+
+// IsDir reports whether the entry is a directory.
+func (e *TreeEntry) IsDir() bool {
+	return e.Type == "commit_directory"
+}
+
+// IsSymlink reports whether the entry is a symbolic link.
+func (e *TreeEntry) IsSymlink() bool {
+	return e.hasAttribute("link")
+}
+
+// IsExecutable reports whether the entry is an executable file.
+func (e *TreeEntry) IsExecutable() bool {
+	return e.hasAttribute("executable")
+}
+
+func (e *TreeEntry) hasAttribute(attribute string) bool {
+	return hasAttribute(e.Attributes, attribute)
+}
+
+func hasAttribute(attributes []string, attribute string) bool {
+	for _, a := range attributes {
+		if a == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+// WalkTreeFunc is called by WalkTree for each visited entry. It follows the
+// contract of fs.WalkDirFunc: err is set, and entry may be nil, when the root
+// cannot be read, when a directory cannot be listed, in which case fn is
+// called a second time for the directory, or when the content of a file
+// cannot be fetched. Returning fs.SkipDir skips a directory, or the remaining
+// entries of the directory of a file, and fs.SkipAll stops the walk.
+type WalkTreeFunc func(path string, entry *TreeEntry, err error) error
+
+// WalkTree walks the source tree of a repository at a commit, branch or tag
+// without cloning it, calling fn for each file and directory under
+// WalkTreeOptions.Root in lexical order, starting with the root itself.
+//
+// The ref is resolved to a commit when the root is read, so a branch moving
+// during the walk does not mix revisions. Each directory is listed with one
+// paginated request, and file contents are fetched concurrently when
+// requested, while fn is always called from a single goroutine.
+func (r *Repository) WalkTree(ctx context.Context, wo *WalkTreeOptions, fn WalkTreeFunc) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := wo.Concurrency
+	if concurrency <= 0 {
+		concurrency = DEFAULT_WALK_TREE_CONCURRENCY
+	}
+	w := &treeWalker{c: r.c, ctx: ctx, opts: wo, fn: fn, sem: make(chan struct{}, concurrency)}
+	root := strings.Trim(wo.Root, "/")
+	entry, err := r.srcMeta(ctx, wo.Owner, wo.RepoSlug, wo.Ref, root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		w.ref = entry.commitHash()
+		if w.ref == "" {
+			w.ref = wo.Ref
+		}
+		err = w.walk(entry)
+	}
+	if err == fs.SkipDir || err == fs.SkipAll {
+		return nil
+	}
+	return err
+}
+
+type treeWalker struct {
+	c    *Client
+	ctx  context.Context
+	opts *WalkTreeOptions
+	fn   WalkTreeFunc
+	ref  string
+	// sem bounds the contents fetched at once across all the directories.
+	sem chan struct{}
+}
+
+func (w *treeWalker) walk(entry *TreeEntry) error {
+	if !entry.IsDir() {
+		if !w.included(entry.Path) {
+			return nil
+		}
+		var err error
+		if w.opts.FetchContents {
+			entry.Content, err = w.content(w.ctx, entry.Path)
+		}
+		return w.fn(entry.Path, entry, err)
+	}
+
+	if err := w.fn(entry.Path, entry, nil); err != nil {
+		return err
+	}
+	children, err := w.list(entry.Path)
+	if err != nil {
+		if err := w.fn(entry.Path, entry, err); err != nil {
+			return err
+		}
+		// An unreadable directory is skipped, as fs.WalkDir does.
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(w.ctx)
+	defer cancel()
+	contents := w.fetchContents(ctx, children)
+	for i, child := range children {
+		var err error
+		if contents[i] != nil {
+			result := <-contents[i]
+			child.Content, err = result.content, result.err
+		}
+		if child.IsDir() {
+			err = w.walk(child)
+		} else {
+			err = w.fn(child.Path, child, err)
+		}
+		if err == fs.SkipDir && child.IsDir() {
+			continue
+		}
+		if err != nil {
+			if err == fs.SkipDir {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+type treeContent struct {
+	content []byte
+	err     error
+}
+
+// fetchContents starts fetching the contents of the files of a directory,
+// with at most WalkTreeOptions.Concurrency requests in flight for the whole
+// walk. It returns a channel for each file being fetched, and nil for other
+// entries.
+func (w *treeWalker) fetchContents(ctx context.Context, entries []*TreeEntry) []chan treeContent {
+	results := make([]chan treeContent, len(entries))
+	if !w.opts.FetchContents {
+		return results
+	}
+
+	for i, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		results[i] = make(chan treeContent, 1)
+		go func(entry *TreeEntry, result chan<- treeContent) {
+			select {
+			case w.sem <- struct{}{}:
+			case <-ctx.Done():
+				result <- treeContent{err: ctx.Err()}
+				return
+			}
+			defer func() { <-w.sem }()
+			content, err := w.content(ctx, entry.Path)
+			result <- treeContent{content: content, err: err}
+		}(entry, results[i])
+	}
+	return results
+}
+
+// list returns the directories of a directory that are not excluded and its
+// files that are included, sorted by path.
+func (w *treeWalker) list(dir string) ([]*TreeEntry, error) {
+	urlStr := w.c.requestUrl("/repositories/%s/%s/src/%s/%s",
+		w.opts.Owner, w.opts.RepoSlug, url.PathEscape(w.ref), escapeTreePath(dir))
+	if dir != "" {
+		urlStr += "/"
+	}
+
+	var entries []*TreeEntry
+	for entry, err := range iteratePages(w.c, w.ctx, urlStr, decodeTreeEntry) {
+		if err != nil {
+			return nil, err
+		}
+		if entry.IsDir() && w.excluded(entry.Path) || !entry.IsDir() && !w.included(entry.Path) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, nil
+}
+
+func (w *treeWalker) content(ctx context.Context, p string) ([]byte, error) {
+	urlStr := w.c.requestUrl("/repositories/%s/%s/src/%s/%s",
+		w.opts.Owner, w.opts.RepoSlug, url.PathEscape(w.ref), escapeTreePath(p))
+	body, err := w.c.executeRawWithContext("GET", urlStr, "", ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// included reports whether a file matches the include patterns, if any, and
+// none of the exclude patterns.
+func (w *treeWalker) included(p string) bool {
+	if w.excluded(p) {
+		return false
+	}
+	if len(w.opts.Include) == 0 {
+		return true
+	}
+	for _, pattern := range w.opts.Include {
+		if matchTreeGlob(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *treeWalker) excluded(p string) bool {
+	for _, pattern := range w.opts.Exclude {
+		if matchTreeGlob(pattern, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTreeGlob reports whether a slash-separated path matches a glob pattern.
+// A pattern without a slash matches the base name, like in .gitignore files;
+// otherwise it matches the whole path, and a "**" element matches any number
+// of directories. Other elements use the syntax of path.Match.
+func matchTreeGlob(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	}
+	return matchTreeSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchTreeSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchTreeSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// srcMeta returns the metadata of the file or directory at p, including the
+// commit the ref resolves to.
+func (r *Repository) srcMeta(ctx context.Context, owner, repoSlug, ref, p string) (*TreeEntry, error) {
+	urlStr := r.c.requestUrl("/repositories/%s/%s/src/%s/%s?format=meta",
+		owner, repoSlug, url.PathEscape(ref), escapeTreePath(p))
+	response, err := r.c.executeWithContext("GET", urlStr, "", ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeTreeEntry(response)
+}
+
+func escapeTreePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func (e *TreeEntry) commitHash() string {
+	hash, _ := e.Commit["hash"].(string)
+	return hash
+}
+
+func decodeTreeEntry(response interface{}) (*TreeEntry, error) {
+	entryMap, ok := response.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("not a valid format")
+	}
+	if entryMap["type"] == "error" {
+		return nil, DecodeError(entryMap)
+	}
+
+	entry := new(TreeEntry)
+	if err := mapstructure.Decode(entryMap, &entry.RepositoryFile); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -22,7 +22,15 @@ type User struct {
 
 // Profile is getting the user data
 func (u *User) Profile() (*User, error) {
-	urlStr := u.c.GetApiBaseURL() + "/user"
+	return u.ProfileWithOptions(&UserOptions{})
+}
+
+// ProfileWithOptions is like Profile, returning only uo.Fields when set.
+func (u *User) ProfileWithOptions(uo *UserOptions) (*User, error) {
+	urlStr, err := u.c.withFieldsParam(u.c.GetApiBaseURL()+"/user", uo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	response, err := u.c.execute("GET", urlStr, "")
 	if err != nil {
 		return nil, err
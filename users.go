@@ -3,11 +3,18 @@ package bitbucket
 type Users struct {
 	c       *Client
 	SSHKeys *SSHKeys
-	users
 }
 
 func (u *Users) Get(t string) (*User, error) {
-	urlStr := u.c.GetApiBaseURL() + "/users/" + t + "/"
+	return u.GetWithOptions(&UserOptions{Username: t})
+}
+
+// GetWithOptions is like Get, returning only uo.Fields when set.
+func (u *Users) GetWithOptions(uo *UserOptions) (*User, error) {
+	urlStr, err := u.c.withFieldsParam(u.c.GetApiBaseURL()+"/users/"+uo.Username+"/", uo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	response, err := u.c.execute("GET", urlStr, "")
 	if err != nil {
 		return nil, err
@@ -40,7 +40,8 @@ func decodeWebhook(response interface{}) (*Webhook, error) {
 func decodeWebhooks(response interface{}) ([]Webhook, error) {
 	webhooks := make([]Webhook, 0)
 	resMap := response.(map[string]interface{})
-	for _, v := range resMap["values"].([]interface{}) {
+	values, _ := resMap["values"].([]interface{})
+	for _, v := range values {
 		wh, err := decodeWebhook(v)
 		if err != nil {
 			return nil, err
@@ -78,7 +79,11 @@ func (r *Webhooks) buildWebhooksBody(ro *WebhooksOptions) (string, error) {
 
 func (r *Webhooks) List(ro *WebhooksOptions) ([]Webhook, error) {
 	urlStr := r.c.requestUrl("/repositories/%s/%s/hooks/", ro.Owner, ro.RepoSlug)
-	res, err := r.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := r.c.withFieldsParam(urlStr, ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +93,11 @@ func (r *Webhooks) List(ro *WebhooksOptions) ([]Webhook, error) {
 // Deprecate Gets for List call
 func (r *Webhooks) Gets(ro *WebhooksOptions) (interface{}, error) {
 	urlStr := r.c.requestUrl("/repositories/%s/%s/hooks/", ro.Owner, ro.RepoSlug)
-	return r.c.executePaginated("GET", urlStr, "", nil)
+	urlStr, err := r.c.withFieldsParam(urlStr, ro.Fields, true)
+	if err != nil {
+		return nil, err
+	}
+	return r.c.executePaginatedWithContext("GET", urlStr, "", nil, ro.ctx)
 }
 
 func (r *Webhooks) Create(ro *WebhooksOptions) (*Webhook, error) {
@@ -107,7 +116,11 @@ func (r *Webhooks) Create(ro *WebhooksOptions) (*Webhook, error) {
 
 func (r *Webhooks) Get(ro *WebhooksOptions) (*Webhook, error) {
 	urlStr := r.c.requestUrl("/repositories/%s/%s/hooks/%s", ro.Owner, ro.RepoSlug, ro.Uuid)
-	response, err := r.c.execute("GET", urlStr, "")
+	urlStr, err := r.c.withFieldsParam(urlStr, ro.Fields, false)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.c.executeWithContext("GET", urlStr, "", ro.ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -17,7 +17,6 @@ type Workspace struct {
 	Slug       string
 	Is_Private bool
 	Name       string
-	workspace
 }
 
 type WorkspaceList struct {
@@ -70,8 +69,18 @@ func (t *Permission) GetUserPermissionsByUuid(organization, member string) (*Per
 	return decodePermission(response), err
 }
 
+// List returns the workspaces the authenticated user has access to.
 func (t *Workspace) List() (*WorkspaceList, error) {
-	urlStr := t.c.requestUrl("/workspaces")
+	return t.ListWithOptions(&WorkspaceOptions{})
+}
+
+// ListWithOptions is like List. When wo.Fields is set only those fields are
+// returned, e.g. []string{"values.slug", "values.name"}.
+func (t *Workspace) ListWithOptions(wo *WorkspaceOptions) (*WorkspaceList, error) {
+	urlStr, err := t.c.withFieldsParam(t.c.requestUrl("/workspaces"), wo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 	response, err := t.c.executePaginated("GET", urlStr, "", nil)
 	if err != nil {
 		return nil, err
@@ -81,7 +90,15 @@ func (t *Workspace) List() (*WorkspaceList, error) {
 }
 
 func (t *Workspace) Get(workspace string) (*Workspace, error) {
-	urlStr := t.c.requestUrl("/workspaces/%s", workspace)
+	return t.GetWithOptions(&WorkspaceOptions{Workspace: workspace})
+}
+
+// GetWithOptions is like Get, returning only wo.Fields when set.
+func (t *Workspace) GetWithOptions(wo *WorkspaceOptions) (*Workspace, error) {
+	urlStr, err := t.c.withFieldsParam(t.c.requestUrl("/workspaces/%s", wo.Workspace), wo.Fields, false)
+	if err != nil {
+		return nil, err
+	}
 	response, err := t.c.execute("GET", urlStr, "")
 	if err != nil {
 		return nil, err
@@ -91,7 +108,15 @@ func (t *Workspace) Get(workspace string) (*Workspace, error) {
 }
 
 func (w *Workspace) Members(teamname string) (*WorkspaceMembers, error) {
-	urlStr := w.c.requestUrl("/workspaces/%s/members", teamname)
+	return w.MembersWithOptions(&WorkspaceOptions{Workspace: teamname})
+}
+
+// MembersWithOptions is like Members, returning only wo.Fields when set.
+func (w *Workspace) MembersWithOptions(wo *WorkspaceOptions) (*WorkspaceMembers, error) {
+	urlStr, err := w.c.withFieldsParam(w.c.requestUrl("/workspaces/%s/members", wo.Workspace), wo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 	response, err := w.c.executePaginated("GET", urlStr, "", nil)
 	if err != nil {
 		return nil, err
@@ -101,7 +126,15 @@ func (w *Workspace) Members(teamname string) (*WorkspaceMembers, error) {
 }
 
 func (w *Workspace) Projects(teamname string) (*ProjectsRes, error) {
-	urlStr := w.c.requestUrl("/workspaces/%s/projects/", teamname)
+	return w.ProjectsWithOptions(&WorkspaceOptions{Workspace: teamname})
+}
+
+// ProjectsWithOptions is like Projects, returning only wo.Fields when set.
+func (w *Workspace) ProjectsWithOptions(wo *WorkspaceOptions) (*ProjectsRes, error) {
+	urlStr, err := w.c.withFieldsParam(w.c.requestUrl("/workspaces/%s/projects/", wo.Workspace), wo.Fields, true)
+	if err != nil {
+		return nil, err
+	}
 	response, err := w.c.executePaginated("GET", urlStr, "", nil)
 	if err != nil {
 		return nil, err
@@ -112,18 +145,19 @@ func (w *Workspace) Projects(teamname string) (*ProjectsRes, error) {
 
 func decodePermission(permission interface{}) *Permission {
 	permissionResponseMap := permission.(map[string]interface{})
-	if permissionResponseMap["size"].(float64) == 0 {
+	if size, ok := permissionResponseMap["size"].(float64); ok && size == 0 {
 		return nil
 	}
 
-	permissionValues := permissionResponseMap["values"].([]interface{})
+	permissionValues, _ := permissionResponseMap["values"].([]interface{})
 	if len(permissionValues) == 0 {
 		return nil
 	}
 
-	permissionValue := permissionValues[0].(map[string]interface{})
+	permissionValue, _ := permissionValues[0].(map[string]interface{})
+	permissionType, _ := permissionValue["permission"].(string)
 	return &Permission{
-		Type: permissionValue["permission"].(string),
+		Type: permissionType,
 	}
 }
 
@@ -141,7 +175,7 @@ func decodeWorkspace(workspace interface{}) (*Workspace, error) {
 
 func decodeWorkspaceList(workspaceResponse interface{}) (*WorkspaceList, error) {
 	workspaceResponseMap := workspaceResponse.(map[string]interface{})
-	workspaceMapList := workspaceResponseMap["values"].([]interface{})
+	workspaceMapList, _ := workspaceResponseMap["values"].([]interface{})
 
 	var workspaces []Workspace
 	for _, workspaceMap := range workspaceMapList {
@@ -194,7 +228,7 @@ func decodeProjects(projectResponse interface{}) (*ProjectsRes, error) {
 	}
 
 	var projects []Project
-	projectArray := projectsResponseMap["values"].([]interface{})
+	projectArray, _ := projectsResponseMap["values"].([]interface{})
 	for _, projectEntry := range projectArray {
 		var project Project
 		if err := mapstructure.Decode(projectEntry, &project); err == nil {
@@ -237,9 +271,12 @@ func decodeMembers(membersResponse interface{}) (*WorkspaceMembers, error) {
 	}
 
 	var members []User
-	userArray := responseMap["values"].([]interface{})
+	userArray, _ := responseMap["values"].([]interface{})
 	for _, userEntry := range userArray {
-		userEntryMap := userEntry.(map[string]interface{})
+		userEntryMap, ok := userEntry.(map[string]interface{})
+		if !ok || userEntryMap["user"] == nil {
+			continue
+		}
 
 		member, err := decodeUser(userEntryMap["user"])
 		if err != nil {